@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/cli"
+	"github.com/openshift/odo/pkg/odo/cli/plugins"
 	"github.com/openshift/odo/pkg/odo/cli/version"
 	"github.com/openshift/odo/pkg/odo/util"
 	"github.com/openshift/odo/pkg/odo/util/completion"
@@ -24,6 +26,10 @@ func main() {
 
 	root := cli.NewCmdOdo(cli.OdoRecommendedName, cli.OdoRecommendedName)
 	rootCmp := createCompletion(root)
+	// the plugins are only looked for when completing, as the directories of the PATH are read
+	if os.Getenv("COMP_LINE") != "" {
+		addPluginCompletions(&rootCmp, plugins.NewExecHandler(plugins.Prefix).List(root))
+	}
 	cmp := complete.New("odo", rootCmp)
 
 	// AddFlags adds the completion flags to the program flags, specifying custom names
@@ -118,3 +124,34 @@ func createCompletion(root *cobra.Command) complete.Command {
 
 	return rootCmp
 }
+
+// addPluginCompletions adds the plugins which are run by odo, and the subcommands of their metadata, to the
+// completion of the root command
+func addPluginCompletions(rootCmp *complete.Command, pluginList []plugins.Plugin) {
+	for _, plugin := range pluginList {
+		if plugin.ShadowedBy != "" {
+			continue
+		}
+		pluginCmp := complete.Command{Args: complete.PredictAnything}
+		if len(plugin.Commands) > 0 {
+			pluginCmp.Sub = make(complete.Commands)
+			for _, command := range plugin.Commands {
+				pluginCmp.Sub[command.Name] = complete.Command{Args: complete.PredictAnything}
+			}
+		}
+		*rootCmp = addSubCompletion(*rootCmp, strings.Split(plugin.Name, " "), pluginCmp)
+	}
+}
+
+// addSubCompletion returns the completion with the sub completion added under the words of its command
+func addSubCompletion(cmp complete.Command, words []string, sub complete.Command) complete.Command {
+	if cmp.Sub == nil {
+		cmp.Sub = make(complete.Commands)
+	}
+	if len(words) == 1 {
+		cmp.Sub[words[0]] = sub
+		return cmp
+	}
+	cmp.Sub[words[0]] = addSubCompletion(cmp.Sub[words[0]], words[1:], sub)
+	return cmp
+}
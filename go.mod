@@ -5,6 +5,8 @@ go 1.13
 require (
 	github.com/Netflix/go-expect v0.0.0-20201125194554-85d881c3777e
 	github.com/blang/semver v3.5.1+incompatible
+	github.com/containerd/containerd v1.4.3
+	github.com/deislabs/oras v0.8.1
 	github.com/devfile/api/v2 v2.0.0-20210408144711-a313872749ed
 	github.com/devfile/library v1.0.0-alpha.2.0.20210409194304-7a52b221a48e
 	github.com/devfile/registry-support/index/generator v0.0.0-20210407161420-cd279527f873
@@ -17,7 +19,6 @@ require (
 	github.com/go-git/go-git/v5 v5.2.0
 	github.com/gobwas/glob v0.2.3
 	github.com/golang/mock v1.5.0
-	github.com/gregjones/httpcache v0.0.0-20180305231024-9cad4c3443a7
 	github.com/hinshun/vt10x v0.0.0-20180809195222-d55458df857c
 	github.com/kubernetes-sigs/service-catalog v0.3.1
 	github.com/kylelemons/godebug v0.0.0-20170820004349-d65d576e9348
@@ -44,6 +45,7 @@ require (
 	github.com/tidwall/gjson v1.7.3
 	github.com/xtgo/uuid v0.0.0-20140804021211-a0b114877d4c // indirect
 	github.com/zalando/go-keyring v0.1.1
+	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
 	golang.org/x/term v0.0.0-20210317153231-de623e64d2a6
 	gopkg.in/AlecAivazis/survey.v1 v1.8.8
 	gopkg.in/segmentio/analytics-go.v3 v3.1.0
@@ -56,7 +58,6 @@ require (
 	k8s.io/klog/v2 v2.4.0
 	k8s.io/kubectl v0.20.1
 	sigs.k8s.io/yaml v1.2.0
-
 )
 
 replace (
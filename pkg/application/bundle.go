@@ -0,0 +1,448 @@
+package application
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	applabels "github.com/openshift/odo/pkg/application/labels"
+	componentlabels "github.com/openshift/odo/pkg/component/labels"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/odo/util/validation"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/workspace"
+)
+
+const (
+	// BundleFileName is the name of the file describing the content of a bundle
+	BundleFileName = "bundle.yaml"
+
+	bundleKind        = "ApplicationBundle"
+	devfileFileName   = "devfile.yaml"
+	envFileName       = "env.yaml"
+	componentsDirName = "components"
+	servicesDirName   = "services"
+)
+
+// Bundle describes the exported application, the devfiles and the environment files of its components being in the
+// components/<name> directories of the bundle and the manifests of its operator backed services in the services directory
+type Bundle struct {
+	metav1.TypeMeta `yaml:",inline"`
+
+	// Application is the name of the exported application
+	Application string `yaml:"Application"`
+	// Project is the project the application was exported from
+	Project string `yaml:"Project,omitempty"`
+	// Components are the exported components
+	Components []BundleComponent `yaml:"Components,omitempty"`
+	// Services are the manifest files of the operator backed services, relative to the bundle directory
+	Services []string `yaml:"Services,omitempty"`
+}
+
+// BundleComponent is a component of a bundle
+type BundleComponent struct {
+	Name string `yaml:"Name"`
+	// Context is the context directory of the component, relative to the root of the workspace
+	Context string `yaml:"Context"`
+	// DependsOn are the names of the components the component depends on
+	DependsOn []string `yaml:"DependsOn,omitempty"`
+}
+
+// bundleEnvFile is the environment file of a component in a bundle, holding the settings of the exported environment
+type bundleEnvFile struct {
+	ComponentSettings envinfo.ComponentSettings `yaml:"ComponentSettings"`
+}
+
+// ImportParameters are the parameters of the import of a bundle
+type ImportParameters struct {
+	// Application is the name of the imported application, the exported name if empty
+	Application string
+	// Project is the project the application is imported to
+	Project string
+	// Names maps the exported names of the components to their imported names
+	Names map[string]string
+	// Hosts maps the exported URL hosts, or their domains, to the imported ones
+	Hosts map[string]string
+	// Force overwrites the components existing in the import directory
+	Force bool
+}
+
+// ImportResult is the result of the import of a bundle
+type ImportResult struct {
+	// Manifests are the manifests of the services to create
+	Manifests []map[string]interface{}
+	// Links are the links of the components to recreate once they are pushed, as their service bindings are not in
+	// the bundle
+	Links []ImportedLink
+	// DroppedLinks are the links of the components to services which are not in the bundle, and are not imported
+	DroppedLinks []ImportedLink
+}
+
+// ImportedLink is a link of an imported component to an operator backed service
+type ImportedLink struct {
+	// Component is the imported name of the component
+	Component string
+	// Context is the context directory of the component, relative to the import directory
+	Context string
+	// Service is the linked service, as <kind>/<name>
+	Service string
+}
+
+// Export writes the bundle of the application to dir: the devfiles and the environment settings of the components,
+// and the manifests of the operator backed services which are not defined in the devfiles
+func Export(dir, name, project, root string, components []workspace.Component, services []unstructured.Unstructured) (Bundle, error) {
+	bundle := Bundle{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       bundleKind,
+			APIVersion: appAPIVersion,
+		},
+		Application: name,
+		Project:     project,
+	}
+
+	inlined := map[string]bool{}
+	for _, component := range components {
+		context, err := filepath.Rel(root, component.Context)
+		if err != nil {
+			return bundle, err
+		}
+		componentDir := filepath.Join(dir, componentsDirName, component.Name)
+		if err = os.MkdirAll(componentDir, 0750); err != nil {
+			return bundle, err
+		}
+
+		devfilePath := filepath.Join(component.Context, devfileFileName)
+		if err = copyFile(devfilePath, filepath.Join(componentDir, devfileFileName)); err != nil {
+			return bundle, errors.Wrapf(err, "unable to export the devfile of the component %s", component.Name)
+		}
+		if err = addInlinedServices(devfilePath, inlined); err != nil {
+			return bundle, err
+		}
+
+		envInfo, err := envinfo.NewEnvSpecificInfo(component.Context)
+		if err != nil {
+			return bundle, err
+		}
+		settings := envInfo.GetComponentSettings()
+		// the state of the component in the exported cluster is not exported
+		settings.RunMode = nil
+		settings.Cluster = nil
+		if err = util.WriteToFile(&bundleEnvFile{ComponentSettings: settings}, filepath.Join(componentDir, envFileName)); err != nil {
+			return bundle, err
+		}
+
+		bundle.Components = append(bundle.Components, BundleComponent{
+			Name:      component.Name,
+			Context:   filepath.ToSlash(context),
+			DependsOn: component.DependsOn,
+		})
+	}
+
+	for _, service := range services {
+		if inlined[serviceKey(service.GetKind(), service.GetName())] {
+			continue
+		}
+		manifest := filepath.ToSlash(filepath.Join(servicesDirName, strings.ToLower(fmt.Sprintf("%s-%s.yaml", service.GetKind(), service.GetName()))))
+		if err := writeYAML(filepath.Join(dir, manifest), portableManifest(service)); err != nil {
+			return bundle, err
+		}
+		bundle.Services = append(bundle.Services, manifest)
+	}
+
+	return bundle, util.WriteToFile(&bundle, filepath.Join(dir, BundleFileName))
+}
+
+// ReadBundle reads the bundle of the directory
+func ReadBundle(dir string) (Bundle, error) {
+	var bundle Bundle
+	filename := filepath.Join(dir, BundleFileName)
+	if !util.CheckPathExists(filename) {
+		return bundle, errors.Errorf("%s is not an application bundle, it has no %s file", dir, BundleFileName)
+	}
+	if err := util.GetFromFile(&bundle, filename); err != nil {
+		return bundle, errors.Wrapf(err, "unable to read the bundle %s", dir)
+	}
+	if bundle.Kind != bundleKind {
+		return bundle, errors.Errorf("%s is not an application bundle", dir)
+	}
+	return bundle, nil
+}
+
+// Import writes the components of the bundle to their contexts in root, adds them to the workspace of root, and
+// returns the manifests of the services to create, all of them renamed according to the parameters. The links of the
+// components are not written, as their service bindings don't exist in the project, they are returned to be recreated
+// when their services are in the bundle, and dropped otherwise
+func Import(dir, root string, bundle Bundle, parameters ImportParameters) (ImportResult, error) {
+	var result ImportResult
+	appName := parameters.Application
+	if appName == "" {
+		appName = bundle.Application
+	}
+	rename := func(name string) string {
+		if newName, ok := parameters.Names[name]; ok {
+			return newName
+		}
+		return name
+	}
+	if err := validateBundle(bundle, rename); err != nil {
+		return result, err
+	}
+
+	services := map[string]bool{}
+	for _, service := range bundle.Services {
+		filename, err := bundlePath(dir, service)
+		if err != nil {
+			return result, err
+		}
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return result, errors.Wrapf(err, "unable to read the service %s", service)
+		}
+		var manifest unstructured.Unstructured
+		if err = yaml.Unmarshal(data, &manifest.Object); err != nil {
+			return result, errors.Wrapf(err, "unable to read the service %s", service)
+		}
+		labels := manifest.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for key, value := range labels {
+			switch key {
+			case applabels.ApplicationLabel, applabels.App:
+				labels[key] = appName
+			case componentlabels.ComponentLabel:
+				labels[key] = rename(value)
+			}
+		}
+		manifest.SetLabels(labels)
+		result.Manifests = append(result.Manifests, manifest.Object)
+		services[serviceKey(strings.ToLower(manifest.GetKind()), manifest.GetName())] = true
+	}
+	// the services defined in the devfiles are created when their components are pushed
+	inlined := map[string]bool{}
+	for _, component := range bundle.Components {
+		if err := addInlinedServices(filepath.Join(dir, componentsDirName, component.Name, devfileFileName), inlined); err != nil {
+			return result, err
+		}
+	}
+	for key := range inlined {
+		services[strings.ToLower(key)] = true
+	}
+
+	var references []workspace.ComponentReference
+	for _, component := range bundle.Components {
+		context, err := bundlePath(root, component.Context)
+		if err != nil {
+			return result, err
+		}
+		envInfo, err := envinfo.NewEnvSpecificInfo(context)
+		if err != nil {
+			return result, err
+		}
+		if envInfo.Exists() && !parameters.Force {
+			return result, errors.Errorf("%s already contains a component, use --force to overwrite it", context)
+		}
+
+		var envFile bundleEnvFile
+		componentDir := filepath.Join(dir, componentsDirName, component.Name)
+		if err = util.GetFromFile(&envFile, filepath.Join(componentDir, envFileName)); err != nil {
+			return result, errors.Wrapf(err, "unable to read the settings of the component %s", component.Name)
+		}
+		settings := envFile.ComponentSettings
+		settings.Name = rename(component.Name)
+		settings.AppName = appName
+		settings.Project = parameters.Project
+		if settings.URL != nil {
+			urls := make([]localConfigProvider.LocalURL, len(*settings.URL))
+			for i, url := range *settings.URL {
+				url.Host = remapHost(url.Host, parameters.Hosts)
+				urls[i] = url
+			}
+			settings.URL = &urls
+		}
+		if settings.Link != nil {
+			for _, link := range *settings.Link {
+				imported := ImportedLink{
+					Component: settings.Name,
+					Context:   path.Clean(component.Context),
+					Service:   link.ServiceKind + "/" + link.ServiceName,
+				}
+				if services[serviceKey(strings.ToLower(link.ServiceKind), link.ServiceName)] {
+					result.Links = append(result.Links, imported)
+				} else {
+					result.DroppedLinks = append(result.DroppedLinks, imported)
+				}
+			}
+			settings.Link = nil
+		}
+
+		if err = os.MkdirAll(context, 0750); err != nil {
+			return result, err
+		}
+		if err = copyFile(filepath.Join(componentDir, devfileFileName), filepath.Join(context, devfileFileName)); err != nil {
+			return result, errors.Wrapf(err, "unable to import the devfile of the component %s", component.Name)
+		}
+		if err = envInfo.SetComponentSettings(settings); err != nil {
+			return result, err
+		}
+
+		var dependencies []string
+		for _, dependency := range component.DependsOn {
+			dependencies = append(dependencies, rename(dependency))
+		}
+		references = append(references, workspace.ComponentReference{Context: path.Clean(component.Context), DependsOn: dependencies})
+	}
+	return result, addToWorkspace(root, references)
+}
+
+// validateBundle checks that the names and the paths of the bundle can't be used to write or read files outside of
+// the bundle and import directories, as the bundle may come from an untrusted source
+func validateBundle(bundle Bundle, rename func(string) string) error {
+	for _, component := range bundle.Components {
+		if err := validation.ValidateName(component.Name); err != nil {
+			return errors.Wrap(err, "invalid component in the bundle")
+		}
+		if err := validation.ValidateName(rename(component.Name)); err != nil {
+			return errors.Wrapf(err, "unable to rename the component %s", component.Name)
+		}
+		if _, err := bundlePath("", component.Context); err != nil {
+			return err
+		}
+	}
+	for _, service := range bundle.Services {
+		if _, err := bundlePath("", service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundlePath returns the slash separated relative path of the bundle joined to base, or an error when the path is
+// absolute or not inside base
+func bundlePath(base, p string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(p))
+	if path.IsAbs(p) || filepath.IsAbs(cleaned) || filepath.VolumeName(cleaned) != "" ||
+		cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("the path %s of the bundle is not inside the bundle or the import directory", p)
+	}
+	return filepath.Join(base, cleaned), nil
+}
+
+// addToWorkspace adds the component contexts to the workspace of root, creating it if it doesn't exist
+func addToWorkspace(root string, references []workspace.ComponentReference) error {
+	ws := workspace.NewWorkspace(nil)
+	if util.CheckPathExists(workspace.GetFilename(root)) {
+		wi, err := workspace.Find(root)
+		if err != nil {
+			return err
+		}
+		ws = wi.Workspace
+	}
+	for _, reference := range references {
+		found := false
+		for i := range ws.Components {
+			if ws.Components[i].Context == reference.Context {
+				ws.Components[i] = reference
+				found = true
+			}
+		}
+		if !found {
+			ws.Components = append(ws.Components, reference)
+		}
+	}
+	return workspace.Write(root, ws)
+}
+
+// addInlinedServices adds the services defined as Kubernetes components in the devfile to inlined
+func addInlinedServices(devfilePath string, inlined map[string]bool) error {
+	devObj, err := parser.Parse(devfilePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse the devfile %s", devfilePath)
+	}
+	components, err := devObj.Data.GetComponents(parsercommon.DevfileOptions{
+		ComponentOptions: parsercommon.ComponentOptions{ComponentType: devfilev1.KubernetesComponentType},
+	})
+	if err != nil {
+		return err
+	}
+	for _, component := range components {
+		var service unstructured.Unstructured
+		if err = yaml.Unmarshal([]byte(component.Kubernetes.Inlined), &service.Object); err != nil {
+			continue
+		}
+		inlined[serviceKey(service.GetKind(), service.GetName())] = true
+	}
+	return nil
+}
+
+// serviceKey identifies an operator backed service
+func serviceKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// portableManifest returns the manifest of the service without its status and the fields set by the cluster
+func portableManifest(service unstructured.Unstructured) map[string]interface{} {
+	manifest := service.DeepCopy()
+	manifest.SetNamespace("")
+	manifest.SetUID("")
+	manifest.SetResourceVersion("")
+	manifest.SetGeneration(0)
+	manifest.SetSelfLink("")
+	manifest.SetCreationTimestamp(metav1.Time{})
+	manifest.SetManagedFields(nil)
+	manifest.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(manifest.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(manifest.Object, "status")
+	return manifest.Object
+}
+
+// remapHost returns the host with the mapped host or domain replaced, the longest mapped domain being replaced when
+// several of them match
+func remapHost(host string, hosts map[string]string) string {
+	if newHost, ok := hosts[host]; ok {
+		return newHost
+	}
+	matched := ""
+	for domain := range hosts {
+		if strings.HasSuffix(host, "."+domain) && len(domain) > len(matched) {
+			matched = domain
+		}
+	}
+	if matched == "" {
+		return host
+	}
+	return strings.TrimSuffix(host, matched) + hosts[matched]
+}
+
+// copyFile copies the file, keeping its mode
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	return util.CopyFile(src, dst, info)
+}
+
+// writeYAML writes the manifest to the file, creating its directory if needed
+func writeYAML(filename string, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0640) // #nosec G306
+}
@@ -0,0 +1,308 @@
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/workspace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const bundleTestDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi8/nodejs-12:1-36
+  - name: inlined
+    kubernetes:
+      inlined: |
+        apiVersion: postgresql.dev4devs.com/v1alpha1
+        kind: Database
+        metadata:
+          name: inlined-db
+`
+
+func TestExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "source")
+	bundleDir := filepath.Join(dir, "bundle")
+	target := filepath.Join(dir, "target")
+
+	urls := []localConfigProvider.LocalURL{{Name: "http", Port: 3000, Host: "backend.apps.example.com"}}
+	links := []envinfo.EnvInfoLink{
+		{Name: "backend-database-db", ServiceKind: "Database", ServiceName: "db"},
+		{Name: "backend-database-inlined-db", ServiceKind: "Database", ServiceName: "inlined-db"},
+		{Name: "backend-redis-cache", ServiceKind: "Redis", ServiceName: "cache"},
+	}
+	settings := map[string]envinfo.ComponentSettings{
+		"backend":  {Name: "backend", AppName: "app", Project: "source", URL: &urls, Link: &links},
+		"frontend": {Name: "frontend", AppName: "app", Project: "source"},
+	}
+	var components []workspace.Component
+	for _, name := range []string{"backend", "frontend"} {
+		context := filepath.Join(source, name)
+		if err = os.MkdirAll(context, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(context, "devfile.yaml"), []byte(bundleTestDevfile), 0644); err != nil {
+			t.Fatal(err)
+		}
+		envInfo, err := envinfo.NewEnvSpecificInfo(context)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = envInfo.SetComponentSettings(settings[name]); err != nil {
+			t.Fatal(err)
+		}
+		component := workspace.Component{Name: name, Context: context, Project: "source", App: "app"}
+		if name == "frontend" {
+			component.DependsOn = []string{"backend"}
+		}
+		components = append(components, component)
+	}
+
+	services := []unstructured.Unstructured{
+		{Object: map[string]interface{}{
+			"apiVersion": "postgresql.dev4devs.com/v1alpha1",
+			"kind":       "Database",
+			"metadata": map[string]interface{}{
+				"name":      "db",
+				"namespace": "source",
+				"uid":       "1234",
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/part-of":  "app",
+					"app.kubernetes.io/instance": "backend",
+				},
+			},
+			"spec":   map[string]interface{}{"size": int64(1)},
+			"status": map[string]interface{}{"ready": true},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "postgresql.dev4devs.com/v1alpha1",
+			"kind":       "Database",
+			"metadata":   map[string]interface{}{"name": "inlined-db"},
+		}},
+	}
+
+	if _, err = Export(bundleDir, "app", "source", source, components, services); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := ReadBundle(bundleDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantComponents := []BundleComponent{
+		{Name: "backend", Context: "backend"},
+		{Name: "frontend", Context: "frontend", DependsOn: []string{"backend"}},
+	}
+	if !reflect.DeepEqual(bundle.Components, wantComponents) {
+		t.Errorf("got the bundle components %+v, want %+v", bundle.Components, wantComponents)
+	}
+	if want := []string{"services/database-db.yaml"}; !reflect.DeepEqual(bundle.Services, want) {
+		t.Errorf("got the bundle services %v, want %v", bundle.Services, want)
+	}
+
+	result, err := Import(bundleDir, target, bundle, ImportParameters{
+		Application: "staging",
+		Project:     "target",
+		Names:       map[string]string{"backend": "api"},
+		Hosts:       map[string]string{"apps.example.com": "staging.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wi, err := workspace.Find(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := wi.GetComponents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []workspace.Component{
+		{Name: "api", Context: filepath.Join(target, "backend"), Project: "target", App: "staging"},
+		{Name: "frontend", Context: filepath.Join(target, "frontend"), Project: "target", App: "staging", DependsOn: []string{"api"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got the imported components %+v, want %+v", got, want)
+	}
+
+	envInfo, err := envinfo.NewEnvSpecificInfo(filepath.Join(target, "backend"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotURLs := envInfo.GetComponentSettings().URL
+	if gotURLs == nil || len(*gotURLs) != 1 || (*gotURLs)[0].Host != "backend.staging.example.com" {
+		t.Errorf("got the imported URLs %+v, want the host backend.staging.example.com", gotURLs)
+	}
+	if gotLinks := envInfo.GetComponentSettings().Link; gotLinks != nil {
+		t.Errorf("got the imported links %+v, want none", *gotLinks)
+	}
+	wantLinks := []ImportedLink{
+		{Component: "api", Context: "backend", Service: "Database/db"},
+		{Component: "api", Context: "backend", Service: "Database/inlined-db"},
+	}
+	if !reflect.DeepEqual(result.Links, wantLinks) {
+		t.Errorf("got the links to recreate %+v, want %+v", result.Links, wantLinks)
+	}
+	wantDropped := []ImportedLink{{Component: "api", Context: "backend", Service: "Redis/cache"}}
+	if !reflect.DeepEqual(result.DroppedLinks, wantDropped) {
+		t.Errorf("got the dropped links %+v, want %+v", result.DroppedLinks, wantDropped)
+	}
+
+	if len(result.Manifests) != 1 {
+		t.Fatalf("got %d service manifests, want 1", len(result.Manifests))
+	}
+	manifest := unstructured.Unstructured{Object: result.Manifests[0]}
+	wantLabels := map[string]string{
+		"app.kubernetes.io/part-of":  "staging",
+		"app.kubernetes.io/instance": "api",
+	}
+	if !reflect.DeepEqual(manifest.GetLabels(), wantLabels) {
+		t.Errorf("got the service labels %v, want %v", manifest.GetLabels(), wantLabels)
+	}
+	if manifest.GetNamespace() != "" || manifest.GetUID() != "" || manifest.Object["status"] != nil {
+		t.Errorf("got the cluster fields in the service manifest %v", manifest.Object)
+	}
+
+	if _, err = Import(bundleDir, target, bundle, ImportParameters{Project: "target"}); err == nil {
+		t.Errorf("got no error when importing over existing components without force")
+	}
+}
+
+func TestImportMaliciousBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bundleDir := filepath.Join(dir, "bundle")
+	target := filepath.Join(dir, "target")
+	if err = os.MkdirAll(filepath.Join(bundleDir, componentsDirName, "backend"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(bundleDir, componentsDirName, "backend", devfileFileName), []byte(bundleTestDevfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(bundleDir, componentsDirName, "backend", envFileName), []byte("ComponentSettings: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "secret.yaml"), []byte("kind: Secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		components []BundleComponent
+		services   []string
+		names      map[string]string
+	}{
+		{
+			name:       "Case 1: context escaping the import directory",
+			components: []BundleComponent{{Name: "backend", Context: "../outside"}},
+		},
+		{
+			name:       "Case 2: context escaping the import directory after cleaning",
+			components: []BundleComponent{{Name: "backend", Context: "backend/../../outside"}},
+		},
+		{
+			name:       "Case 3: absolute context",
+			components: []BundleComponent{{Name: "backend", Context: filepath.ToSlash(filepath.Join(dir, "outside"))}},
+		},
+		{
+			name:       "Case 4: component name escaping the bundle directory",
+			components: []BundleComponent{{Name: "../../backend", Context: "backend"}},
+		},
+		{
+			name:       "Case 5: component renamed to an invalid name",
+			components: []BundleComponent{{Name: "backend", Context: "backend"}},
+			names:      map[string]string{"backend": "../api"},
+		},
+		{
+			name:       "Case 6: service escaping the bundle directory",
+			components: []BundleComponent{{Name: "backend", Context: "backend"}},
+			services:   []string{"../secret.yaml"},
+		},
+		{
+			name:       "Case 7: absolute service",
+			components: []BundleComponent{{Name: "backend", Context: "backend"}},
+			services:   []string{filepath.ToSlash(filepath.Join(dir, "secret.yaml"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := Bundle{Application: "app", Components: tt.components, Services: tt.services}
+			if _, err := Import(bundleDir, target, bundle, ImportParameters{Project: "target", Names: tt.names}); err == nil {
+				t.Errorf("got no error importing the bundle")
+			}
+			for _, path := range []string{filepath.Join(dir, "outside"), target} {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Errorf("got the file %s written by the import", path)
+				}
+			}
+		})
+	}
+}
+
+func TestRemapHost(t *testing.T) {
+	hosts := map[string]string{
+		"www.example.com":  "www.example.org",
+		"apps.example.com": "apps.example.org",
+		"example.com":      "example.net",
+	}
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{
+			name: "Case 1: exact host",
+			host: "www.example.com",
+			want: "www.example.org",
+		},
+		{
+			name: "Case 2: host of a mapped domain",
+			host: "frontend.apps.example.com",
+			want: "frontend.apps.example.org",
+		},
+		{
+			name: "Case 3: host of a domain mapped with its parent domain",
+			host: "myapps.apps.example.com",
+			want: "myapps.apps.example.org",
+		},
+		{
+			name: "Case 4: host of the parent domain only",
+			host: "myapps.example.com",
+			want: "myapps.example.net",
+		},
+		{
+			name: "Case 5: host not mapped",
+			host: "myapps.example.org",
+			want: "myapps.example.org",
+		},
+		{
+			name: "Case 6: empty host",
+			host: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remapHost(tt.host, hosts); got != tt.want {
+				t.Errorf("got the host %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
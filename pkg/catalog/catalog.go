@@ -3,10 +3,15 @@ package catalog
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/openshift/odo/pkg/preference"
 	"github.com/zalando/go-keyring"
@@ -19,6 +24,7 @@ import (
 	indexSchema "github.com/devfile/registry-support/index/generator/schema"
 	registryLibrary "github.com/devfile/registry-support/registry-library/library"
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/signature"
 	"github.com/openshift/odo/pkg/util"
 	olm "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/pkg/errors"
@@ -56,33 +62,21 @@ func GetDevfileRegistries(registryName string) ([]Registry, error) {
 		return nil, err
 	}
 
-	hasName := len(registryName) != 0
-	if cfg.OdoSettings.RegistryList != nil {
-		registryList := *cfg.OdoSettings.RegistryList
-		// Loop backwards here to ensure the registry display order is correct (display latest newly added registry firstly)
-		for i := len(registryList) - 1; i >= 0; i-- {
-			registry := registryList[i]
-			if hasName {
-				if registryName == registry.Name {
-					reg := Registry{
-						Name:   registry.Name,
-						URL:    registry.URL,
-						Secure: registry.Secure,
-					}
-					devfileRegistries = append(devfileRegistries, reg)
-					return devfileRegistries, nil
-				}
-			} else {
-				reg := Registry{
-					Name:   registry.Name,
-					URL:    registry.URL,
-					Secure: registry.Secure,
-				}
-				devfileRegistries = append(devfileRegistries, reg)
-			}
+	// Registries are ordered by priority, the latest newly added registry comes first among registries with the same priority
+	for _, registry := range preference.SortRegistriesByPriority(cfg.GetRegistryList()) {
+		if len(registryName) != 0 && registryName != registry.Name {
+			continue
+		}
+		devfileRegistries = append(devfileRegistries, Registry{
+			Name:             registry.Name,
+			URL:              registry.URL,
+			Secure:           registry.Secure,
+			Priority:         registry.Priority,
+			RequireSignature: registry.RequireSignature,
+		})
+		if len(registryName) != 0 {
+			break
 		}
-	} else {
-		return nil, nil
 	}
 
 	return devfileRegistries, nil
@@ -122,72 +116,99 @@ func convertURL(URL string) (string, error) {
 
 const indexPath = "/devfiles/index.json"
 
-// getRegistryDevfiles retrieves the registry's index devfile entries
-func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponentType, err error) {
-	var devfileIndex []indexSchema.Schema
+// devfileSignatureName is the name of the detached signature of the devfile shipped with the resources of a stack
+const devfileSignatureName = "devfile.yaml" + signature.SignatureSuffix
+
+// getRegistryIndex retrieves the registry's index through the HTTP cache, the returned response
+// tells if the index had to be served from the cache without being revalidated
+func getRegistryIndex(registry Registry, cfg *preference.PreferenceInfo) (devfileIndex []indexSchema.Schema, resp util.HTTPCachedResponse, err error) {
+	var request util.HTTPRequestParams
 
 	if strings.Contains(registry.URL, "github") {
 		// Github-based registry
 		URL, err := convertURL(registry.URL)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unable to convert URL %s", registry.URL)
-		}
-		registry.URL = URL
-		indexLink := registry.URL + indexPath
-		request := util.HTTPRequestParams{
-			URL: indexLink,
+			return nil, resp, errors.Wrapf(err, "unable to convert URL %s", registry.URL)
 		}
+		request.URL = URL + indexPath
 		if registryUtil.IsSecure(registry.Name) {
 			token, err := keyring.Get(fmt.Sprintf("%s%s", util.CredentialPrefix, registry.Name), registryUtil.RegistryUser)
 			if err != nil {
-				return nil, errors.Wrap(err, "unable to get secure registry credential from keyring")
+				return nil, resp, errors.Wrap(err, "unable to get secure registry credential from keyring")
 			}
 			request.Token = token
 		}
-
-		cfg, err := preference.New()
+	} else {
+		// OCI-based registry, the index is served by the registry REST API
+		urlObj, err := url.Parse(registry.URL)
 		if err != nil {
-			return nil, err
+			return nil, resp, errors.Wrapf(err, "unable to parse URL %s", registry.URL)
 		}
+		urlObj.Path = path.Join(urlObj.Path, "index")
+		request.URL = urlObj.String()
+	}
 
-		jsonBytes, err := util.HTTPGetRequest(request, cfg.GetRegistryCacheTime())
+	resp, err = util.HTTPGetCachedRequest(request, cfg.GetRegistryCacheTime(), cfg.GetOffline())
+	if err != nil {
+		return nil, resp, errors.Wrapf(err, "unable to download the devfile index from %s", request.URL)
+	}
+
+	err = json.Unmarshal(resp.Body, &devfileIndex)
+	if err != nil {
+		if cfg.GetOffline() {
+			return nil, resp, errors.Wrapf(err, "unable to unmarshal the cached devfile index from %s", request.URL)
+		}
+		if err := util.CleanDefaultHTTPCacheDir(); err != nil {
+			log.Warning("Error while cleaning up cache dir.")
+		}
+		// we try once again
+		resp, err = util.HTTPGetCachedRequest(request, cfg.GetRegistryCacheTime(), false)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unable to download the devfile index.json from %s", indexLink)
+			return nil, resp, errors.Wrapf(err, "unable to download the devfile index from %s", request.URL)
 		}
 
-		err = json.Unmarshal(jsonBytes, &devfileIndex)
+		err = json.Unmarshal(resp.Body, &devfileIndex)
 		if err != nil {
-			if err := util.CleanDefaultHTTPCacheDir(); err != nil {
-				log.Warning("Error while cleaning up cache dir.")
-			}
-			// we try once again
-			jsonBytes, err := util.HTTPGetRequest(request, cfg.GetRegistryCacheTime())
-			if err != nil {
-				return nil, errors.Wrapf(err, "unable to download the devfile index.json from %s", indexLink)
-			}
-
-			err = json.Unmarshal(jsonBytes, &devfileIndex)
-			if err != nil {
-				return nil, errors.Wrapf(err, "unable to unmarshal the devfile index.json from %s", indexLink)
-			}
+			return nil, resp, errors.Wrapf(err, "unable to unmarshal the devfile index from %s", request.URL)
 		}
+	}
+
+	return devfileIndex, resp, nil
+}
+
+// getRegistryDevfiles retrieves the registry's index devfile entries
+func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponentType, err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return nil, err
+	}
+
+	var devfileIndex []indexSchema.Schema
+	var resp util.HTTPCachedResponse
+	if registryUtil.IsFileBasedRegistry(registry.URL) {
+		devfileIndex, _, err = getFileBasedRegistryIndex(registry)
 	} else {
-		// OCI-based registry
-		devfileIndex, err = registryLibrary.GetRegistryStacks(registry.URL)
-		if err != nil {
-			return nil, err
-		}
+		devfileIndex, resp, err = getRegistryIndex(registry, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Stale {
+		log.Warningf("Registry %s could not be reached, using its cached index from %s", registry.Name, resp.FetchedAt.Format(time.RFC1123))
 	}
 
 	for _, devfileIndexEntry := range devfileIndex {
 		stackDevfile := DevfileComponentType{
 			Name:        devfileIndexEntry.Name,
+			Version:     devfileIndexEntry.Version,
 			DisplayName: devfileIndexEntry.DisplayName,
 			Description: devfileIndexEntry.Description,
 			Link:        devfileIndexEntry.Links["self"],
 			Registry:    registry,
 			Language:    devfileIndexEntry.Language,
 			Tags:        devfileIndexEntry.Tags,
+			Stale:       resp.Stale,
 		}
 		registryDevfiles = append(registryDevfiles, stackDevfile)
 	}
@@ -195,6 +216,94 @@ func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponent
 	return registryDevfiles, nil
 }
 
+// PullStackFromRegistry pulls the stack with all its resources from the OCI-based or file-based registry to destDir,
+// the given version of the stack is pulled if version isn't empty.
+// Pulled stacks are kept in the local cache, in offline mode or when the registry can't be reached
+// the cached stack is used instead.
+// The detached signature of the devfile shipped with the stack is returned instead of being copied to destDir,
+// it is nil if the stack isn't signed.
+func PullStackFromRegistry(registry Registry, stack string, version string, destDir string, offline bool) ([]byte, error) {
+	if registryUtil.IsFileBasedRegistry(registry.URL) {
+		stackDir, err := getFileBasedStackDir(registry, stack, version)
+		if err != nil {
+			return nil, err
+		}
+		return copyStack(stackDir, destDir)
+	}
+
+	stackCacheName := stack
+	if version != "" {
+		stackCacheName = stack + "@" + version
+	}
+	stackCacheDir := filepath.Join(util.GetCacheDir(), "stacks", util.GetDNS1123Name(registry.Name), stackCacheName)
+
+	if !offline {
+		tmpDir, err := ioutil.TempDir("", "odostack")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		if version == "" {
+			err = registryLibrary.PullStackFromRegistry(registry.URL, stack, tmpDir)
+		} else {
+			err = pullStackVersionFromRegistry(registry, stack, version, tmpDir)
+		}
+		if err != nil {
+			if !util.CheckPathExists(stackCacheDir) {
+				return nil, err
+			}
+			log.Warningf("Registry %s could not be reached, using the cached stack %s", registry.Name, stack)
+		} else {
+			// refresh the cached stack, failing to do so shouldn't prevent using the pulled one
+			if err = os.RemoveAll(stackCacheDir); err == nil {
+				err = util.CopyDirWithFS(tmpDir, stackCacheDir)
+			}
+			if err != nil {
+				klog.V(4).Infof("Unable to cache stack %s from registry %s: %v", stack, registry.Name, err)
+				return copyStack(tmpDir, destDir)
+			}
+		}
+	}
+
+	if !util.CheckPathExists(stackCacheDir) {
+		return nil, errors.Wrapf(util.ErrNotCached, "unable to get stack %s from registry %s in offline mode", stack, registry.Name)
+	}
+	return copyStack(stackCacheDir, destDir)
+}
+
+// copyStack copies the resources of the stack in stackDir to destDir, except the signature of the devfile which
+// is returned, nil if there is none
+func copyStack(stackDir string, destDir string) ([]byte, error) {
+	entries, err := ioutil.ReadDir(stackDir)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(destDir, 0755); err != nil {
+		return nil, err
+	}
+
+	var sig []byte
+	for _, entry := range entries {
+		src := filepath.Join(stackDir, entry.Name())
+		dst := filepath.Join(destDir, entry.Name())
+		switch {
+		case entry.Name() == devfileSignatureName && !entry.IsDir():
+			if sig, err = ioutil.ReadFile(src); err != nil {
+				return nil, errors.Wrapf(err, "unable to read the signature of the devfile %s", src)
+			}
+		case entry.IsDir():
+			err = util.CopyDirWithFS(src, dst)
+		default:
+			err = util.CopyFileWithFs(src, dst)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sig, nil
+}
+
 // ListDevfileComponents lists all the available devfile components
 func ListDevfileComponents(registryName string) (DevfileComponentTypeList, error) {
 	catalogDevfileList := &DevfileComponentTypeList{}
@@ -214,7 +323,7 @@ func ListDevfileComponents(registryName string) (DevfileComponentTypeList, error
 	devfileIndicesMutex := &sync.Mutex{}
 	retrieveRegistryIndices := util.NewConcurrentTasks(len(catalogDevfileList.DevfileRegistries))
 
-	// The 2D slice index is the priority of the registry (highest priority has highest index)
+	// The 2D slice index is the rank of the registry (highest priority has lowest index)
 	// and the element is the devfile slice that belongs to the registry
 	registrySlice := make([][]DevfileComponentType, len(catalogDevfileList.DevfileRegistries))
 	for regPriority, reg := range catalogDevfileList.DevfileRegistries {
@@ -224,6 +333,10 @@ func ListDevfileComponents(registryName string) (DevfileComponentTypeList, error
 		retrieveRegistryIndices.Add(util.ConcurrentTask{ToRun: func(errChannel chan error) {
 			registryDevfiles, err := getRegistryDevfiles(registry)
 			if err != nil {
+				if errors.Cause(err) == util.ErrNotCached {
+					log.Warningf("Registry %s has not been cached yet and can't be used in offline mode\n", registry.Name)
+					return
+				}
 				log.Warningf("Registry %s is not set up properly with error: %v, please check the registry URL and credential (refer `odo registry update --help`)\n", registry.Name, err)
 				return
 			}
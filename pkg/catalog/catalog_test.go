@@ -186,7 +186,10 @@ OdoSettings:
   - Name: DefaultDevfileRegistry
     URL: https://registry.devfile.io
   - Name: CheDevfileRegistry
-    URL: https://che-devfile-registry.openshift.io/`,
+    URL: https://che-devfile-registry.openshift.io/
+  - Name: LowPriorityRegistry
+    URL: https://registry.example.com
+    Priority: -1`,
 	))
 	if err != nil {
 		t.Error(err)
@@ -214,6 +217,12 @@ OdoSettings:
 					URL:    "https://registry.devfile.io",
 					Secure: false,
 				},
+				{
+					Name:     "LowPriorityRegistry",
+					URL:      "https://registry.example.com",
+					Secure:   false,
+					Priority: -1,
+				},
 			},
 		},
 		{
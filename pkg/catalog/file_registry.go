@@ -0,0 +1,246 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devfile/library/pkg/devfile/parser"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/pkg/errors"
+	"github.com/zalando/go-keyring"
+	"k8s.io/klog"
+
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+)
+
+const (
+	// registryIndexFile is the name of the index file of a file-based registry
+	registryIndexFile = "index.json"
+	// registryStacksDir is the directory holding the stacks in the devfile registry repository layout
+	registryStacksDir = "stacks"
+	// registryDevfilesDir is the directory holding the index and the stacks in the Git-based registry layout
+	registryDevfilesDir = "devfiles"
+)
+
+// fileURLToPath converts a file:// URL to a path in the local filesystem
+func fileURLToPath(registryURL string) string {
+	return filepath.FromSlash(strings.TrimPrefix(registryURL, "file://"))
+}
+
+// getFileBasedRegistryDir returns the directory holding the registry,
+// registries distributed as tarballs are extracted in the cache directory first
+func getFileBasedRegistryDir(registry Registry) (string, error) {
+	if !registryUtil.IsTarballRegistry(registry.URL) {
+		dir := fileURLToPath(registry.URL)
+		info, err := os.Stat(dir)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to access registry %s", registry.Name)
+		}
+		if !info.IsDir() {
+			return "", errors.Errorf("registry %s should either be a directory or a .tar.gz archive", registry.Name)
+		}
+		return dir, nil
+	}
+
+	sum := sha256.Sum256([]byte(registry.URL))
+	extractDir := filepath.Join(util.GetCacheDir(), "registries", util.GetDNS1123Name(registry.Name)+"-"+hex.EncodeToString(sum[:8]))
+
+	var tarball string
+	if registryUtil.IsLocalRegistry(registry.URL) {
+		tarball = fileURLToPath(registry.URL)
+		tarballInfo, err := os.Stat(tarball)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to access registry %s", registry.Name)
+		}
+		// the tarball has already been extracted and hasn't changed since
+		if extractInfo, err := os.Stat(extractDir); err == nil && extractInfo.ModTime().After(tarballInfo.ModTime()) {
+			return extractDir, nil
+		}
+	} else {
+		cfg, err := preference.New()
+		if err != nil {
+			return "", err
+		}
+		request := util.HTTPRequestParams{
+			URL: registry.URL,
+		}
+		if registryUtil.IsSecure(registry.Name) {
+			token, err := keyring.Get(fmt.Sprintf("%s%s", util.CredentialPrefix, registry.Name), registryUtil.RegistryUser)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to get secure registry credential from keyring")
+			}
+			request.Token = token
+		}
+		resp, err := util.HTTPGetCachedRequest(request, cfg.GetRegistryCacheTime(), cfg.GetOffline())
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to download registry %s", registry.Name)
+		}
+		// the cached tarball has already been extracted
+		if resp.FromCache && util.CheckPathExists(extractDir) {
+			return extractDir, nil
+		}
+
+		f, err := ioutil.TempFile("", "odoregistry*.tar.gz")
+		if err != nil {
+			return "", err
+		}
+		tarball = f.Name()
+		defer os.Remove(tarball)
+		_, err = f.Write(resp.Body)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	klog.V(4).Infof("Extracting registry %s to %s", registry.Name, extractDir)
+	if err := os.RemoveAll(extractDir); err != nil {
+		return "", err
+	}
+	if _, err := util.Untar(tarball, extractDir); err != nil {
+		// don't leave a partially extracted registry behind
+		_ = os.RemoveAll(extractDir)
+		return "", errors.Wrapf(err, "unable to extract registry %s", registry.Name)
+	}
+	return extractDir, nil
+}
+
+// findRegistryIndex looks for the index of the registry stored in dir, it supports both the devfile registry
+// repository layout (index.json and stacks/ at the root) and the Git-based registry layout (devfiles/index.json).
+// Tarballs often wrap the registry in a single top-level directory, which is looked into as well.
+func findRegistryIndex(dir string) (string, error) {
+	for _, candidate := range []string{
+		filepath.Join(dir, registryIndexFile),
+		filepath.Join(dir, registryDevfilesDir, registryIndexFile),
+	} {
+		if util.CheckPathExists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 1 && files[0].IsDir() {
+		return findRegistryIndex(filepath.Join(dir, files[0].Name()))
+	}
+	return "", errors.Errorf("unable to find %s in %s, the registry should follow the devfile registry layout", registryIndexFile, dir)
+}
+
+// getFileBasedRegistryIndex reads the index of a file-based registry and returns it along with its location
+func getFileBasedRegistryIndex(registry Registry) (devfileIndex []indexSchema.Schema, indexFile string, err error) {
+	dir, err := getFileBasedRegistryDir(registry)
+	if err != nil {
+		return nil, "", err
+	}
+
+	indexFile, err = findRegistryIndex(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	jsonBytes, err := ioutil.ReadFile(indexFile)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to read the devfile index from %s", indexFile)
+	}
+	err = json.Unmarshal(jsonBytes, &devfileIndex)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to unmarshal the devfile index from %s", indexFile)
+	}
+	return devfileIndex, indexFile, nil
+}
+
+// getFileBasedStackDir returns the directory holding the devfile and the resources of the stack,
+// the first stack with the given name is used if version is empty
+func getFileBasedStackDir(registry Registry, stack string, version string) (string, error) {
+	devfileIndex, indexFile, err := getFileBasedRegistryIndex(registry)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range devfileIndex {
+		if entry.Name != stack || (version != "" && entry.Version != version) {
+			continue
+		}
+		indexDir := filepath.Dir(indexFile)
+		candidates := []string{
+			filepath.Join(indexDir, registryStacksDir, stack),
+			filepath.Join(indexDir, stack),
+		}
+		// Git-based registry indexes link the devfile relative to the registry root
+		if self := entry.Links["self"]; strings.HasSuffix(self, ".yaml") {
+			candidates = append([]string{
+				filepath.Dir(filepath.Join(filepath.Dir(indexDir), filepath.FromSlash(self))),
+				filepath.Dir(filepath.Join(indexDir, filepath.FromSlash(self))),
+			}, candidates...)
+		}
+		for _, candidate := range candidates {
+			if util.CheckPathExists(filepath.Join(candidate, "devfile.yaml")) {
+				return candidate, nil
+			}
+		}
+		return "", errors.Errorf("unable to find the devfile of stack %s in registry %s", stack, registry.Name)
+	}
+	if version != "" {
+		return "", errors.Errorf("version %s of stack %s does not exist in the registry %s", version, stack, registry.Name)
+	}
+	return "", errors.Errorf("stack %s does not exist in the registry %s", stack, registry.Name)
+}
+
+// GetFileBasedRegistryDevfile returns the content of the devfile of the stack from a file-based registry
+func GetFileBasedRegistryDevfile(registry Registry, stack string) ([]byte, error) {
+	stackDir, err := getFileBasedStackDir(registry, stack, "")
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(filepath.Join(stackDir, "devfile.yaml"))
+}
+
+// ValidateFileBasedRegistry checks that the registry URL points to a directory or a tarball
+// following the devfile registry layout, remote tarballs are only checked to be valid URLs
+func ValidateFileBasedRegistry(registryName string, registryURL string) error {
+	if !registryUtil.IsLocalRegistry(registryURL) {
+		return util.ValidateURL(registryURL)
+	}
+	_, _, err := getFileBasedRegistryIndex(Registry{Name: registryName, URL: registryURL})
+	return err
+}
+
+// ResolveStarterProjects makes the zip starter projects of a stack from a file-based registry point to archives
+// shipped within the registry, zip locations without a scheme are relative to the stack directory
+func ResolveStarterProjects(registry Registry, stack string, version string, devObj parser.DevfileObj) error {
+	starterProjects, err := devObj.Data.GetStarterProjects(parsercommon.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	stackDir := ""
+	for _, starterProject := range starterProjects {
+		if starterProject.Zip == nil || strings.Contains(starterProject.Zip.Location, "://") {
+			continue
+		}
+		if stackDir == "" {
+			stackDir, err = getFileBasedStackDir(registry, stack, version)
+			if err != nil {
+				return err
+			}
+		}
+		location, err := filepath.Abs(filepath.Join(stackDir, filepath.FromSlash(starterProject.Zip.Location)))
+		if err != nil {
+			return err
+		}
+		starterProject.Zip.Location = "file://" + filepath.ToSlash(location)
+		devObj.Data.UpdateStarterProject(starterProject)
+	}
+	return nil
+}
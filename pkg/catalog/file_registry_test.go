@@ -0,0 +1,232 @@
+package catalog
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/preference"
+)
+
+const testRegistryIndex = `[
+	{
+		"name": "nodejs",
+		"displayName": "NodeJS Runtime",
+		"description": "Stack with NodeJS 12",
+		"tags": ["NodeJS", "Express"],
+		"language": "nodejs",
+		"links": {
+			"self": "devfile-catalog/nodejs:latest"
+		}
+	}
+]`
+
+const testRegistryDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+`
+
+// createTestRegistry creates a registry following the devfile registry repository layout in dir
+func createTestRegistry(t *testing.T, dir string) {
+	files := map[string]string{
+		"index.json":                 testRegistryIndex,
+		"stacks/nodejs/devfile.yaml": testRegistryDevfile,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// createTestRegistryTarball archives the registry stored in dir within a top-level registry/ directory
+func createTestRegistryTarball(t *testing.T, dir string, tarball string) {
+	f, err := os.Create(tarball)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gzWriter := gzip.NewWriter(f)
+	defer gzWriter.Close()
+	tarWriter := tar.NewWriter(gzWriter)
+	defer tarWriter.Close()
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:     filepath.ToSlash(filepath.Join("registry", rel)),
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(content)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileBasedRegistry(t *testing.T) {
+	tempConfigFile, err := ioutil.TempFile("", "odoconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tempConfigFile.Name())
+	defer tempConfigFile.Close()
+	os.Setenv(preference.GlobalConfigEnvName, tempConfigFile.Name())
+	defer os.Unsetenv(preference.GlobalConfigEnvName)
+
+	registryDir, err := ioutil.TempDir("", "odoregistry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(registryDir)
+	createTestRegistry(t, registryDir)
+
+	tarballDir, err := ioutil.TempDir("", "odoregistrytarball")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tarballDir)
+	tarball := filepath.Join(tarballDir, "registry.tar.gz")
+	createTestRegistryTarball(t, registryDir, tarball)
+
+	tests := []struct {
+		name     string
+		registry Registry
+	}{
+		{
+			name:     "Case 1: Registry stored in a directory",
+			registry: Registry{Name: "LocalRegistry", URL: "file://" + filepath.ToSlash(registryDir)},
+		},
+		{
+			name:     "Case 2: Registry stored in a tarball",
+			registry: Registry{Name: "TarballRegistry", URL: "file://" + filepath.ToSlash(tarball)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFileBasedRegistry(tt.registry.Name, tt.registry.URL); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+
+			got, err := getRegistryDevfiles(tt.registry)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := []DevfileComponentType{
+				{
+					Name:        "nodejs",
+					DisplayName: "NodeJS Runtime",
+					Description: "Stack with NodeJS 12",
+					Link:        "devfile-catalog/nodejs:latest",
+					Registry:    tt.registry,
+					Language:    "nodejs",
+					Tags:        []string{"NodeJS", "Express"},
+				},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Got: %v, want: %v", got, want)
+			}
+
+			devfileData, err := GetFileBasedRegistryDevfile(tt.registry, "nodejs")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(devfileData) != testRegistryDevfile {
+				t.Errorf("Got devfile %q, want %q", string(devfileData), testRegistryDevfile)
+			}
+
+			if _, err = GetFileBasedRegistryDevfile(tt.registry, "java"); err == nil {
+				t.Errorf("expected an error for a stack that isn't in the registry")
+			}
+		})
+	}
+}
+
+func TestPullStackFromFileBasedRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		sig     string
+		wantSig []byte
+	}{
+		{
+			name: "Case 1: Unsigned stack",
+		},
+		{
+			name:    "Case 2: Signed stack",
+			sig:     "signature",
+			wantSig: []byte("signature"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registryDir, err := ioutil.TempDir("", "odoregistry")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(registryDir)
+			createTestRegistry(t, registryDir)
+			stackDir := filepath.Join(registryDir, "stacks", "nodejs")
+			if err = os.MkdirAll(filepath.Join(stackDir, "resources"), 0750); err != nil {
+				t.Fatal(err)
+			}
+			if err = ioutil.WriteFile(filepath.Join(stackDir, "resources", "README.md"), []byte("nodejs"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if tt.sig != "" {
+				if err = ioutil.WriteFile(filepath.Join(stackDir, devfileSignatureName), []byte(tt.sig), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			destDir, err := ioutil.TempDir("", "odocomponent")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(destDir)
+
+			registry := Registry{Name: "LocalRegistry", URL: "file://" + filepath.ToSlash(registryDir)}
+			sig, err := PullStackFromRegistry(registry, "nodejs", "", destDir, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sig, tt.wantSig) {
+				t.Errorf("Got the signature %q, want %q", sig, tt.wantSig)
+			}
+
+			for _, name := range []string{"devfile.yaml", filepath.Join("resources", "README.md")} {
+				if _, err = os.Stat(filepath.Join(destDir, name)); err != nil {
+					t.Errorf("the resource %s of the stack was not pulled: %v", name, err)
+				}
+			}
+			if _, err = os.Stat(filepath.Join(destDir, devfileSignatureName)); !os.IsNotExist(err) {
+				t.Errorf("the signature of the devfile was copied to the component directory")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package catalog
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/deislabs/oras/pkg/content"
+	orasctx "github.com/deislabs/oras/pkg/context"
+	"github.com/deislabs/oras/pkg/oras"
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	registryLibrary "github.com/devfile/registry-support/registry-library/library"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+)
+
+// ParseStackReference parses a stack reference of the form [registry/]stack[@version]
+func ParseStackReference(reference string) (StackReference, error) {
+	var stackRef StackReference
+	name := reference
+
+	if i := strings.LastIndex(name, "@"); i != -1 {
+		stackRef.Version = name[i+1:]
+		name = name[:i]
+		if stackRef.Version == "" {
+			return StackReference{}, errors.Errorf("invalid stack reference %q, the version is missing after @", reference)
+		}
+	}
+
+	if i := strings.Index(name, "/"); i != -1 {
+		stackRef.Registry = name[:i]
+		name = name[i+1:]
+		if stackRef.Registry == "" || strings.Contains(name, "/") {
+			return StackReference{}, errors.Errorf("invalid stack reference %q, it should be [registry/]stack[@version]", reference)
+		}
+	}
+
+	if name == "" {
+		return StackReference{}, errors.Errorf("invalid stack reference %q, the stack name is missing", reference)
+	}
+	stackRef.Name = name
+	return stackRef, nil
+}
+
+// String returns the stack reference in the [registry/]stack[@version] form
+func (s StackReference) String() string {
+	reference := s.Name
+	if s.Registry != "" {
+		reference = s.Registry + "/" + reference
+	}
+	if s.Version != "" {
+		reference = reference + "@" + s.Version
+	}
+	return reference
+}
+
+// isNewerStackVersion returns true if version is newer than other, versions that aren't valid
+// semantic versions are considered older than the valid ones and are compared as strings
+func isNewerStackVersion(version string, other string) bool {
+	v, err := semver.ParseTolerant(version)
+	o, otherErr := semver.ParseTolerant(other)
+	switch {
+	case err == nil && otherErr == nil:
+		return v.GT(o)
+	case err == nil || otherErr == nil:
+		return err == nil
+	default:
+		return version > other
+	}
+}
+
+// ResolveStack returns the stack matching the reference among the items, which are expected to be ordered by registry priority
+// as returned by ListDevfileComponents. If the registry isn't part of the reference the stack from the registry with the
+// highest priority is used, and the newest version of the stack is used if the version isn't part of the reference.
+func ResolveStack(items []DevfileComponentType, stackRef StackReference) (DevfileComponentType, bool) {
+	var resolved DevfileComponentType
+	found := false
+	for _, item := range items {
+		if item.Name != stackRef.Name {
+			continue
+		}
+		if stackRef.Registry != "" && item.Registry.Name != stackRef.Registry {
+			continue
+		}
+		if stackRef.Version != "" && item.Version != stackRef.Version {
+			continue
+		}
+		if !found {
+			resolved = item
+			found = true
+			continue
+		}
+		// only the versions published by the selected registry are considered
+		if item.Registry.Name == resolved.Registry.Name && isNewerStackVersion(item.Version, resolved.Version) {
+			resolved = item
+		}
+	}
+	return resolved, found
+}
+
+// GetStackConflicts returns the stacks published by several registries, the items are expected to be ordered
+// by registry priority as returned by ListDevfileComponents
+func GetStackConflicts(items []DevfileComponentType) []StackConflict {
+	var conflicts []StackConflict
+	registries := make(map[string][]string)
+	var names []string
+
+	for _, item := range items {
+		stackRegistries, ok := registries[item.Name]
+		if !ok {
+			names = append(names, item.Name)
+		}
+		if !util.In(stackRegistries, item.Registry.Name) {
+			registries[item.Name] = append(stackRegistries, item.Registry.Name)
+		}
+	}
+
+	for _, name := range names {
+		if len(registries[name]) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, StackConflict{
+			Name:       name,
+			Selected:   registries[name][0],
+			Registries: registries[name],
+		})
+	}
+	return conflicts
+}
+
+// pullStackVersionFromRegistry pulls the given version of the stack with all its resources from the OCI-based registry
+// to destDir, the registry library only pulls the first stack of the index matching the name
+func pullStackVersionFromRegistry(registry Registry, stack string, version string, destDir string) error {
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+	devfileIndex, _, err := getRegistryIndex(registry, cfg)
+	if err != nil {
+		return err
+	}
+
+	var stackIndex *indexSchema.Schema
+	for i := range devfileIndex {
+		if devfileIndex[i].Name == stack && devfileIndex[i].Version == version {
+			stackIndex = &devfileIndex[i]
+			break
+		}
+	}
+	if stackIndex == nil {
+		return errors.Errorf("version %s of stack %s does not exist in the registry %s", version, stack, registry.Name)
+	}
+
+	urlObj, err := url.Parse(registry.URL)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse URL %s", registry.URL)
+	}
+	resolver := docker.NewResolver(docker.ResolverOptions{PlainHTTP: urlObj.Scheme != "https"})
+	ref := path.Join(urlObj.Host, stackIndex.Links["self"])
+	fileStore := content.NewFileStore(destDir)
+	defer fileStore.Close()
+
+	_, _, err = oras.Pull(orasctx.Background(), resolver, ref, fileStore, oras.WithAllowedMediaTypes(registryLibrary.DevfileAllMediaTypesList))
+	if err != nil {
+		return errors.Wrapf(err, "failed to pull stack %s from %s", stack, ref)
+	}
+
+	// the resources of the stack are pulled as a single archive
+	archivePath := filepath.Join(destDir, "archive.tar")
+	if util.CheckPathExists(archivePath) {
+		if _, err = util.Untar(archivePath, destDir); err != nil {
+			return errors.Wrapf(err, "unable to extract the resources of stack %s", stack)
+		}
+		return os.Remove(archivePath)
+	}
+	return nil
+}
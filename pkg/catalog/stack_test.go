@@ -0,0 +1,160 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStackReference(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      StackReference
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: Stack name only",
+			reference: "java-springboot",
+			want:      StackReference{Name: "java-springboot"},
+		},
+		{
+			name:      "Case 2: Stack pinned to a registry",
+			reference: "InternalRegistry/java-springboot",
+			want:      StackReference{Registry: "InternalRegistry", Name: "java-springboot"},
+		},
+		{
+			name:      "Case 3: Stack pinned to a registry and a version",
+			reference: "InternalRegistry/java-springboot@1.1.0",
+			want:      StackReference{Registry: "InternalRegistry", Name: "java-springboot", Version: "1.1.0"},
+		},
+		{
+			name:      "Case 4: Stack pinned to a version",
+			reference: "java-springboot@1.1.0",
+			want:      StackReference{Name: "java-springboot", Version: "1.1.0"},
+		},
+		{
+			name:      "Case 5: Missing version",
+			reference: "java-springboot@",
+			wantErr:   true,
+		},
+		{
+			name:      "Case 6: Missing stack name",
+			reference: "InternalRegistry/",
+			wantErr:   true,
+		},
+		{
+			name:      "Case 7: Too many path elements",
+			reference: "InternalRegistry/java/springboot",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStackReference(tt.reference)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+			if err == nil && got.String() != tt.reference {
+				t.Errorf("Got reference %q, want %q", got.String(), tt.reference)
+			}
+		})
+	}
+}
+
+func TestResolveStack(t *testing.T) {
+	internal := Registry{Name: "InternalRegistry", Priority: 10}
+	public := Registry{Name: "DefaultDevfileRegistry"}
+	// items are ordered by registry priority as returned by ListDevfileComponents
+	items := []DevfileComponentType{
+		{Name: "java-springboot", Version: "1.0.0", Registry: internal},
+		{Name: "java-springboot", Version: "1.2.0", Registry: internal},
+		{Name: "java-springboot", Version: "1.1.0", Registry: internal},
+		{Name: "java-springboot", Version: "2.0.0", Registry: public},
+		{Name: "nodejs", Registry: public},
+	}
+
+	tests := []struct {
+		name      string
+		reference StackReference
+		want      DevfileComponentType
+		wantFound bool
+	}{
+		{
+			name:      "Case 1: Newest version from the registry with the highest priority",
+			reference: StackReference{Name: "java-springboot"},
+			want:      items[1],
+			wantFound: true,
+		},
+		{
+			name:      "Case 2: Stack pinned to a registry",
+			reference: StackReference{Registry: "DefaultDevfileRegistry", Name: "java-springboot"},
+			want:      items[3],
+			wantFound: true,
+		},
+		{
+			name:      "Case 3: Stack pinned to a version",
+			reference: StackReference{Name: "java-springboot", Version: "1.1.0"},
+			want:      items[2],
+			wantFound: true,
+		},
+		{
+			name:      "Case 4: Version only published by a registry with a lower priority",
+			reference: StackReference{Name: "java-springboot", Version: "2.0.0"},
+			want:      items[3],
+			wantFound: true,
+		},
+		{
+			name:      "Case 5: Stack without version",
+			reference: StackReference{Name: "nodejs"},
+			want:      items[4],
+			wantFound: true,
+		},
+		{
+			name:      "Case 6: Unknown version",
+			reference: StackReference{Name: "nodejs", Version: "1.0.0"},
+		},
+		{
+			name:      "Case 7: Stack missing from the registry",
+			reference: StackReference{Registry: "InternalRegistry", Name: "nodejs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ResolveStack(items, tt.reference)
+			if found != tt.wantFound {
+				t.Fatalf("Got found %t, want %t", found, tt.wantFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetStackConflicts(t *testing.T) {
+	items := []DevfileComponentType{
+		{Name: "java-springboot", Version: "1.0.0", Registry: Registry{Name: "InternalRegistry"}},
+		{Name: "java-springboot", Version: "1.1.0", Registry: Registry{Name: "InternalRegistry"}},
+		{Name: "nodejs", Registry: Registry{Name: "InternalRegistry"}},
+		{Name: "java-springboot", Registry: Registry{Name: "CheDevfileRegistry"}},
+		{Name: "java-springboot", Registry: Registry{Name: "DefaultDevfileRegistry"}},
+		{Name: "python", Registry: Registry{Name: "DefaultDevfileRegistry"}},
+	}
+	want := []StackConflict{
+		{
+			Name:       "java-springboot",
+			Selected:   "InternalRegistry",
+			Registries: []string{"InternalRegistry", "CheDevfileRegistry", "DefaultDevfileRegistry"},
+		},
+	}
+
+	got := GetStackConflicts(items)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got: %v, want: %v", got, want)
+	}
+}
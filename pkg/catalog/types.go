@@ -14,20 +14,41 @@ type ComponentType struct {
 
 // Registry is the main struct of devfile registry
 type Registry struct {
-	Name   string
-	URL    string
-	Secure bool
+	Name             string
+	URL              string
+	Secure           bool
+	Priority         int
+	RequireSignature bool
 }
 
 // DevfileComponentType is the main struct for devfile catalog components
 type DevfileComponentType struct {
 	Name        string
+	Version     string
 	DisplayName string
 	Description string
 	Link        string
 	Registry    Registry
 	Language    string
 	Tags        []string
+	// Stale is true if the entry comes from a cached registry index that couldn't be revalidated
+	Stale bool
+}
+
+// StackReference identifies a devfile stack as [registry/]stack[@version],
+// the registry and the version are optional
+type StackReference struct {
+	Registry string
+	Name     string
+	Version  string
+}
+
+// StackConflict describes a stack published by several registries, Registries are ordered by priority
+// and the stack from the Selected registry is the one used when the registry isn't specified
+type StackConflict struct {
+	Name       string   `json:"name"`
+	Selected   string   `json:"selected"`
+	Registries []string `json:"registries"`
 }
 
 // ComponentSpec is the spec for ComponentType
@@ -115,7 +115,7 @@ func GetDefaultComponentName(componentPath string, componentPathType config.SrcT
 	}
 
 	// If there's no prefix in config file, or its value is empty string use safe default - the current directory along with component type
-	if cfg.OdoSettings.NamePrefix == nil || *cfg.OdoSettings.NamePrefix == "" {
+	if cfg.GetNamePrefix() == "" {
 		prefix, err = GetComponentDir(componentPath, componentPathType)
 		if err != nil {
 			return "", errors.Wrap(err, "unable to generate random component name")
@@ -123,7 +123,7 @@ func GetDefaultComponentName(componentPath string, componentPathType config.SrcT
 		prefix = util.TruncateString(prefix, componentRandomNamePartsMaxLen)
 	} else {
 		// Set the required prefix into componentName
-		prefix = *cfg.OdoSettings.NamePrefix
+		prefix = cfg.GetNamePrefix()
 	}
 
 	// Generate unique name for the component using prefix and unique random suffix
@@ -28,6 +28,8 @@ type ComponentFullDescriptionSpec struct {
 	Storage    storage.StorageList `json:"storages,omitempty"`
 	Env        []corev1.EnvVar     `json:"env,omitempty"`
 	Ports      []string            `json:"ports,omitempty"`
+	// Cluster is the cluster the component is bound to
+	Cluster *envinfo.ClusterBinding `json:"cluster,omitempty"`
 }
 
 // ComponentFullDescription describes a component fully
@@ -145,6 +147,9 @@ func NewComponentFullDescriptionFromClientAndLocalConfig(client *occlient.Client
 	}
 
 	cfd.fillEmptyFields(componentDesc, componentName, applicationName, projectName)
+	if envInfo != nil {
+		cfd.Spec.Cluster = envInfo.GetClusterBinding()
+	}
 
 	var urls urlpkg.URLList
 
@@ -205,6 +210,11 @@ func (cfd *ComponentFullDescription) Print(client *occlient.Client) error {
 		log.Describef("Source: ", cfd.Spec.Source)
 	}
 
+	// Cluster
+	if cfd.Spec.Cluster != nil {
+		log.Describef("Cluster: ", fmt.Sprintf("%s (context %s)", cfd.Spec.Cluster.Server, cfd.Spec.Cluster.Context))
+	}
+
 	// Env
 	if cfd.Spec.Env != nil {
 
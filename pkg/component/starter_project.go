@@ -1,6 +1,9 @@
 package component
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -11,11 +14,14 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/log"
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/signature"
 	"github.com/openshift/odo/pkg/util"
 
 	"github.com/pkg/errors"
+	"k8s.io/klog"
 )
 
 const (
@@ -25,12 +31,12 @@ const (
 
 var DevfilePath = filepath.Join("./", devFile)
 
-func checkoutProject(subDir, zipURL, path, starterToken string) error {
+func checkoutProject(subDir, zipURL, path, starterToken string, verify func(pathToZip string) error) error {
 
 	if subDir == "" {
 		subDir = "/"
 	}
-	err := util.GetAndExtractZip(zipURL, path, subDir, starterToken)
+	err := util.GetAndExtractZipWithVerification(zipURL, path, subDir, starterToken, verify)
 	if err != nil {
 		return errors.Wrap(err, "failed to download and extract project zip folder")
 	}
@@ -72,14 +78,18 @@ func GetStarterProject(projects []devfilev1.StarterProject, projectPassed string
 }
 
 // DownloadStarterProject Downloads first starter project from list of starter projects in devfile
-func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedToken string, contextDir string) error {
+// Downloaded starter projects are kept in the local cache, in offline mode only the cached ones can be used.
+// The signature of zip starter projects is checked with verifier, git starter projects are refused when requireSignature
+// is set as they can't be verified. The returned provenance records the digest of the zip archive or the commit of the
+// git repository the project was downloaded from.
+// If customize isn't nil it is called with the directory holding the project before it's copied to the context directory.
+func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedToken string, contextDir string, offline bool, verifier *signature.Verifier, requireSignature bool, customize func(projectDir string) error) (provenance envinfo.ResourceProvenance, err error) {
 	var path string
-	var err error
 	// Retrieve the working directory in order to clone correctly
 	if contextDir == "" {
 		path, err = os.Getwd()
 		if err != nil {
-			return errors.Wrapf(err, "Could not get the current working directory.")
+			return provenance, errors.Wrapf(err, "Could not get the current working directory.")
 		}
 	} else {
 		path = contextDir
@@ -88,37 +98,137 @@ func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedT
 	// We will check to see if the project has a valid directory
 	err = util.IsValidProjectDir(path, DevfilePath)
 	if err != nil {
-		return err
+		return provenance, err
+	}
+
+	// only the zip archives of starter projects are signed, git repositories can't be verified
+	if requireSignature && starterProject.Zip == nil {
+		return provenance, errors.Wrapf(signature.ErrUnsigned, "starter project %s can't be used, its registry requires signatures and only zip starter projects can be verified", starterProject.Name)
 	}
 
 	log.Info("\nStarter Project")
 
-	if starterProject.Git != nil || starterProject.Github != nil {
-		err := downloadGitProject(starterProject, decryptedToken, path)
+	cacheDir, err := getStarterProjectCacheDir(starterProject)
+	if err != nil {
+		return provenance, err
+	}
+	provenanceFile := cacheDir + ".json"
+
+	if offline {
+		if !util.CheckPathExists(cacheDir) {
+			return provenance, errors.Wrapf(util.ErrNotCached, "starter project %s has never been downloaded and can't be used in offline mode", starterProject.Name)
+		}
+		if data, err := ioutil.ReadFile(provenanceFile); err == nil {
+			_ = json.Unmarshal(data, &provenance)
+		}
+		if requireSignature && !provenance.Verified {
+			return provenance, errors.Wrapf(signature.ErrUnsigned, "cached starter project %s can't be used, its registry requires signatures", starterProject.Name)
+		}
+		cacheSpinner := log.Spinnerf("Using cached starter project %s", starterProject.Name)
+		defer cacheSpinner.End(false)
+		if err = copyStarterProject(cacheDir, path, customize); err != nil {
+			return provenance, err
+		}
+		cacheSpinner.End(true)
+		return provenance, nil
+	}
+
+	// the project is downloaded to a temporary directory first so that it can be cached
+	downloadDir, err := ioutil.TempDir("", "odostarter")
+	if err != nil {
+		return provenance, err
+	}
+	defer os.RemoveAll(downloadDir)
 
+	provenance.Kind = envinfo.StarterProjectResource
+	if starterProject.Git != nil || starterProject.Github != nil {
+		var commit string
+		provenance.Source, commit, err = downloadGitProject(starterProject, decryptedToken, downloadDir)
 		if err != nil {
-			return err
+			return provenance, err
 		}
+		provenance.Digest = "git:" + commit
 
 	} else if starterProject.Zip != nil {
 		url := starterProject.Zip.Location
 		sparseDir := starterProject.SubDir
+		provenance.Source = url
+		// the archive is verified before being extracted
+		verify := func(pathToZip string) error {
+			data, err := ioutil.ReadFile(pathToZip)
+			if err != nil {
+				return err
+			}
+			sig := signature.FetchSignature(url, decryptedToken, 0, false)
+			result, err := verifier.Check(url, data, sig, requireSignature)
+			provenance.Digest = result.Digest
+			provenance.Verified = result.Verified
+			return err
+		}
 		downloadSpinner := log.Spinnerf("Downloading starter project %s from %s", starterProject.Name, url)
-		err := checkoutProject(sparseDir, url, path, decryptedToken)
+		err := checkoutProject(sparseDir, url, downloadDir, decryptedToken, verify)
 		if err != nil {
 			downloadSpinner.End(false)
-			return err
+			return provenance, err
 		}
 		downloadSpinner.End(true)
 	} else {
-		return errors.Errorf("Project type not supported")
+		return provenance, errors.Errorf("Project type not supported")
 	}
 
-	return nil
+	// refresh the cached project, failing to do so shouldn't prevent using the downloaded one
+	if err = os.RemoveAll(cacheDir); err == nil {
+		err = util.CopyDirWithFS(downloadDir, cacheDir)
+	}
+	if err == nil {
+		var data []byte
+		if data, err = json.Marshal(provenance); err == nil {
+			err = ioutil.WriteFile(provenanceFile, data, 0600)
+		}
+	}
+	if err != nil {
+		klog.V(4).Infof("Unable to cache starter project %s: %v", starterProject.Name, err)
+	}
+
+	return provenance, copyStarterProject(downloadDir, path, customize)
+}
+
+// copyStarterProject copies the starter project from srcDir to path. The project is customized in a staging
+// directory so that srcDir, which can be the cache, is left untouched.
+func copyStarterProject(srcDir string, path string, customize func(projectDir string) error) error {
+	if customize == nil {
+		return util.CopyDirWithFS(srcDir, path)
+	}
+
+	stagingDir, err := ioutil.TempDir("", "odostarter")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err = util.CopyDirWithFS(srcDir, stagingDir); err != nil {
+		return err
+	}
+	if err = customize(stagingDir); err != nil {
+		return err
+	}
+	return util.CopyDirWithFS(stagingDir, path)
 }
 
-// downloadGitProject downloads the git starter projects from devfile.yaml
-func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken, path string) error {
+// getStarterProjectCacheDir returns the directory caching the given starter project,
+// it depends on where the project comes from so that different versions don't collide
+func getStarterProjectCacheDir(starterProject *devfilev1.StarterProject) (string, error) {
+	source, err := json.Marshal(starterProject.ProjectSource)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append(source, []byte(starterProject.SubDir)...))
+	return filepath.Join(util.GetCacheDir(), "starterprojects", starterProject.Name+"-"+hex.EncodeToString(sum[:8])), nil
+}
+
+// downloadGitProject downloads the git starter projects from devfile.yaml,
+// it returns the URL of the remote and the commit that was checked out
+func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken, path string) (string, string, error) {
 
 	var projectSource devfilev1.GitLikeProjectSource
 	if starterProject.Git != nil {
@@ -129,7 +239,7 @@ func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken,
 
 	remoteName, remoteUrl, revision, err := parsercommon.GetDefaultSource(projectSource)
 	if err != nil {
-		return errors.Wrapf(err, "unable to get default project source for starter project %s", starterProject.Name)
+		return "", "", errors.Wrapf(err, "unable to get default project source for starter project %s", starterProject.Name)
 	}
 
 	// convert revision to referenceName type, ref name could be a branch or tag
@@ -173,30 +283,35 @@ func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken,
 		originalPath = path
 		path, err = ioutil.TempDir("", "")
 		if err != nil {
-			return err
+			return "", "", err
 		}
 	}
 
-	_, err = git.PlainClone(path, false, cloneOptions)
+	repository, err := git.PlainClone(path, false, cloneOptions)
 
 	if err != nil {
 
 		// it returns the following error if no matching ref found
 		// if we get this error, we are trying again considering revision as tag, only if revision is specified.
 		if _, ok := err.(git.NoMatchingRefSpecError); !ok || revision == "" {
-			return err
+			return "", "", err
 		}
 
 		// try again to consider revision as tag name
 		cloneOptions.ReferenceName = plumbing.NewTagReferenceName(revision)
 		// remove if any .git folder downloaded in above try
 		_ = os.RemoveAll(filepath.Join(path, ".git"))
-		_, err = git.PlainClone(path, false, cloneOptions)
+		repository, err = git.PlainClone(path, false, cloneOptions)
 		if err != nil {
-			return err
+			return "", "", err
 		}
 	}
 
+	head, err := repository.Head()
+	if err != nil {
+		return "", "", errors.Wrapf(err, "unable to get the commit of starter project %s", starterProject.Name)
+	}
+
 	// we don't want to download project be a git repo
 	err = os.RemoveAll(filepath.Join(path, ".git"))
 	if err != nil {
@@ -208,11 +323,11 @@ func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken,
 		err = util.GitSubDir(path, originalPath,
 			starterProject.SubDir)
 		if err != nil {
-			return err
+			return "", "", err
 		}
 	}
 	downloadSpinner.End(true)
 
-	return nil
+	return remoteUrl, head.Hash().String(), nil
 
 }
@@ -0,0 +1,36 @@
+package component
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/openshift/odo/pkg/signature"
+	"github.com/pkg/errors"
+)
+
+func TestDownloadStarterProjectRequireSignature(t *testing.T) {
+	dir, err := ioutil.TempDir("", "starter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	starterProject := &devfilev1.StarterProject{
+		Name: "nodejs-starter",
+		ProjectSource: devfilev1.ProjectSource{
+			Git: &devfilev1.GitProjectSource{
+				GitLikeProjectSource: devfilev1.GitLikeProjectSource{
+					Remotes: map[string]string{"origin": "https://github.com/odo-devfiles/nodejs-ex.git"},
+				},
+			},
+		},
+	}
+	for _, offline := range []bool{false, true} {
+		_, err = DownloadStarterProject(starterProject, "", dir, offline, nil, true, nil)
+		if errors.Cause(err) != signature.ErrUnsigned {
+			t.Errorf("got the error %v for a git starter project of a registry requiring signatures, offline: %v, want %v", err, offline, signature.ErrUnsigned)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package component
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog"
+)
+
+// StarterTemplateFile is the file declaring the parameters of a starter project, it is looked for at the root
+// of the starter project and removed once the project has been rendered
+const StarterTemplateFile = "starter-template.yaml"
+
+// StarterParameter is a value asked to the user to customize a starter project
+type StarterParameter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// StarterTemplate describes how a starter project is customized. The file contents and the paths are
+// Go templates rendered with the values of the parameters, for example {{.ModuleName}} or
+// src/main/java/{{.Package | path}}/Application.java
+type StarterTemplate struct {
+	Parameters []StarterParameter `yaml:"parameters"`
+	// Files are the glob patterns, relative to the project root, of the files whose contents are rendered,
+	// no contents are rendered if empty, as the project may hold other templates such as Helm charts
+	Files []string `yaml:"files,omitempty"`
+}
+
+// starterTemplateFuncs are the functions available in the templates of starter projects
+var starterTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	// path converts a Java package or a similar dotted name to a path
+	"path": func(s string) string {
+		return strings.Replace(s, ".", "/", -1)
+	},
+}
+
+// GetStarterTemplate reads the template declaration of the starter project stored in dir,
+// it returns nil if the starter project isn't a template
+func GetStarterTemplate(dir string) (*StarterTemplate, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, StarterTemplateFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var starterTemplate StarterTemplate
+	if err = yaml.Unmarshal(data, &starterTemplate); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse %s", StarterTemplateFile)
+	}
+	for _, parameter := range starterTemplate.Parameters {
+		if parameter.Name == "" {
+			return nil, errors.Errorf("invalid %s, all the parameters should have a name", StarterTemplateFile)
+		}
+	}
+	return &starterTemplate, nil
+}
+
+// ParseStarterParams parses the values passed as key=value with the --starter-param flag
+func ParseStarterParams(params []string) (map[string]string, error) {
+	values := make(map[string]string, len(params))
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid starter parameter %q, it should be key=value", param)
+		}
+		values[kv[0]] = kv[1]
+	}
+	return values, nil
+}
+
+// ResolveParameters returns the values of all the parameters of the template. The values passed by the user are used first,
+// prompt is called for the missing ones if it isn't nil, otherwise their default values are used.
+func (t *StarterTemplate) ResolveParameters(values map[string]string, prompt func(parameter StarterParameter) string) (map[string]string, error) {
+	resolved := make(map[string]string, len(t.Parameters))
+	var missing []string
+	for _, parameter := range t.Parameters {
+		value, ok := values[parameter.Name]
+		switch {
+		case ok:
+		case prompt != nil:
+			value = prompt(parameter)
+		case parameter.Default != "":
+			value = parameter.Default
+		default:
+			missing = append(missing, parameter.Name)
+		}
+		resolved[parameter.Name] = value
+	}
+	if len(missing) != 0 {
+		return nil, errors.Errorf("the starter project requires the parameters %s, please pass them with --starter-param", strings.Join(missing, ", "))
+	}
+
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			klog.V(4).Infof("Ignoring starter parameter %s which isn't declared by the starter project", name)
+		}
+	}
+	return resolved, nil
+}
+
+// Render renders the templates in the file contents and paths of the starter project stored in dir
+// and removes the template declaration from the project
+func (t *StarterTemplate) Render(dir string, values map[string]string) error {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// deepest paths first so that the parent directories are renamed after their content
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == StarterTemplateFile {
+			continue
+		}
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() && t.isRendered(rel) {
+			if err = renderStarterFile(path, info, values); err != nil {
+				return err
+			}
+		}
+
+		renderedName, err := renderStarterString(rel, info.Name(), values)
+		if err != nil {
+			return err
+		}
+		if renderedName == info.Name() {
+			continue
+		}
+		target, err := renderedTarget(dir, rel, renderedName)
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return err
+		}
+		if err = os.Rename(path, target); err != nil {
+			return errors.Wrapf(err, "unable to rename %s", rel)
+		}
+	}
+
+	return os.Remove(filepath.Join(dir, StarterTemplateFile))
+}
+
+// renderedTarget returns the path of the file at rel, relative to the project stored in dir, once renamed to
+// renderedName. The rendered name must be a relative path which keeps the file within the project.
+func renderedTarget(dir string, rel string, renderedName string) (string, error) {
+	name := filepath.FromSlash(renderedName)
+	if strings.TrimSpace(name) == "" || filepath.IsAbs(name) || strings.HasPrefix(renderedName, "/") {
+		return "", errors.Errorf("invalid name %q rendered for %s, it should be a relative path", renderedName, rel)
+	}
+	targetRel := filepath.Join(filepath.Dir(rel), name)
+	if targetRel == "." || targetRel == ".." || strings.HasPrefix(targetRel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid name %q rendered for %s, it is outside of the starter project", renderedName, rel)
+	}
+	return filepath.Join(dir, targetRel), nil
+}
+
+// isRendered returns true if the contents of the file at path, relative to the project root, should be rendered
+func (t *StarterTemplate) isRendered(path string) bool {
+	for _, pattern := range t.Files {
+		if matched, err := filepath.Match(pattern, filepath.ToSlash(path)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// renderStarterFile renders the template in the contents of the file, binary files are left untouched
+func renderStarterFile(path string, info os.FileInfo, values map[string]string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if isBinaryContent(data) || !bytes.Contains(data, []byte("{{")) {
+		return nil
+	}
+	rendered, err := renderStarterString(path, string(data), values)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(rendered), info.Mode())
+}
+
+// isBinaryContent returns true if data looks like the content of a binary file, that is it contains a NUL byte
+// within its first 8000 bytes as git does
+func isBinaryContent(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) != -1
+}
+
+// renderStarterString renders the template text, name identifies the template in errors
+func renderStarterString(name string, text string, values map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Funcs(starterTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to parse the template in %s", name)
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, values); err != nil {
+		return "", errors.Wrapf(err, "unable to render the template in %s", name)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,233 @@
+package component
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseStarterParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "Case 1: Valid parameters",
+			params: []string{"GroupId=com.example", "Greeting=a=b"},
+			want:   map[string]string{"GroupId": "com.example", "Greeting": "a=b"},
+		},
+		{
+			name:    "Case 2: Parameter without value",
+			params:  []string{"GroupId"},
+			wantErr: true,
+		},
+		{
+			name:    "Case 3: Parameter without name",
+			params:  []string{"=com.example"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStarterParams(tt.params)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveParameters(t *testing.T) {
+	starterTemplate := StarterTemplate{
+		Parameters: []StarterParameter{
+			{Name: "GroupId"},
+			{Name: "ArtifactId", Default: "demo"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		prompt  func(parameter StarterParameter) string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "Case 1: Values passed by the user override the defaults",
+			values: map[string]string{"GroupId": "com.example", "ArtifactId": "app"},
+			want:   map[string]string{"GroupId": "com.example", "ArtifactId": "app"},
+		},
+		{
+			name:   "Case 2: Default values are used for the missing parameters",
+			values: map[string]string{"GroupId": "com.example"},
+			want:   map[string]string{"GroupId": "com.example", "ArtifactId": "demo"},
+		},
+		{
+			name:    "Case 3: Missing parameter without default value",
+			values:  map[string]string{"ArtifactId": "app"},
+			wantErr: true,
+		},
+		{
+			name:   "Case 4: Missing parameters are prompted",
+			values: map[string]string{"ArtifactId": "app"},
+			prompt: func(parameter StarterParameter) string {
+				return "org.example"
+			},
+			want: map[string]string{"GroupId": "org.example", "ArtifactId": "app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := starterTemplate.ResolveParameters(tt.values, tt.prompt)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStarterTemplateRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "odostarter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		StarterTemplateFile: "parameters:\n- name: Package\n- name: Name\nfiles:\n- pom.xml\n- src/*/*.java\n",
+		"pom.xml":           "<artifactId>{{.Name | lower}}</artifactId>",
+		filepath.Join("src", "{{.Package | path}}", "{{.Name}}.java"): "package {{.Package}};",
+		"README.md": "no template here",
+		filepath.Join("chart", "templates", "deployment.yaml"): "replicas: {{ .Values.replicas }}",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	starterTemplate, err := GetStarterTemplate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if starterTemplate == nil || len(starterTemplate.Parameters) != 2 {
+		t.Fatalf("Got template %v, want 2 parameters", starterTemplate)
+	}
+
+	err = starterTemplate.Render(dir, map[string]string{"Package": "com.example", "Name": "Demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"pom.xml": "<artifactId>demo</artifactId>",
+		filepath.Join("src", "com", "example", "Demo.java"): "package com.example;",
+		"README.md": "no template here",
+		filepath.Join("chart", "templates", "deployment.yaml"): "replicas: {{ .Values.replicas }}",
+	}
+	for name, content := range want {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("unable to read %s: %v", name, err)
+			continue
+		}
+		if string(data) != content {
+			t.Errorf("Got %q in %s, want %q", string(data), name, content)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(dir, StarterTemplateFile)); !os.IsNotExist(err) {
+		t.Errorf("%s should have been removed", StarterTemplateFile)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "src", "{{.Package | path}}")); !os.IsNotExist(err) {
+		t.Errorf("the templated directory should have been renamed")
+	}
+}
+
+func TestStarterTemplateRenderOutsideProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		value    string
+	}{
+		{
+			name:     "Case 1: Parent directory",
+			fileName: "{{.Name}}.txt",
+			value:    "../escaped",
+		},
+		{
+			name:     "Case 2: Parent directory of a nested file",
+			fileName: filepath.Join("src", "{{.Name}}.txt"),
+			value:    "../../escaped",
+		},
+		{
+			name:     "Case 3: Absolute path",
+			fileName: "{{.Name}}.txt",
+			value:    "/escaped",
+		},
+		{
+			name:     "Case 4: Project root",
+			fileName: "{{.Name}}",
+			value:    ".",
+		},
+		{
+			name:     "Case 5: Empty name",
+			fileName: "{{.Name}}",
+			value:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentDir, err := ioutil.TempDir("", "odostarter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(parentDir)
+			dir := filepath.Join(parentDir, "project")
+
+			files := map[string]string{
+				StarterTemplateFile: "parameters:\n- name: Name\n",
+				tt.fileName:         "content",
+			}
+			for name, content := range files {
+				path := filepath.Join(dir, name)
+				if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+					t.Fatal(err)
+				}
+				if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			starterTemplate, err := GetStarterTemplate(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = starterTemplate.Render(dir, map[string]string{"Name": tt.value}); err == nil {
+				t.Errorf("expected an error for the rendered name %q", tt.value)
+			}
+			if _, err = os.Stat(filepath.Join(dir, tt.fileName)); err != nil {
+				t.Errorf("the file %s should not have been renamed: %v", tt.fileName, err)
+			}
+			if _, err = os.Stat(filepath.Join(parentDir, "escaped.txt")); !os.IsNotExist(err) {
+				t.Errorf("the file was renamed outside of the starter project")
+			}
+		})
+	}
+}
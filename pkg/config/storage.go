@@ -67,6 +67,9 @@ func (lci *LocalConfigInfo) ValidateStorage(storage localConfigProvider.LocalSto
 	if storage.Size == "" || storage.Path == "" {
 		return fmt.Errorf("\"size\" and \"path\" flags are required for s2i components")
 	}
+	if storage.StorageClass != "" || storage.AccessMode != "" || storage.VolumeMode != "" {
+		return fmt.Errorf("\"storage-class\", \"access-mode\" and \"volume-mode\" flags are not supported for s2i components")
+	}
 
 	configStorage, err := lci.ListStorage()
 	if err != nil {
@@ -0,0 +1,109 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift/odo/pkg/testingutil/filesystem"
+	"k8s.io/klog"
+)
+
+const (
+	// ComponentTarget is the target of the ports forwarded to the component pod
+	ComponentTarget = "component"
+
+	portForwardFileSuffix = "odo-port-forward"
+)
+
+// PortForwardSession records the ports forwarded by a running `odo port-forward`
+type PortForwardSession struct {
+	ProcessID int `json:"processID"`
+	// Target is "component" when the ports are forwarded to the component pod, or the name of the linked service
+	Target    string     `json:"target"`
+	PortPairs []PortPair `json:"portPairs"`
+}
+
+// getPortForwardFilePrefix returns the prefix of the files recording the port forwarding sessions of the component
+func getPortForwardFilePrefix(componentName, appName, projectName string) string {
+	arr := []string{projectName, appName, componentName, portForwardFileSuffix}
+	if appName == "" {
+		arr = []string{projectName, componentName, portForwardFileSuffix}
+	}
+	return strings.Join(arr, "-")
+}
+
+// GetPortForwardFilePath returns the path of the file recording the port forwarding session of the current process
+func GetPortForwardFilePath(f *DefaultPortForwarder) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("%s-%d.json", getPortForwardFilePrefix(f.componentName, f.appName, f.projectName), os.Getpid()))
+}
+
+// CreatePortForwardFile records the port pairs forwarded by the current process to the target
+func CreatePortForwardFile(f *DefaultPortForwarder, target string, portPairs []string) error {
+	return createPortForwardFile(f, target, portPairs, filesystem.DefaultFs{})
+}
+
+func createPortForwardFile(f *DefaultPortForwarder, target string, portPairs []string, fs filesystem.Filesystem) error {
+	session := PortForwardSession{
+		ProcessID: os.Getpid(),
+		Target:    target,
+	}
+	for _, portPair := range portPairs {
+		pair, err := parsePortPair(portPair)
+		if err != nil {
+			return err
+		}
+		session.PortPairs = append(session.PortPairs, pair)
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(GetPortForwardFilePath(f), data, 0600)
+}
+
+// GetPortForwardSessions returns the active port forwarding sessions of the component
+func GetPortForwardSessions(f *DefaultPortForwarder) []PortForwardSession {
+	return getPortForwardSessions(f, filesystem.DefaultFs{})
+}
+
+// getPortForwardSessions reads the port forwarding session files of the component, the files of the
+// sessions which are not running anymore are ignored
+func getPortForwardSessions(f *DefaultPortForwarder, fs filesystem.Filesystem) []PortForwardSession {
+	tempDir := os.TempDir()
+	files, err := fs.ReadDir(tempDir)
+	if err != nil {
+		klog.V(4).Infof("unable to read the directory %v: %v", tempDir, err)
+		return nil
+	}
+
+	prefix := getPortForwardFilePrefix(f.componentName, f.appName, f.projectName) + "-"
+	var sessions []PortForwardSession
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), prefix) || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		data, err := fs.ReadFile(filepath.Join(tempDir, file.Name()))
+		if err != nil {
+			klog.V(4).Infof("unable to read the port forwarding file %v: %v", file.Name(), err)
+			continue
+		}
+		var session PortForwardSession
+		if err = json.Unmarshal(data, &session); err != nil || len(session.PortPairs) == 0 {
+			klog.V(4).Infof("couldn't unmarshal the port forwarding file %v", file.Name())
+			continue
+		}
+		if !IsForwardingPort(session.ProcessID, session.PortPairs[0].LocalPort) {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ProcessID < sessions[j].ProcessID
+	})
+	return sessions
+}
@@ -0,0 +1,118 @@
+package debug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/testingutil/filesystem"
+	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func Test_getPortForwardSessions(t *testing.T) {
+	fs := filesystem.NewFakeFs()
+	envDir, err := ioutil.TempDir("", "forwards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(envDir)
+	envInfo, err := envinfo.NewEnvSpecificInfo(envDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = envInfo.SetComponentSettings(envinfo.ComponentSettings{Name: "nodejs", AppName: "app", Project: "myproject"}); err != nil {
+		t.Fatal(err)
+	}
+	streams := k8sgenclioptions.NewTestIOStreamsDiscard()
+	// the sessions are written as `odo port-forward` does and read as `odo debug info` does
+	reader := NewComponentPortForwarder(envInfo, "", nil, nil, streams)
+	writers := map[string]*DefaultPortForwarder{
+		ComponentTarget: NewComponentPortForwarder(envInfo, "", nil, nil, streams),
+		"mydb":          NewComponentPortForwarder(envInfo, "mydb", nil, nil, streams),
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	forwardedPort := listener.Addr().(*net.TCPAddr).Port
+
+	if err = fs.MkdirAll(os.TempDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = createPortForwardFile(writers["mydb"], "mydb", []string{"5432"}, fs); err == nil {
+		t.Errorf("createPortForwardFile() should fail for an invalid port pair")
+	}
+
+	tests := []struct {
+		name      string
+		target    string
+		portPairs []string
+		fileName  string
+		want      []PortForwardSession
+	}{
+		{
+			name:      "Case 1: Active session",
+			target:    ComponentTarget,
+			portPairs: []string{fmt.Sprintf("%d:9090", forwardedPort), "9001:9000"},
+			want: []PortForwardSession{{
+				ProcessID: os.Getpid(),
+				Target:    ComponentTarget,
+				PortPairs: []PortPair{{LocalPort: forwardedPort, RemotePort: 9090}, {LocalPort: 9001, RemotePort: 9000}},
+			}},
+		},
+		{
+			name:      "Case 2: Session of a linked service",
+			target:    "mydb",
+			portPairs: []string{fmt.Sprintf("%d:5432", forwardedPort)},
+			want: []PortForwardSession{{
+				ProcessID: os.Getpid(),
+				Target:    "mydb",
+				PortPairs: []PortPair{{LocalPort: forwardedPort, RemotePort: 5432}},
+			}},
+		},
+		{
+			name:      "Case 3: Session of another component",
+			target:    "mydb",
+			portPairs: []string{fmt.Sprintf("%d:5432", forwardedPort)},
+			fileName:  "myproject-nodejs-2-odo-port-forward-1.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := writers[tt.target]
+			path := GetPortForwardFilePath(writer)
+			if err := createPortForwardFile(writer, tt.target, tt.portPairs, fs); err != nil {
+				t.Fatal(err)
+			}
+			if tt.fileName != "" {
+				if err := fs.Rename(path, filepath.Join(os.TempDir(), tt.fileName)); err != nil {
+					t.Fatal(err)
+				}
+				path = filepath.Join(os.TempDir(), tt.fileName)
+			}
+			defer fs.Remove(path)
+
+			got := getPortForwardSessions(reader, fs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPortForwardSessions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Case 4: Session not running anymore", func(t *testing.T) {
+		if err := createPortForwardFile(writers[ComponentTarget], ComponentTarget, []string{fmt.Sprintf("%d:9090", forwardedPort)}, fs); err != nil {
+			t.Fatal(err)
+		}
+		listener.Close()
+		if got := getPortForwardSessions(reader, fs); len(got) != 0 {
+			t.Errorf("getPortForwardSessions() = %+v, the session isn't running anymore", got)
+		}
+	})
+}
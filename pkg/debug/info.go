@@ -27,6 +27,19 @@ type OdoDebugFileSpec struct {
 	DebugProcessID int    `json:"debugProcessID"`
 	RemotePort     int    `json:"remotePort"`
 	LocalPort      int    `json:"localPort"`
+	// Runtime is the debug runtime of the component, if known
+	Runtime Runtime `json:"runtime,omitempty"`
+	// AdditionalPorts are the other ports of the debug container forwarded with the debug port
+	AdditionalPorts []PortPair `json:"additionalPorts,omitempty"`
+	// PortForwards are the active port forwarding sessions of the component, they are listed by odo debug info
+	// and are not stored in the debug info file
+	PortForwards []PortForwardSession `json:"portForwards,omitempty"`
+}
+
+// PortPair is a local port forwarded to a remote port of the component
+type PortPair struct {
+	RemotePort int `json:"remotePort"`
+	LocalPort  int `json:"localPort"`
 }
 
 // GetDebugInfoFilePath gets the file path of the debug info file
@@ -43,24 +56,23 @@ func GetDebugInfoFilePath(componentName, appName string, projectName string) str
 	return filepath.Join(tempDir, debugFileName)
 }
 
-func CreateDebugInfoFile(f *DefaultPortForwarder, portPair string) error {
-	return createDebugInfoFile(f, portPair, filesystem.DefaultFs{})
+// CreateDebugInfoFile creates the debug info file of the component, the first port pair being the one of the debugger
+func CreateDebugInfoFile(f *DefaultPortForwarder, runtime Runtime, portPairs ...string) error {
+	return createDebugInfoFile(f, runtime, portPairs, filesystem.DefaultFs{})
 }
 
 // createDebugInfoFile creates a file in the temp directory with information regarding the debugging session of a component
-func createDebugInfoFile(f *DefaultPortForwarder, portPair string, fs filesystem.Filesystem) error {
-	portPairs := strings.Split(portPair, ":")
-	if len(portPairs) != 2 {
-		return errors.New("port pair should be of the format localPort:RemotePort")
+func createDebugInfoFile(f *DefaultPortForwarder, runtime Runtime, portPairs []string, fs filesystem.Filesystem) error {
+	if len(portPairs) == 0 {
+		return errors.New("at least one port pair should be forwarded")
 	}
-
-	localPort, err := strconv.Atoi(portPairs[0])
-	if err != nil {
-		return errors.New("local port should be a int")
-	}
-	remotePort, err := strconv.Atoi(portPairs[1])
-	if err != nil {
-		return errors.New("remote port should be a int")
+	var pairs []PortPair
+	for _, portPair := range portPairs {
+		pair, err := parsePortPair(portPair)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, pair)
 	}
 
 	odoDebugFile := OdoDebugFile{
@@ -73,10 +85,12 @@ func createDebugInfoFile(f *DefaultPortForwarder, portPair string, fs filesystem
 			Namespace: f.projectName,
 		},
 		Spec: OdoDebugFileSpec{
-			App:            f.appName,
-			DebugProcessID: os.Getpid(),
-			RemotePort:     remotePort,
-			LocalPort:      localPort,
+			App:             f.appName,
+			DebugProcessID:  os.Getpid(),
+			RemotePort:      pairs[0].RemotePort,
+			LocalPort:       pairs[0].LocalPort,
+			Runtime:         runtime,
+			AdditionalPorts: pairs[1:],
 		},
 	}
 	odoDebugPathData, err := json.Marshal(odoDebugFile)
@@ -96,6 +110,24 @@ func createDebugInfoFile(f *DefaultPortForwarder, portPair string, fs filesystem
 	return nil
 }
 
+// parsePortPair parses a port pair of the format localPort:RemotePort
+func parsePortPair(portPair string) (PortPair, error) {
+	portPairs := strings.Split(portPair, ":")
+	if len(portPairs) != 2 {
+		return PortPair{}, errors.New("port pair should be of the format localPort:RemotePort")
+	}
+
+	localPort, err := strconv.Atoi(portPairs[0])
+	if err != nil {
+		return PortPair{}, errors.New("local port should be a int")
+	}
+	remotePort, err := strconv.Atoi(portPairs[1])
+	if err != nil {
+		return PortPair{}, errors.New("remote port should be a int")
+	}
+	return PortPair{RemotePort: remotePort, LocalPort: localPort}, nil
+}
+
 // GetDebugInfo gathers the information with regards to debugging information
 func GetDebugInfo(f *DefaultPortForwarder) (OdoDebugFile, bool) {
 	return getDebugInfo(f, filesystem.DefaultFs{})
@@ -120,38 +152,47 @@ func getDebugInfo(f *DefaultPortForwarder, fs filesystem.Filesystem) (OdoDebugFi
 		return OdoDebugFile{}, false
 	}
 
-	// get the debug process id and send a signal 0 to check if it's alive or not
+	if !IsForwardingPort(odoDebugFileData.Spec.DebugProcessID, odoDebugFileData.Spec.LocalPort) {
+		return OdoDebugFile{}, false
+	}
+	// returns the unmarshalled data
+	return odoDebugFileData, true
+}
+
+// IsForwardingPort returns true if the process with the given pid is alive and the local port is in use,
+// which means that the process is likely forwarding the port
+func IsForwardingPort(pid int, localPort int) bool {
+	// send a signal 0 to the process to check if it's alive or not
 	// according to https://golang.org/pkg/os/#FindProcess
 	// On Unix systems, FindProcess always succeeds and returns a Process for the given pid, regardless of whether the process exists.
 	// thus this step will pass on Unix systems and so for those systems and some others supporting signals
 	// we check if the process is alive or not by sending a signal 0 to the process
-	processInfo, err := os.FindProcess(odoDebugFileData.Spec.DebugProcessID)
+	processInfo, err := os.FindProcess(pid)
 	if err != nil || processInfo == nil {
-		klog.V(4).Infof("error getting the process info for pid %v", odoDebugFileData.Spec.DebugProcessID)
-		return OdoDebugFile{}, false
+		klog.V(4).Infof("error getting the process info for pid %v", pid)
+		return false
 	}
 
 	// signal is not available on windows so we skip this step for windows
 	if runtime.GOOS != "windows" {
 		err = processInfo.Signal(syscall.Signal(0))
 		if err != nil {
-			klog.V(4).Infof("error sending signal 0 to pid %v, cause: %v", odoDebugFileData.Spec.DebugProcessID, err)
-			return OdoDebugFile{}, false
+			klog.V(4).Infof("error sending signal 0 to pid %v, cause: %v", pid, err)
+			return false
 		}
 	}
 
-	// gets the debug local port and tries to listen on it
-	// if error doesn't occur the debug port was free and thus no debug process was using the port
-	addressLook := "localhost:" + strconv.Itoa(odoDebugFileData.Spec.LocalPort)
+	// tries to listen on the local port
+	// if error doesn't occur the port was free and thus no process was forwarding the port
+	addressLook := "localhost:" + strconv.Itoa(localPort)
 	listener, err := net.Listen("tcp", addressLook)
 	if err == nil {
-		klog.V(4).Infof("the debug port %v is free, thus debug is not running", odoDebugFileData.Spec.LocalPort)
+		klog.V(4).Infof("the local port %v is free, thus it isn't forwarded", localPort)
 		err = listener.Close()
 		if err != nil {
 			klog.V(4).Infof("error occurred while closing the listener, cause :%v", err)
 		}
-		return OdoDebugFile{}, false
+		return false
 	}
-	// returns the unmarshalled data
-	return odoDebugFileData, true
+	return true
 }
@@ -126,7 +126,7 @@ func Test_createDebugInfoFile(t *testing.T) {
 				}
 			}
 
-			if err := createDebugInfoFile(tt.args.defaultPortForwarder, tt.args.portPair, tt.args.fs); (err != nil) != tt.wantErr {
+			if err := createDebugInfoFile(tt.args.defaultPortForwarder, "", []string{tt.args.portPair}, tt.args.fs); (err != nil) != tt.wantErr {
 				t.Errorf("createDebugInfoFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
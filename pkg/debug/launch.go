@@ -0,0 +1,107 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
+)
+
+const (
+	// launchFileVersion is the version of the VS Code launch.json files created by odo
+	launchFileVersion = "0.2.0"
+	// workspaceFolder is the VS Code variable of the folder opened in the editor
+	workspaceFolder = "${workspaceFolder}"
+)
+
+// LaunchConfiguration is a VS Code launch configuration attaching the debugger of a runtime to a forwarded port
+type LaunchConfiguration map[string]interface{}
+
+// GetLaunchFilePath returns the path of the VS Code launch.json file of the component context
+func GetLaunchFilePath(contextDir string) string {
+	return filepath.Join(contextDir, ".vscode", "launch.json")
+}
+
+// NewLaunchConfiguration returns the launch configuration attaching the debugger of the runtime to the local port,
+// the sources of the context directory being mapped to the remote sources directory of the component
+func NewLaunchConfiguration(runtime Runtime, componentName string, localPort int, remoteRoot string) (LaunchConfiguration, error) {
+	configuration := LaunchConfiguration{
+		"name":    fmt.Sprintf("odo: attach to %s", componentName),
+		"request": "attach",
+	}
+	switch runtime {
+	case NodeRuntime:
+		configuration["type"] = "node"
+		configuration["address"] = "localhost"
+		configuration["port"] = localPort
+		configuration["localRoot"] = workspaceFolder
+		configuration["remoteRoot"] = remoteRoot
+	case JavaRuntime:
+		configuration["type"] = "java"
+		configuration["hostName"] = "localhost"
+		configuration["port"] = localPort
+	case GoRuntime:
+		configuration["type"] = "go"
+		configuration["mode"] = "remote"
+		configuration["host"] = "127.0.0.1"
+		configuration["port"] = localPort
+		configuration["substitutePath"] = []map[string]string{{"from": workspaceFolder, "to": remoteRoot}}
+	case PythonRuntime:
+		configuration["type"] = "python"
+		configuration["connect"] = map[string]interface{}{"host": "localhost", "port": localPort}
+		configuration["pathMappings"] = []map[string]string{{"localRoot": workspaceFolder, "remoteRoot": remoteRoot}}
+	default:
+		return nil, fmt.Errorf("unable to generate a launch configuration for the debug runtime %q, set the %s attribute of the devfile to one of %v", runtime, RuntimeAttribute, Runtimes())
+	}
+	return configuration, nil
+}
+
+// UpdateLaunchFile adds the configuration to the VS Code launch.json file of the context directory,
+// replacing the configuration with the same name; the file is created if it doesn't exist
+func UpdateLaunchFile(contextDir string, configuration LaunchConfiguration) error {
+	return updateLaunchFile(GetLaunchFilePath(contextDir), configuration, filesystem.DefaultFs{})
+}
+
+func updateLaunchFile(path string, configuration LaunchConfiguration, fs filesystem.Filesystem) error {
+	launchFile := map[string]interface{}{
+		"version": launchFileVersion,
+	}
+	data, err := fs.ReadFile(path)
+	if err == nil {
+		if err = json.Unmarshal(data, &launchFile); err != nil {
+			return errors.Wrapf(err, "unable to parse %s, launch files with comments are not supported", path)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	var configurations []interface{}
+	if existing, ok := launchFile["configurations"]; ok {
+		if configurations, ok = existing.([]interface{}); !ok {
+			return fmt.Errorf("the configurations of %s should be a list", path)
+		}
+	}
+	replaced := false
+	for i, existing := range configurations {
+		if c, ok := existing.(map[string]interface{}); ok && c["name"] == configuration["name"] {
+			configurations[i] = configuration
+			replaced = true
+		}
+	}
+	if !replaced {
+		configurations = append(configurations, configuration)
+	}
+	launchFile["configurations"] = configurations
+
+	data, err = json.MarshalIndent(launchFile, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err = fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return fs.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,149 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openshift/odo/pkg/testingutil/filesystem"
+)
+
+func TestNewLaunchConfiguration(t *testing.T) {
+	tests := []struct {
+		name        string
+		runtime     Runtime
+		wantType    string
+		wantMapping string
+		wantErr     bool
+	}{
+		{
+			name:        "Case 1: Node inspector",
+			runtime:     NodeRuntime,
+			wantType:    "node",
+			wantMapping: `"remoteRoot":"/projects/nodejs-starter"`,
+		},
+		{
+			name:     "Case 2: JDWP",
+			runtime:  JavaRuntime,
+			wantType: "java",
+		},
+		{
+			name:        "Case 3: Delve",
+			runtime:     GoRuntime,
+			wantType:    "go",
+			wantMapping: `"substitutePath":[{"from":"${workspaceFolder}","to":"/projects/nodejs-starter"}]`,
+		},
+		{
+			name:        "Case 4: Debugpy",
+			runtime:     PythonRuntime,
+			wantType:    "python",
+			wantMapping: `"pathMappings":[{"localRoot":"${workspaceFolder}","remoteRoot":"/projects/nodejs-starter"}]`,
+		},
+		{
+			name:    "Case 5: Unknown runtime",
+			runtime: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLaunchConfiguration(tt.runtime, "nodejs", 5858, "/projects/nodejs-starter")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLaunchConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got["type"] != tt.wantType || got["request"] != "attach" || got["name"] != "odo: attach to nodejs" {
+				t.Errorf("unexpected launch configuration %v", got)
+			}
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), tt.wantMapping) {
+				t.Errorf("the launch configuration %s should contain %s", data, tt.wantMapping)
+			}
+		})
+	}
+}
+
+func Test_updateLaunchFile(t *testing.T) {
+	fs := filesystem.NewFakeFs()
+	configuration := LaunchConfiguration{"name": "odo: attach to nodejs", "type": "node", "port": 5858}
+
+	tests := []struct {
+		name       string
+		existing   string
+		wantConfig []interface{}
+		wantErr    bool
+	}{
+		{
+			name: "Case 1: No launch file",
+			wantConfig: []interface{}{
+				map[string]interface{}{"name": "odo: attach to nodejs", "type": "node", "port": float64(5858)},
+			},
+		},
+		{
+			name:     "Case 2: Configuration added to the existing ones",
+			existing: `{"version": "0.2.0", "configurations": [{"name": "Launch Program", "type": "node"}]}`,
+			wantConfig: []interface{}{
+				map[string]interface{}{"name": "Launch Program", "type": "node"},
+				map[string]interface{}{"name": "odo: attach to nodejs", "type": "node", "port": float64(5858)},
+			},
+		},
+		{
+			name:     "Case 3: Configuration with the same name replaced",
+			existing: `{"version": "0.2.0", "configurations": [{"name": "odo: attach to nodejs", "type": "node", "port": 9229}]}`,
+			wantConfig: []interface{}{
+				map[string]interface{}{"name": "odo: attach to nodejs", "type": "node", "port": float64(5858)},
+			},
+		},
+		{
+			name:     "Case 4: Launch file with comments",
+			existing: "{\n// comment\n\"version\": \"0.2.0\"}",
+			wantErr:  true,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := GetLaunchFilePath(fmt.Sprintf("context-%d", i))
+			if tt.existing != "" {
+				if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := fs.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := updateLaunchFile(path, configuration, fs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateLaunchFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			data, err := fs.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var launchFile map[string]interface{}
+			if err = json.Unmarshal(data, &launchFile); err != nil {
+				t.Fatal(err)
+			}
+			if launchFile["version"] != launchFileVersion {
+				t.Errorf("got version %v, want %v", launchFile["version"], launchFileVersion)
+			}
+			if !reflect.DeepEqual(launchFile["configurations"], tt.wantConfig) {
+				t.Errorf("got configurations %v, want %v", launchFile["configurations"], tt.wantConfig)
+			}
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package debug
 
 import (
+	"github.com/devfile/library/pkg/devfile/generator"
+	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/occlient"
 	"k8s.io/client-go/rest"
 
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/openshift/odo/pkg/log"
 	corev1 "k8s.io/api/core/v1"
@@ -14,6 +19,14 @@ import (
 	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog"
+)
+
+const (
+	// minReconnectDelay is the delay before forwarding the ports again after the connection to the pod was lost
+	minReconnectDelay = time.Second
+	// maxReconnectDelay is the maximum delay between two attempts to forward the ports
+	maxReconnectDelay = 30 * time.Second
 )
 
 // DefaultPortForwarder implements the SPDY based port forwarder
@@ -24,6 +37,8 @@ type DefaultPortForwarder struct {
 	componentName string
 	appName       string
 	projectName   string
+	// serviceName is the Kubernetes service whose pods the ports are forwarded to, instead of the component pod
+	serviceName string
 }
 
 func NewDefaultPortForwarder(componentName, appName string, projectName string, client *occlient.Client, kClient *kclient.Client, streams k8sgenclioptions.IOStreams) *DefaultPortForwarder {
@@ -37,16 +52,137 @@ func NewDefaultPortForwarder(componentName, appName string, projectName string,
 	}
 }
 
+// NewComponentPortForwarder returns a port forwarder to the component of the local configuration, or to the pods of
+// the Kubernetes service when serviceName is not empty, the remote ports being the ports of the service or of its pods.
+// The sessions of devfile components are recorded without their application, as `odo debug` records them, so that
+// `odo debug info` finds the sessions of `odo port-forward`
+func NewComponentPortForwarder(provider localConfigProvider.LocalConfigProvider, serviceName string, client *occlient.Client, kClient *kclient.Client, streams k8sgenclioptions.IOStreams) *DefaultPortForwarder {
+	appName := provider.GetApplication()
+	if _, ok := provider.(*envinfo.EnvSpecificInfo); ok {
+		appName = ""
+	}
+	f := NewDefaultPortForwarder(provider.GetName(), appName, provider.GetNamespace(), client, kClient, streams)
+	f.serviceName = serviceName
+	return f
+}
+
 // ForwardPorts forwards the port using the url for the remote pod.
 // portPair is a pair of port in format "localPort:RemotePort" that is to be forwarded
 // stop Chan is used to stop port forwarding
 // ready Chan is used to signal failure to the channel receiver
 func (f *DefaultPortForwarder) ForwardPorts(portPair string, stopChan, readyChan chan struct{}, isDevfile bool) error {
+	return f.ForwardPortPairs([]string{portPair}, stopChan, readyChan, isDevfile)
+}
+
+// KeepForwarding forwards the port pairs until stopChan is closed. The ports are forwarded again to the new pod
+// of the component when the connection is lost, for example when the pod is replaced after a push.
+func (f *DefaultPortForwarder) KeepForwarding(portPairs []string, stopChan chan struct{}, isDevfile bool) error {
+	retryDelay := minReconnectDelay
+	for {
+		// the port forwarder closes its channels, new ones are needed for every connection
+		connectionStop := make(chan struct{})
+		connectionDone := make(chan struct{})
+		readyChan := make(chan struct{})
+		go func() {
+			select {
+			case <-stopChan:
+				close(connectionStop)
+			case <-connectionDone:
+			}
+		}()
+
+		err := f.ForwardPortPairs(portPairs, connectionStop, readyChan, isDevfile)
+		close(connectionDone)
+
+		select {
+		case <-stopChan:
+			return nil
+		default:
+		}
+
+		select {
+		case <-readyChan:
+			// the ports were forwarded before the connection was lost
+			retryDelay = minReconnectDelay
+		default:
+		}
+		if err != nil {
+			klog.V(4).Infof("Unable to forward the ports: %v", err)
+		}
+		log.Infof("Port forwarding to the component %s was interrupted, reconnecting in %v", f.componentName, retryDelay)
+
+		select {
+		case <-stopChan:
+			return nil
+		case <-time.After(retryDelay):
+		}
+		if retryDelay *= 2; retryDelay > maxReconnectDelay {
+			retryDelay = maxReconnectDelay
+		}
+	}
+}
+
+// getServicePod returns a pod of the service of the port forwarder, and the port pairs to the ports of the pod
+// to which the remote ports of the given port pairs are routed by the service
+func (f *DefaultPortForwarder) getServicePod(portPairs []string) (*corev1.Pod, []string, error) {
+	service, err := f.kClient.GetService(f.serviceName)
+	if err != nil {
+		return nil, nil, err
+	}
+	pod, err := f.kClient.GetServicePod(service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	podPortPairs := make([]string, 0, len(portPairs))
+	for _, portPair := range portPairs {
+		pair, err := parsePortPair(portPair)
+		if err != nil {
+			return nil, nil, err
+		}
+		targetPort, err := kclient.GetServiceTargetPort(service, pod, pair.RemotePort)
+		if err != nil {
+			return nil, nil, err
+		}
+		podPortPairs = append(podPortPairs, fmt.Sprintf("%d:%d", pair.LocalPort, targetPort))
+	}
+	return pod, podPortPairs, nil
+}
+
+// GetProjectSource returns the directory of the project sources in the containers of the devfile component,
+// as set by the PROJECT_SOURCE environment variable of its containers mounting the sources
+func (f *DefaultPortForwarder) GetProjectSource() (string, error) {
+	pod, err := f.kClient.GetPodUsingComponentName(f.componentName)
+	if err != nil {
+		return "", err
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == generator.EnvProjectsSrc {
+				return env.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no container of component %s mounts the project sources", f.componentName)
+}
+
+// ForwardPortPairs forwards several ports to the remote pod, portPairs are in the "localPort:RemotePort" format
+func (f *DefaultPortForwarder) ForwardPortPairs(portPairs []string, stopChan, readyChan chan struct{}, isDevfile bool) error {
 	var pod *corev1.Pod
 	var conf *rest.Config
 	var err error
 
-	if f.kClient != nil && isDevfile {
+	if f.serviceName != "" {
+		conf, err = f.kClient.KubeConfig.ClientConfig()
+		if err != nil {
+			return err
+		}
+
+		pod, portPairs, err = f.getServicePod(portPairs)
+		if err != nil {
+			return err
+		}
+	} else if f.kClient != nil && isDevfile {
 		conf, err = f.kClient.KubeConfig.ClientConfig()
 		if err != nil {
 			return err
@@ -80,10 +216,10 @@ func (f *DefaultPortForwarder) ForwardPorts(portPair string, stopChan, readyChan
 	req := f.kClient.GeneratePortForwardReq(pod.Name)
 
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
-	fw, err := portforward.New(dialer, []string{portPair}, stopChan, readyChan, f.Out, f.ErrOut)
+	fw, err := portforward.New(dialer, portPairs, stopChan, readyChan, f.Out, f.ErrOut)
 	if err != nil {
 		return err
 	}
-	log.Info("Started port forwarding at ports -", portPair)
+	log.Info("Started port forwarding at ports -", strings.Join(portPairs, " "))
 	return fw.ForwardPorts()
 }
@@ -0,0 +1,128 @@
+package debug
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/pkg/errors"
+)
+
+// Runtime is a language runtime whose debugger odo can attach editors to
+type Runtime string
+
+const (
+	// NodeRuntime is the Node.js runtime, debugged with the Node inspector
+	NodeRuntime Runtime = "nodejs"
+	// JavaRuntime is a Java runtime, debugged with JDWP
+	JavaRuntime Runtime = "java"
+	// GoRuntime is the Go runtime, debugged with delve
+	GoRuntime Runtime = "go"
+	// PythonRuntime is the Python runtime, debugged with debugpy
+	PythonRuntime Runtime = "python"
+
+	// RuntimeAttribute is the attribute of the debug command, or of the devfile metadata, setting the debug runtime
+	RuntimeAttribute = "odo.dev/debug-runtime"
+
+	// debugEndpointPrefix is the prefix of the names of the endpoints of the debug container forwarded with the debug port
+	debugEndpointPrefix = "debug"
+)
+
+// runtimeAliases are the devfile languages, project types and stacks of the debug runtimes
+var runtimeAliases = map[string]Runtime{
+	"nodejs":      NodeRuntime,
+	"node":        NodeRuntime,
+	"javascript":  NodeRuntime,
+	"typescript":  NodeRuntime,
+	"java":        JavaRuntime,
+	"jdwp":        JavaRuntime,
+	"maven":       JavaRuntime,
+	"gradle":      JavaRuntime,
+	"springboot":  JavaRuntime,
+	"quarkus":     JavaRuntime,
+	"openliberty": JavaRuntime,
+	"wildfly":     JavaRuntime,
+	"vertx":       JavaRuntime,
+	"go":          GoRuntime,
+	"golang":      GoRuntime,
+	"delve":       GoRuntime,
+	"python":      PythonRuntime,
+	"debugpy":     PythonRuntime,
+	"django":      PythonRuntime,
+	"flask":       PythonRuntime,
+}
+
+// Runtimes returns the supported debug runtimes
+func Runtimes() []Runtime {
+	return []Runtime{NodeRuntime, JavaRuntime, GoRuntime, PythonRuntime}
+}
+
+// GetRuntime returns the debug runtime of the component, set by the odo.dev/debug-runtime attribute of
+// its debug command or devfile metadata, or guessed from the language, project type or name of the devfile.
+// An empty runtime is returned if it can't be guessed.
+func GetRuntime(devObj parser.DevfileObj, debugCommand devfilev1.Command) (Runtime, error) {
+	metadata := devObj.Data.GetMetadata()
+	for _, attrs := range []attributes.Attributes{debugCommand.Attributes, metadata.Attributes} {
+		if !attrs.Exists(RuntimeAttribute) {
+			continue
+		}
+		var err error
+		value := attrs.GetString(RuntimeAttribute, &err)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to read the %s attribute", RuntimeAttribute)
+		}
+		runtime, ok := runtimeAliases[strings.ToLower(value)]
+		if !ok {
+			return "", fmt.Errorf("the debug runtime %q of the %s attribute is not one of %v", value, RuntimeAttribute, Runtimes())
+		}
+		return runtime, nil
+	}
+
+	for _, value := range []string{metadata.Language, metadata.ProjectType, metadata.Name} {
+		if runtime, ok := runtimeAliases[strings.ToLower(value)]; ok {
+			return runtime, nil
+		}
+	}
+	// stack names are often suffixed by a flavour, such as java-springboot or python-django
+	for _, part := range strings.Split(strings.ToLower(metadata.Name), "-") {
+		if runtime, ok := runtimeAliases[part]; ok {
+			return runtime, nil
+		}
+	}
+	return "", nil
+}
+
+// GetDebugEndpointPorts returns the target ports of the endpoints whose name starts with "debug" of the
+// container running the debug command, they are forwarded along with the debug port
+func GetDebugEndpointPorts(devObj parser.DevfileObj, debugCommand devfilev1.Command, debugPort int) ([]int, error) {
+	if debugCommand.Exec == nil {
+		return nil, nil
+	}
+
+	containers, err := devObj.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	portSet := map[int]bool{}
+	for _, container := range containers {
+		if container.Name != debugCommand.Exec.Component || container.Container == nil {
+			continue
+		}
+		for _, endpoint := range container.Container.Endpoints {
+			if strings.HasPrefix(endpoint.Name, debugEndpointPrefix) && endpoint.TargetPort != debugPort {
+				portSet[endpoint.TargetPort] = true
+			}
+		}
+	}
+
+	var ports []int
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
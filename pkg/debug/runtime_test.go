@@ -0,0 +1,123 @@
+package debug
+
+import (
+	"reflect"
+	"testing"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/openshift/odo/pkg/testingutil"
+)
+
+func fakeDevfileObj(t *testing.T, metadata devfilepkg.DevfileMetadata, components ...devfilev1.Component) parser.DevfileObj {
+	devfileData, err := data.NewDevfileData(string(data.APIVersion200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	devfileData.SetMetadata(metadata)
+	if err = devfileData.AddComponents(components); err != nil {
+		t.Fatal(err)
+	}
+	return parser.DevfileObj{Data: devfileData}
+}
+
+func TestGetRuntime(t *testing.T) {
+	tests := []struct {
+		name         string
+		metadata     devfilepkg.DevfileMetadata
+		debugCommand devfilev1.Command
+		want         Runtime
+		wantErr      bool
+	}{
+		{
+			name:     "Case 1: Runtime from the language",
+			metadata: devfilepkg.DevfileMetadata{Name: "nodejs", Language: "JavaScript"},
+			want:     NodeRuntime,
+		},
+		{
+			name:     "Case 2: Runtime from the stack name",
+			metadata: devfilepkg.DevfileMetadata{Name: "python-django"},
+			want:     PythonRuntime,
+		},
+		{
+			name:     "Case 3: Runtime from the devfile attribute",
+			metadata: devfilepkg.DevfileMetadata{Name: "nodejs", Attributes: attributes.Attributes{}.PutString(RuntimeAttribute, "delve")},
+			want:     GoRuntime,
+		},
+		{
+			name:         "Case 4: Runtime from the debug command attribute",
+			metadata:     devfilepkg.DevfileMetadata{Name: "nodejs", Attributes: attributes.Attributes{}.PutString(RuntimeAttribute, "go")},
+			debugCommand: devfilev1.Command{Id: "debug", Attributes: attributes.Attributes{}.PutString(RuntimeAttribute, "java")},
+			want:         JavaRuntime,
+		},
+		{
+			name:     "Case 5: Invalid runtime attribute",
+			metadata: devfilepkg.DevfileMetadata{Name: "nodejs", Attributes: attributes.Attributes{}.PutString(RuntimeAttribute, "cobol")},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 6: Unknown runtime",
+			metadata: devfilepkg.DevfileMetadata{Name: "php-laravel", Language: "PHP"},
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetRuntime(fakeDevfileObj(t, tt.metadata), tt.debugCommand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRuntime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetRuntime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDebugEndpointPorts(t *testing.T) {
+	runtimeContainer := testingutil.GetFakeContainerComponent("runtime")
+	runtimeContainer.Container.Endpoints = []devfilev1.Endpoint{
+		{Name: "http", TargetPort: 8080},
+		{Name: "debug", TargetPort: 5858},
+		{Name: "debug-adapter", TargetPort: 5678},
+	}
+	toolsContainer := testingutil.GetFakeContainerComponent("tools")
+	toolsContainer.Container.Endpoints = []devfilev1.Endpoint{
+		{Name: "debug-tools", TargetPort: 2345},
+	}
+	devObj := fakeDevfileObj(t, devfilepkg.DevfileMetadata{Name: "python"}, runtimeContainer, toolsContainer)
+
+	tests := []struct {
+		name         string
+		debugCommand devfilev1.Command
+		want         []int
+	}{
+		{
+			name: "Case 1: Debug endpoints of the debug container other than the debug port",
+			debugCommand: devfilev1.Command{
+				Id:           "debug",
+				CommandUnion: devfilev1.CommandUnion{Exec: &devfilev1.ExecCommand{Component: "runtime"}},
+			},
+			want: []int{5678},
+		},
+		{
+			name: "Case 2: No debug command",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDebugEndpointPorts(devObj, tt.debugCommand, 5858)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDebugEndpointPorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
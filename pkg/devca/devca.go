@@ -0,0 +1,278 @@
+// Package devca manages the local development certificate authority used by odo to issue
+// certificates for secure URLs that browsers and HTTP clients can be told to trust
+package devca
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/preference"
+)
+
+const (
+	// caDirName is the directory holding the CA, relative to the odo configuration directory
+	caDirName = "ca"
+	// CertFileName is the name of the PEM encoded CA certificate
+	CertFileName = "odo-dev-ca.crt"
+	// keyFileName is the name of the PEM encoded CA private key
+	keyFileName = "odo-dev-ca.key"
+
+	// CommonName is the common name of the CA and the issuer of the certificates it signs
+	CommonName = "odo local development CA"
+	// legacyCommonName is the common name of the self-signed certificates created by previous odo versions
+	legacyCommonName = "Odo self-signed certificate"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+	// certificateValidity stays well below the 398 days accepted by browsers for leaf certificates
+	certificateValidity = 90 * 24 * time.Hour
+	// RenewBefore is how long before their expiration certificates are renewed
+	RenewBefore = 30 * 24 * time.Hour
+)
+
+// CA is the local development certificate authority
+type CA struct {
+	// Certificate is the certificate of the CA
+	Certificate *x509.Certificate
+	// CertFile is the path of the CA certificate, to be trusted by the user
+	CertFile string
+
+	privateKey *rsa.PrivateKey
+}
+
+// GetDir returns the directory holding the CA under the odo configuration directory
+func GetDir() (string, error) {
+	configDir, err := preference.GetConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get the odo configuration directory")
+	}
+	return filepath.Join(configDir, caDirName), nil
+}
+
+// LoadOrCreate loads the CA stored in dir, generating it when it doesn't exist or is about to expire
+// created is true when a new CA was generated and needs to be trusted by the user
+func LoadOrCreate(dir string) (ca *CA, created bool, err error) {
+	certFile := filepath.Join(dir, CertFileName)
+	keyFile := filepath.Join(dir, keyFileName)
+
+	ca, err = load(certFile, keyFile)
+	if err == nil {
+		if time.Now().Add(RenewBefore).Before(ca.Certificate.NotAfter) {
+			return ca, false, nil
+		}
+		klog.V(4).Infof("the local development CA %s expires on %s, generating a new one", certFile, ca.Certificate.NotAfter)
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return nil, false, err
+	}
+
+	ca, err = create(dir, certFile, keyFile)
+	if err != nil {
+		return nil, false, err
+	}
+	return ca, true, nil
+}
+
+func load(certFile, keyFile string) (*CA, error) {
+	certPem, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPem, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := parseCertificate(certPem)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the CA certificate %s", certFile)
+	}
+	block, _ := pem.Decode(keyPem)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in the CA private key %s", keyFile)
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the CA private key %s", keyFile)
+	}
+	return &CA{Certificate: certificate, CertFile: certFile, privateKey: privateKey}, nil
+}
+
+func create(dir, certFile, keyFile string) (*CA, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate rsa key")
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   CommonName,
+			Organization: []string{"Odo"},
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the CA certificate")
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the CA certificate")
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "unable to create directory %s", dir)
+	}
+	if err = ioutil.WriteFile(keyFile, encodePrivateKey(privateKey), 0600); err != nil {
+		return nil, errors.Wrapf(err, "unable to write the CA private key %s", keyFile)
+	}
+	if err = ioutil.WriteFile(certFile, encodeCertificate(der), 0644); err != nil {
+		return nil, errors.Wrapf(err, "unable to write the CA certificate %s", certFile)
+	}
+	klog.V(4).Infof("generated the local development CA %s", certFile)
+	return &CA{Certificate: certificate, CertFile: certFile, privateKey: privateKey}, nil
+}
+
+// IssueCertificate returns a PEM encoded certificate and private key signed by the CA,
+// valid for the hosts and their subdomains
+func (ca *CA) IssueCertificate(hosts ...string) (certPem []byte, keyPem []byte, err error) {
+	if len(hosts) == 0 {
+		return nil, nil, errors.New("unable to create a certificate without host")
+	}
+	var dnsNames []string
+	for _, host := range hosts {
+		dnsNames = append(dnsNames, host, "*."+host)
+	}
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate rsa key")
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	notAfter := now.Add(certificateValidity)
+	if notAfter.After(ca.Certificate.NotAfter) {
+		notAfter = ca.Certificate.NotAfter
+	}
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   hosts[0],
+			Organization: []string{"Odo"},
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca.Certificate, &privateKey.PublicKey, ca.privateKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to create certificate for %s", strings.Join(hosts, ", "))
+	}
+	return encodeCertificate(der), encodePrivateKey(privateKey), nil
+}
+
+// IsManaged returns true if the PEM encoded certificate was generated by odo, either signed by
+// the local development CA or self-signed by a previous odo version, and can be replaced by odo
+func IsManaged(certPem []byte) bool {
+	certificate, err := parseCertificate(certPem)
+	if err != nil {
+		return false
+	}
+	return certificate.Issuer.CommonName == CommonName || certificate.Subject.CommonName == legacyCommonName
+}
+
+// NeedsRenewal returns true if the PEM encoded certificate is not signed by the CA for every host
+// or expires in less than RenewBefore
+func (ca *CA) NeedsRenewal(certPem []byte, hosts ...string) bool {
+	certificate, err := parseCertificate(certPem)
+	if err != nil {
+		return true
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	for _, host := range hosts {
+		_, err = certificate.Verify(x509.VerifyOptions{
+			DNSName:     host,
+			Roots:       roots,
+			CurrentTime: time.Now().Add(RenewBefore),
+		})
+		if err != nil {
+			klog.V(4).Infof("the certificate for %s needs to be renewed: %v", host, err)
+			return true
+		}
+	}
+	return false
+}
+
+// TrustInstructions returns the instructions to add the CA certificate to the trust store of the system
+func TrustInstructions(certFile string) string {
+	var command string
+	switch runtime.GOOS {
+	case "darwin":
+		command = fmt.Sprintf("sudo security add-trusted-cert -d -r trustRoot -k /Library/Keychains/System.keychain %s", certFile)
+	case "windows":
+		command = fmt.Sprintf("certutil -addstore -f ROOT %s", certFile)
+	default:
+		command = fmt.Sprintf("sudo cp %s /usr/local/share/ca-certificates/%s && sudo update-ca-certificates", certFile, CertFileName)
+	}
+	return fmt.Sprintf("odo generated a local development CA to sign the certificates of secure URLs: %s\n"+
+		"To trust the secure URLs in your browser and HTTP clients, add it to the trust store of your system:\n"+
+		"  %s\n"+
+		"Browsers using their own trust store (e.g. Firefox) need the certificate to be imported in their settings", certFile, command)
+}
+
+func parseCertificate(certPem []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPem)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate certificate serial number")
+	}
+	return serialNumber, nil
+}
+
+func encodeCertificate(der []byte) []byte {
+	out := &bytes.Buffer{}
+	_ = pem.Encode(out, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return out.Bytes()
+}
+
+func encodePrivateKey(privateKey *rsa.PrivateKey) []byte {
+	out := &bytes.Buffer{}
+	_ = pem.Encode(out, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	return out.Bytes()
+}
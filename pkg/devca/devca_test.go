@@ -0,0 +1,172 @@
+package devca
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadOrCreate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "odoca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, created, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created {
+		t.Errorf("the CA should have been created")
+	}
+	if !ca.Certificate.IsCA || ca.Certificate.Subject.CommonName != CommonName {
+		t.Errorf("invalid CA certificate %v", ca.Certificate.Subject)
+	}
+
+	loaded, created, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Errorf("the existing CA should have been loaded")
+	}
+	if !loaded.Certificate.Equal(ca.Certificate) {
+		t.Errorf("the loaded CA is different from the created one")
+	}
+
+	if !strings.Contains(TrustInstructions(ca.CertFile), ca.CertFile) {
+		t.Errorf("the trust instructions don't mention %s", ca.CertFile)
+	}
+}
+
+func TestIssueCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "odoca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, _, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem, _, err := ca.IssueCertificate("1.2.3.4.nip.io", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := parseCertificate(certPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	for _, host := range []string{"1.2.3.4.nip.io", "myurl.1.2.3.4.nip.io", "example.com", "myurl.example.com"} {
+		if _, err = certificate.Verify(x509.VerifyOptions{DNSName: host, Roots: roots}); err != nil {
+			t.Errorf("the certificate is not valid for %s: %v", host, err)
+		}
+	}
+	if certificate.NotAfter.After(time.Now().Add(certificateValidity)) {
+		t.Errorf("the certificate expires on %s, after the expected validity", certificate.NotAfter)
+	}
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "odoca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, _, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDir, err := ioutil.TempDir("", "odoca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(otherDir)
+	otherCA, _, err := LoadOrCreate(otherDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := "1.2.3.4.nip.io"
+	validCert, _, err := ca.IssueCertificate(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCACert, _, err := otherCA.IssueCertificate(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a CA close to its expiration issues certificates expiring with it
+	expiringCA := *ca
+	expiringCertificate := *ca.Certificate
+	expiringCertificate.NotAfter = time.Now().Add(RenewBefore / 2)
+	expiringCA.Certificate = &expiringCertificate
+	expiringCert, _, err := expiringCA.IssueCertificate(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cert    []byte
+		host    string
+		managed bool
+		want    bool
+	}{
+		{
+			name:    "Case 1: Valid certificate",
+			cert:    validCert,
+			host:    host,
+			managed: true,
+			want:    false,
+		},
+		{
+			name:    "Case 2: Certificate for another host",
+			cert:    validCert,
+			host:    "example.com",
+			managed: true,
+			want:    true,
+		},
+		{
+			name:    "Case 3: Certificate signed by another CA",
+			cert:    otherCACert,
+			host:    host,
+			managed: true,
+			want:    true,
+		},
+		{
+			name:    "Case 4: Certificate expiring soon",
+			cert:    expiringCert,
+			host:    host,
+			managed: true,
+			want:    true,
+		},
+		{
+			name:    "Case 5: Invalid certificate",
+			cert:    []byte("invalid"),
+			host:    host,
+			managed: false,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ca.NeedsRenewal(tt.cert, tt.host); got != tt.want {
+				t.Errorf("NeedsRenewal() = %v, want %v", got, tt.want)
+			}
+			if got := IsManaged(tt.cert); got != tt.managed {
+				t.Errorf("IsManaged() = %v, want %v", got, tt.managed)
+			}
+		})
+	}
+}
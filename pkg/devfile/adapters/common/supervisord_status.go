@@ -0,0 +1,189 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+)
+
+const (
+	// SupervisordStatusRunning is the status of a running supervisord program
+	SupervisordStatusRunning = "RUNNING"
+	// SupervisordStatusStarting is the status of a supervisord program being started
+	SupervisordStatusStarting = "STARTING"
+	// SupervisordStatusBackoff is the status of a supervisord program which exited too quickly and is restarted
+	SupervisordStatusBackoff = "BACKOFF"
+	// SupervisordStatusExited is the status of a supervisord program which exited
+	SupervisordStatusExited = "EXITED"
+	// SupervisordStatusFatal is the status of a supervisord program which couldn't be started and isn't restarted anymore
+	SupervisordStatusFatal = "FATAL"
+
+	// CrashLoopRestarts is the number of restarts of a program within CrashLoopWindow from which it is crash looping
+	CrashLoopRestarts = 3
+	// CrashLoopWindow is the period during which the restarts of a program are counted to detect a crash loop
+	CrashLoopWindow = 5 * time.Minute
+)
+
+var (
+	supervisordPIDRegexp      = regexp.MustCompile(`pid (\d+)`)
+	supervisordExitCodeRegexp = regexp.MustCompile(`exit status (\d+)`)
+)
+
+// SupervisordProgramStatus corresponds to the statuses reported by 'supervisord ctl status', example:
+// - debugrun                         STOPPED
+// - devrun                           RUNNING   pid 5640, uptime 11 days, 21:56:20
+// - devrun                           BACKOFF   exit status 1
+type SupervisordProgramStatus struct {
+	Program string
+	Status  string
+	// PID is the process ID of the running program, 0 if it is not running
+	PID int
+	// ExitCode is the exit code of the program, when supervisord reports it
+	ExitCode *int
+}
+
+// ParseSupervisordStatus parses the output of 'supervisord ctl status'
+func ParseSupervisordStatus(lines []string) []SupervisordProgramStatus {
+	result := []SupervisordProgramStatus{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		status := SupervisordProgramStatus{Program: fields[0], Status: fields[1]}
+		description := strings.Join(fields[2:], " ")
+		if match := supervisordPIDRegexp.FindStringSubmatch(description); match != nil && strings.EqualFold(status.Status, SupervisordStatusRunning) {
+			status.PID, _ = strconv.Atoi(match[1])
+		}
+		if match := supervisordExitCodeRegexp.FindStringSubmatch(description); match != nil {
+			exitCode, _ := strconv.Atoi(match[1])
+			status.ExitCode = &exitCode
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// SupervisordProgramHistory is the state of a supervisord program across the status queries
+type SupervisordProgramHistory struct {
+	SupervisordProgramStatus
+	// Restarts is the number of times the program was seen exiting or running with a new process
+	Restarts int
+	// LastExitCode is the last exit code reported for the program
+	LastExitCode *int
+	// CrashLoop indicates that the program is crash looping
+	CrashLoop bool
+	// CrashLoopStarted indicates that the program started crash looping since its previous status
+	CrashLoopStarted bool
+
+	restartTimes []time.Time
+}
+
+// IsCrashLooping returns true if supervisord gave up restarting the program, restarts it after it exited too quickly,
+// or restarted it at least CrashLoopRestarts times during the last CrashLoopWindow
+func (p SupervisordProgramHistory) IsCrashLooping(now time.Time) bool {
+	if strings.EqualFold(p.Status, SupervisordStatusFatal) || strings.EqualFold(p.Status, SupervisordStatusBackoff) {
+		return true
+	}
+	recentRestarts := 0
+	for _, restartTime := range p.restartTimes {
+		if now.Sub(restartTime) <= CrashLoopWindow {
+			recentRestarts++
+		}
+	}
+	return recentRestarts >= CrashLoopRestarts
+}
+
+// SupervisordHistory tracks the restarts and exit codes of the supervisord programs of a container
+type SupervisordHistory struct {
+	programs map[string]*SupervisordProgramHistory
+}
+
+// NewSupervisordHistory returns an empty SupervisordHistory
+func NewSupervisordHistory() *SupervisordHistory {
+	return &SupervisordHistory{programs: map[string]*SupervisordProgramHistory{}}
+}
+
+// Update records the statuses of the programs queried at the given time and returns the history of these programs
+func (h *SupervisordHistory) Update(statuses []SupervisordProgramStatus, now time.Time) []SupervisordProgramHistory {
+	result := make([]SupervisordProgramHistory, 0, len(statuses))
+	for _, status := range statuses {
+		program, ok := h.programs[status.Program]
+		if !ok {
+			program = &SupervisordProgramHistory{SupervisordProgramStatus: status}
+			h.programs[status.Program] = program
+		} else if isSupervisordRestart(program.SupervisordProgramStatus, status) {
+			program.Restarts++
+			program.restartTimes = append(program.restartTimes, now)
+		}
+
+		if status.ExitCode != nil {
+			program.LastExitCode = status.ExitCode
+		}
+		// the PID of the last running process is kept to detect restarts happening between two queries
+		pid := program.PID
+		program.SupervisordProgramStatus = status
+		if status.PID == 0 {
+			program.PID = pid
+		}
+
+		// forget the restarts outside of the crash loop window
+		for len(program.restartTimes) > 0 && now.Sub(program.restartTimes[0]) > CrashLoopWindow {
+			program.restartTimes = program.restartTimes[1:]
+		}
+
+		crashLoop := program.IsCrashLooping(now)
+		program.CrashLoopStarted = crashLoop && !program.CrashLoop
+		program.CrashLoop = crashLoop
+
+		current := *program
+		current.SupervisordProgramStatus.PID = status.PID
+		result = append(result, current)
+	}
+	return result
+}
+
+// isSupervisordRestart returns true if the program exited, or runs a new process, since its previous status
+func isSupervisordRestart(previous, current SupervisordProgramStatus) bool {
+	if isSupervisordExitStatus(current.Status) {
+		return !isSupervisordExitStatus(previous.Status)
+	}
+	// the previous process exited and was restarted between the two queries
+	return current.PID != 0 && previous.PID != 0 && current.PID != previous.PID && !isSupervisordExitStatus(previous.Status)
+}
+
+func isSupervisordExitStatus(status string) bool {
+	return strings.EqualFold(status, SupervisordStatusExited) || strings.EqualFold(status, SupervisordStatusBackoff) || strings.EqualFold(status, SupervisordStatusFatal)
+}
+
+// ReportSupervisordStatus emits the status of the supervisord programs of the container, and a crash loop event for each
+// program which started crash looping
+func ReportSupervisordStatus(loggingClient machineoutput.MachineEventLoggingClient, containerName string, programs []SupervisordProgramHistory) {
+	entries := []machineoutput.SupervisordStatusEntry{}
+	for _, program := range programs {
+		entries = append(entries, machineoutput.SupervisordStatusEntry{
+			Program:   program.Program,
+			Status:    program.Status,
+			Restarts:  program.Restarts,
+			ExitCode:  program.LastExitCode,
+			CrashLoop: program.CrashLoop,
+		})
+	}
+	loggingClient.SupervisordStatus(entries, machineoutput.TimestampNow())
+
+	for _, program := range programs {
+		if program.CrashLoopStarted {
+			loggingClient.SupervisordCrashLoop(machineoutput.SupervisordCrashLoop{
+				Container: containerName,
+				Program:   program.Program,
+				Status:    program.Status,
+				Restarts:  program.Restarts,
+				ExitCode:  program.LastExitCode,
+			}, machineoutput.TimestampNow())
+		}
+	}
+}
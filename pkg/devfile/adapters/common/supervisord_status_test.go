@@ -0,0 +1,135 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSupervisordStatus(t *testing.T) {
+	exitCode := 1
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  []SupervisordProgramStatus
+	}{
+		{
+			name: "Case 1: Running and stopped programs",
+			lines: []string{
+				"debugrun                         STOPPED",
+				"devrun                           RUNNING   pid 5640, uptime 11 days, 21:56:20",
+			},
+			want: []SupervisordProgramStatus{
+				{Program: "debugrun", Status: "STOPPED"},
+				{Program: "devrun", Status: "RUNNING", PID: 5640},
+			},
+		},
+		{
+			name: "Case 2: Program which exited with an error",
+			lines: []string{
+				"devrun                           BACKOFF   exit status 1; not expected",
+			},
+			want: []SupervisordProgramStatus{
+				{Program: "devrun", Status: "BACKOFF", ExitCode: &exitCode},
+			},
+		},
+		{
+			name:  "Case 3: Unexpected output",
+			lines: []string{"", "unexpected"},
+			want:  []SupervisordProgramStatus{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseSupervisordStatus(tt.lines)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSupervisordStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupervisordHistory(t *testing.T) {
+	exitCode := 2
+	running := func(pid int) []SupervisordProgramStatus {
+		return []SupervisordProgramStatus{{Program: "devrun", Status: SupervisordStatusRunning, PID: pid}}
+	}
+	exited := []SupervisordProgramStatus{{Program: "devrun", Status: SupervisordStatusExited, ExitCode: &exitCode}}
+
+	tests := []struct {
+		name             string
+		statuses         [][]SupervisordProgramStatus
+		interval         time.Duration
+		wantRestarts     int
+		wantExitCode     *int
+		wantCrashLoop    bool
+		wantLoopStarted  bool
+		wantFinalRunning bool
+	}{
+		{
+			name:             "Case 1: Program running the same process",
+			statuses:         [][]SupervisordProgramStatus{running(10), running(10), running(10)},
+			interval:         time.Second,
+			wantFinalRunning: true,
+		},
+		{
+			name:             "Case 2: Program restarted between the queries",
+			statuses:         [][]SupervisordProgramStatus{running(10), running(11), running(12)},
+			interval:         time.Second,
+			wantRestarts:     2,
+			wantFinalRunning: true,
+		},
+		{
+			name:            "Case 3: Program crash looping",
+			statuses:        [][]SupervisordProgramStatus{running(10), exited, running(11), exited, running(12), exited},
+			interval:        time.Second,
+			wantRestarts:    3,
+			wantExitCode:    &exitCode,
+			wantCrashLoop:   true,
+			wantLoopStarted: true,
+		},
+		{
+			name:             "Case 4: Restarts outside of the crash loop window",
+			statuses:         [][]SupervisordProgramStatus{running(10), running(11), running(12), running(13)},
+			interval:         CrashLoopWindow,
+			wantRestarts:     3,
+			wantFinalRunning: true,
+		},
+		{
+			name:            "Case 5: Program given up by supervisord",
+			statuses:        [][]SupervisordProgramStatus{{{Program: "devrun", Status: SupervisordStatusFatal}}},
+			interval:        time.Second,
+			wantCrashLoop:   true,
+			wantLoopStarted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := NewSupervisordHistory()
+			now := time.Now()
+			var programs []SupervisordProgramHistory
+			for _, statuses := range tt.statuses {
+				programs = history.Update(statuses, now)
+				now = now.Add(tt.interval)
+			}
+
+			if len(programs) != 1 {
+				t.Fatalf("got %d programs, want 1", len(programs))
+			}
+			program := programs[0]
+			if program.Restarts != tt.wantRestarts {
+				t.Errorf("got %d restarts, want %d", program.Restarts, tt.wantRestarts)
+			}
+			if !reflect.DeepEqual(program.LastExitCode, tt.wantExitCode) {
+				t.Errorf("got exit code %v, want %v", program.LastExitCode, tt.wantExitCode)
+			}
+			if program.CrashLoop != tt.wantCrashLoop || program.CrashLoopStarted != tt.wantLoopStarted {
+				t.Errorf("got crash loop %v and crash loop started %v, want %v and %v", program.CrashLoop, program.CrashLoopStarted, tt.wantCrashLoop, tt.wantLoopStarted)
+			}
+			if (program.Status == SupervisordStatusRunning) != tt.wantFinalRunning {
+				t.Errorf("got status %s", program.Status)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package component
 
 import (
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/openshift/odo/pkg/devfile/adapters/common"
@@ -137,7 +136,9 @@ func createSupervisordStatusReconciler(loggingClient machineoutput.MachineEventL
 
 	go func() {
 		// Map key: containerName (within pod) -> list of statuses from 'supervisord ctl status'
-		lastContainerStatus := map[string][]supervisordStatus{}
+		lastContainerStatus := map[string][]common.SupervisordProgramStatus{}
+		// Map key: same as above -> restarts and exit codes of the programs of the container
+		histories := map[string]*common.SupervisordHistory{}
 
 		for {
 
@@ -163,16 +164,12 @@ func createSupervisordStatusReconciler(loggingClient machineoutput.MachineEventL
 				reportChange = true
 			}
 
-			entries := []machineoutput.SupervisordStatusEntry{}
-
-			for _, status := range event.status {
-				entries = append(entries, machineoutput.SupervisordStatusEntry{
-					Program: status.program,
-					Status:  status.status,
-				})
+			history, hasHistory := histories[event.containerName]
+			if !hasHistory {
+				history = common.NewSupervisordHistory()
+				histories[event.containerName] = history
 			}
-
-			loggingClient.SupervisordStatus(entries, machineoutput.TimestampNow())
+			common.ReportSupervisordStatus(loggingClient, event.containerName, history.Update(event.status, time.Now()))
 
 			if reportChange {
 				klog.V(4).Infof("Ccontainer %v status has changed - is: %v", event.containerName, event.status)
@@ -209,7 +206,7 @@ func (sw *supervisordStatusWatcher) querySupervisordStatusFromContainers(a Adapt
 }
 
 // supervisordStatusesEqual is a simple comparison of []supervisord that ignores slice element order
-func supervisordStatusesEqual(one []supervisordStatus, two []supervisordStatus) bool {
+func supervisordStatusesEqual(one []common.SupervisordProgramStatus, two []common.SupervisordProgramStatus) bool {
 	if len(one) != len(two) {
 		return false
 	}
@@ -234,7 +231,7 @@ func supervisordStatusesEqual(one []supervisordStatus, two []supervisordStatus)
 
 // getSupervisordStatusInContainer executes 'supervisord ctl status' within the container, parses the output,
 // and returns the status
-func getSupervisordStatusInContainer(containerID string, a Adapter) []supervisordStatus {
+func getSupervisordStatusInContainer(containerID string, a Adapter) []common.SupervisordProgramStatus {
 
 	command := []string{common.SupervisordBinaryPath, common.SupervisordCtlSubCommand, "status"}
 
@@ -260,32 +257,11 @@ func getSupervisordStatusInContainer(containerID string, a Adapter) []supervisor
 		return nil
 	}
 
-	result := []supervisordStatus{}
-
-	for _, line := range consoleResult {
-
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
-
-		result = append(result, supervisordStatus{program: fields[0], status: fields[1]})
-	}
-
-	return result
-}
-
-// supervisordStatus corresponds to the statuses reported by 'supervisord ctl status', example:
-// - debugrun                         STOPPED
-// - devrun                           RUNNING   pid 5640, uptime 11 days, 21:56:20
-// Only the first and second fields are included (no pod, uptime, etc)
-type supervisordStatus struct {
-	program string
-	status  string
+	return common.ParseSupervisordStatus(consoleResult)
 }
 
 // All statuses seen within the container
 type supervisordStatusEvent struct {
 	containerName string
-	status        []supervisordStatus
+	status        []common.SupervisordProgramStatus
 }
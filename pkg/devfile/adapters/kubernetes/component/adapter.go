@@ -32,6 +32,7 @@ import (
 	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes/utils"
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/occlient"
 	odoutil "github.com/openshift/odo/pkg/odo/util"
 	storagepkg "github.com/openshift/odo/pkg/storage"
@@ -42,6 +43,9 @@ import (
 
 const supervisorDStatusWaitTimeInterval = 1
 
+// supervisorDStatusChecks is the maximum number of times the supervisord status is checked after the devfile commands are executed
+const supervisorDStatusChecks = 5
+
 // New instantiates a component adapter
 func New(adapterContext common.AdapterContext, client occlient.Client) Adapter {
 
@@ -200,6 +204,12 @@ func (a Adapter) Push(parameters common.PushParameters) (err error) {
 		return errors.Wrap(err, "error while waiting for deployment rollout")
 	}
 
+	// the source PVC of a previous source volume mode is not mounted anymore once the deployment is rolled out
+	err = storage.DeleteUnusedSourcePVCs(*a.Client.GetKubeClient(), a.ComponentName, deployment)
+	if err != nil {
+		return err
+	}
+
 	// Wait for Pod to be in running state otherwise we can't sync data or exec commands to it.
 	pod, err := a.getPod(true)
 	if err != nil {
@@ -301,10 +311,9 @@ func (a Adapter) Push(parameters common.PushParameters) (err error) {
 }
 
 // CheckSupervisordCtlStatus checks the supervisord status according to the given command
-// if the command is not in a running state, we fetch the last 20 lines of the component's log and display it
+// the status is checked a few times to detect the programs which exit or keep being restarted; if the command
+// is not in a running state, we fetch the last 20 lines of the component's log and display it
 func (a Adapter) CheckSupervisordCtlStatus(command devfilev1.Command) error {
-	statusInContainer := getSupervisordStatusInContainer(a.pod.Name, command.Exec.Component, a)
-
 	supervisordProgramName := "devrun"
 
 	// if the command is a debug one, we check against `debugrun`
@@ -312,32 +321,80 @@ func (a Adapter) CheckSupervisordCtlStatus(command devfilev1.Command) error {
 		supervisordProgramName = "debugrun"
 	}
 
-	for _, status := range statusInContainer {
-		if strings.EqualFold(status.program, supervisordProgramName) {
-			if strings.EqualFold(status.status, "running") {
-				return nil
-			} else {
-				numberOfLines := 20
-				log.Warningf("devfile command \"%s\" exited with error status within %d sec", command.Id, supervisorDStatusWaitTimeInterval)
-				log.Infof("Last %d lines of the component's log:", numberOfLines)
-
-				rd, err := a.Log(false, command)
-				if err != nil {
-					return err
-				}
+	history := common.NewSupervisordHistory()
+	runningPID := 0
+	for i := 0; i < supervisorDStatusChecks; i++ {
+		if i > 0 {
+			<-time.After(supervisorDStatusWaitTimeInterval * time.Second)
+		}
 
-				err = util.DisplayLog(false, rd, os.Stderr, a.ComponentName, numberOfLines)
-				if err != nil {
-					return err
-				}
+		var program *common.SupervisordProgramHistory
+		programs := history.Update(getSupervisordStatusInContainer(a.pod.Name, command.Exec.Component, a), time.Now())
+		for j := range programs {
+			if strings.EqualFold(programs[j].Program, supervisordProgramName) {
+				program = &programs[j]
+				break
+			}
+		}
+		if program == nil {
+			return fmt.Errorf("the supervisord program %s not found", supervisordProgramName)
+		}
 
-				log.Info("To get the full log output, please run 'odo log'")
+		switch {
+		case program.CrashLoop:
+			a.Logger().SupervisordCrashLoop(machineoutput.SupervisordCrashLoop{
+				Container: command.Exec.Component,
+				Program:   program.Program,
+				Status:    program.Status,
+				Restarts:  program.Restarts,
+				ExitCode:  program.LastExitCode,
+			}, machineoutput.TimestampNow())
+			log.Errorf("devfile command \"%s\" is crash looping, status %s%s", command.Id, program.Status, exitCodeDescription(program.LastExitCode))
+			if err := a.displayLastLogLines(command); err != nil {
+				return err
+			}
+			return fmt.Errorf("devfile command %q keeps crashing after %d restarts%s", command.Id, program.Restarts, exitCodeDescription(program.LastExitCode))
 
+		case strings.EqualFold(program.Status, common.SupervisordStatusRunning):
+			// the program is considered started once the same process runs for two checks
+			if program.PID != 0 && program.PID == runningPID {
 				return nil
 			}
+			runningPID = program.PID
+
+		case strings.EqualFold(program.Status, common.SupervisordStatusExited):
+			log.Warningf("devfile command \"%s\" exited with error status within %d sec%s", command.Id, supervisorDStatusWaitTimeInterval*(i+1), exitCodeDescription(program.LastExitCode))
+			return a.displayLastLogLines(command)
 		}
 	}
-	return fmt.Errorf("the supervisord program %s not found", supervisordProgramName)
+	return nil
+}
+
+// displayLastLogLines displays the last 20 lines of the component's log
+func (a Adapter) displayLastLogLines(command devfilev1.Command) error {
+	numberOfLines := 20
+	log.Infof("Last %d lines of the component's log:", numberOfLines)
+
+	rd, err := a.Log(false, command)
+	if err != nil {
+		return err
+	}
+
+	err = util.DisplayLog(false, rd, os.Stderr, a.ComponentName, numberOfLines)
+	if err != nil {
+		return err
+	}
+
+	log.Info("To get the full log output, please run 'odo log'")
+	return nil
+}
+
+// exitCodeDescription returns the description of the exit code of a program, if any
+func exitCodeDescription(exitCode *int) string {
+	if exitCode == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (exit code %d)", *exitCode)
 }
 
 // Test runs the devfile test command
@@ -376,8 +433,15 @@ func (a Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSpe
 		LocalConfigProvider: &ei,
 	})
 
-	// handle the ephemeral storage
-	err = storage.HandleEphemeralStorage(*a.Client.GetKubeClient(), storageClient, a.ComponentName)
+	// handle the volume storing the project sources
+	sourceVolume, err := storage.GetSourceVolume(ei.GetSourceVolumeMode(), ei.GetSourceVolumeSize())
+	if err != nil {
+		return err
+	}
+	if componentExists {
+		a.warnSourceVolumeModeChange(sourceVolume.Mode)
+	}
+	err = storage.HandleSourceVolume(*a.Client.GetKubeClient(), storageClient, a.ComponentName, &sourceVolume)
 	if err != nil {
 		return err
 	}
@@ -420,8 +484,6 @@ func (a Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSpe
 	}
 	initContainers = append(initContainers, supervisordInitContainer)
 
-	var odoSourcePVCName string
-
 	// list all the pvcs for the component
 	pvcs, err := a.Client.GetKubeClient().ListPVCs(fmt.Sprintf("%v=%v", "component", a.ComponentName))
 	if err != nil {
@@ -435,15 +497,24 @@ func (a Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSpe
 			continue
 		}
 
+		// the source PVC is mounted by the odo mandatory volumes
+		if _, ok := pvc.Labels[storagelabels.SourcePVCLabel]; ok {
+			continue
+		}
+
 		generatedVolumeName, err := storage.GenerateVolumeNameFromPVC(pvc.Name)
 		if err != nil {
 			return errors.Wrapf(err, "Unable to generate volume name from pvc name")
 		}
 
-		volumeNameToVolInfo[pvc.Labels[storagelabels.StorageLabel]] = storage.VolumeInfo{
+		volumeInfo := storage.VolumeInfo{
 			PVCName:    pvc.Name,
 			VolumeName: generatedVolumeName,
 		}
+		if pvc.Spec.VolumeMode != nil {
+			volumeInfo.VolumeMode = *pvc.Spec.VolumeMode
+		}
+		volumeNameToVolInfo[pvc.Labels[storagelabels.StorageLabel]] = volumeInfo
 	}
 
 	// Get PVC volumes and Volume Mounts
@@ -452,7 +523,11 @@ func (a Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSpe
 		return err
 	}
 
-	odoMandatoryVolumes := utils.GetOdoContainerVolumes(odoSourcePVCName)
+	sourceVolumeSource, err := sourceVolume.VolumeSource()
+	if err != nil {
+		return err
+	}
+	odoMandatoryVolumes := utils.GetOdoContainerVolumes(sourceVolumeSource)
 
 	selectorLabels := map[string]string{
 		"component": componentName,
@@ -545,6 +620,24 @@ func (a Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSpe
 	return nil
 }
 
+// warnSourceVolumeModeChange warns the user when the mode of the source volume of the existing component changes,
+// since the project files are synced again to the new volume
+func (a Adapter) warnSourceVolumeModeChange(mode envinfo.SourceVolumeMode) {
+	deployment, err := a.Client.GetKubeClient().GetDeploymentByName(a.ComponentName)
+	if err != nil {
+		klog.V(4).Infof("unable to get the deployment of component %s: %v", a.ComponentName, err)
+		return
+	}
+	previousMode := storage.GetSourceVolumeMode(deployment)
+	if previousMode == "" || previousMode == mode {
+		return
+	}
+	log.Warningf("The source volume of component %s changes from %s to %s, all the project files will be synced again once the component is restarted", a.ComponentName, previousMode, mode)
+	if previousMode == envinfo.PVCSourceVolume {
+		log.Warningf("The source PVC of component %s will be deleted once the component is restarted", a.ComponentName)
+	}
+}
+
 // getFirstContainerWithSourceVolume returns the first container that set mountSources: true as well
 // as the path to the source volume inside the container.
 // Because the source volume is shared across all components that need it, we only need to sync once,
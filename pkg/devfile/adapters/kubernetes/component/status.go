@@ -4,7 +4,6 @@ import (
 	"context"
 	"reflect"
 	"sort"
-	"strings"
 	"time"
 
 	v1 "k8s.io/api/apps/v1"
@@ -180,7 +179,9 @@ func createSupervisordStatusReconciler(loggingClient machineoutput.MachineEventL
 
 	go func() {
 		// Map key: 'podUID:containerName' (within pod) -> list of statuses from 'supervisord ctl status'
-		lastContainerStatus := map[string][]supervisordStatus{}
+		lastContainerStatus := map[string][]common.SupervisordProgramStatus{}
+		// Map key: same as above -> restarts and exit codes of the programs of the container
+		histories := map[string]*common.SupervisordHistory{}
 
 		for {
 
@@ -206,16 +207,12 @@ func createSupervisordStatusReconciler(loggingClient machineoutput.MachineEventL
 				reportChange = true
 			}
 
-			entries := []machineoutput.SupervisordStatusEntry{}
-
-			for _, status := range event.status {
-				entries = append(entries, machineoutput.SupervisordStatusEntry{
-					Program: status.program,
-					Status:  status.status,
-				})
+			history, hasHistory := histories[key]
+			if !hasHistory {
+				history = common.NewSupervisordHistory()
+				histories[key] = history
 			}
-
-			loggingClient.SupervisordStatus(entries, machineoutput.TimestampNow())
+			common.ReportSupervisordStatus(loggingClient, event.containerName, history.Update(event.status, time.Now()))
 
 			if reportChange {
 				klog.V(4).Infof("Ccontainer %v status has changed - is: %v", event.containerName, event.status)
@@ -304,7 +301,7 @@ func (sw *supervisordStatusWatcher) querySupervisordStatusFromContainers(a Adapt
 }
 
 // supervisordStatusesEqual is a simple comparison of []supervisord that ignores slice element order
-func supervisordStatusesEqual(one []supervisordStatus, two []supervisordStatus) bool {
+func supervisordStatusesEqual(one []common.SupervisordProgramStatus, two []common.SupervisordProgramStatus) bool {
 	if len(one) != len(two) {
 		return false
 	}
@@ -327,7 +324,7 @@ func supervisordStatusesEqual(one []supervisordStatus, two []supervisordStatus)
 
 // getSupervisordStatusInContainer executes 'supervisord ctl status' within the pod and container, parses the output,
 // and returns the status for the container
-func getSupervisordStatusInContainer(podName string, containerName string, a Adapter) []supervisordStatus {
+func getSupervisordStatusInContainer(podName string, containerName string, a Adapter) []common.SupervisordProgramStatus {
 
 	command := []string{common.SupervisordBinaryPath, common.SupervisordCtlSubCommand, "status"}
 	compInfo := common.ComponentInfo{
@@ -352,33 +349,12 @@ func getSupervisordStatusInContainer(podName string, containerName string, a Ada
 		return nil
 	}
 
-	result := []supervisordStatus{}
-
-	for _, line := range consoleResult {
-
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
-
-		result = append(result, supervisordStatus{program: fields[0], status: fields[1]})
-	}
-
-	return result
-}
-
-// supervisordStatus corresponds to the statuses reported by 'supervisord ctl status', example:
-// - debugrun                         STOPPED
-// - devrun                           RUNNING   pid 5640, uptime 11 days, 21:56:20
-// Only the first and second fields are included (no pod, uptime, etc)
-type supervisordStatus struct {
-	program string
-	status  string
+	return common.ParseSupervisordStatus(consoleResult)
 }
 
 // All statuses seen within the container
 type supervisordStatusEvent struct {
 	containerName string
 	podUID        string
-	status        []supervisordStatus
+	status        []common.SupervisordProgramStatus
 }
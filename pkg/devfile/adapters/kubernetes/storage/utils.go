@@ -12,8 +12,12 @@ import (
 	"github.com/openshift/odo/pkg/storage"
 	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -23,6 +27,8 @@ import (
 type VolumeInfo struct {
 	PVCName    string
 	VolumeName string
+	// VolumeMode is the volume mode of the PVC, a Block volume is attached to the containers as a device
+	VolumeMode corev1.PersistentVolumeMode
 }
 
 // GetVolumesAndVolumeMounts gets the PVC volumes and updates the containers with the volume mounts.
@@ -49,6 +55,10 @@ func GetVolumesAndVolumeMounts(devfileObj devfileParser.DevfileObj, containers [
 			}
 		}
 
+		if volInfo.VolumeMode == corev1.PersistentVolumeBlock {
+			addVolumeDeviceToContainers(containers, volInfo.VolumeName, containerNameToMountPaths)
+			continue
+		}
 		addVolumeMountToContainers(containers, volInfo.VolumeName, containerNameToMountPaths)
 	}
 	return pvcVols, nil
@@ -89,6 +99,24 @@ func addVolumeMountToContainers(containers []corev1.Container, volumeName string
 	}
 }
 
+// addVolumeDeviceToContainers adds the block volume as a device to the containers for a given volumeName,
+// the devices are attached at the Mount Paths in containerNameToMountPaths
+func addVolumeDeviceToContainers(containers []corev1.Container, volumeName string, containerNameToMountPaths map[string][]string) {
+
+	for containerName, mountPaths := range containerNameToMountPaths {
+		for i := range containers {
+			if containers[i].Name == containerName {
+				for _, mountPath := range mountPaths {
+					containers[i].VolumeDevices = append(containers[i].VolumeDevices, corev1.VolumeDevice{
+						Name:       volumeName,
+						DevicePath: mountPath,
+					})
+				}
+			}
+		}
+	}
+}
+
 // GenerateVolumeNameFromPVC generates a volume name based on the pvc name
 func GenerateVolumeNameFromPVC(pvc string) (volumeName string, err error) {
 	volumeName, err = util.NamespaceOpenShiftObject(pvc, "vol")
@@ -98,46 +126,170 @@ func GenerateVolumeNameFromPVC(pvc string) (volumeName string, err error) {
 	return
 }
 
-// HandleEphemeralStorage creates or deletes the ephemeral volume based on the preference setting
-func HandleEphemeralStorage(client kclient.Client, storageClient storage.Client, componentName string) error {
-	pref, err := preference.New()
-	if err != nil {
-		return err
+// SourceVolume describes the volume storing the project sources of a component
+type SourceVolume struct {
+	// Mode is the kind of the volume
+	Mode envinfo.SourceVolumeMode
+	// Size is the size of the PVC, or the size limit of the emptyDir; the emptyDir has no size limit if empty
+	Size string
+	// PVCName is the name of the source PVC of the pvc mode
+	PVCName string
+}
+
+// GetSourceVolume returns the source volume with the given mode and size from the env file,
+// the Ephemeral preference decides between the emptyDir and pvc modes when no mode is given
+func GetSourceVolume(mode envinfo.SourceVolumeMode, size string) (SourceVolume, error) {
+	if mode == "" {
+		pref, err := preference.New()
+		if err != nil {
+			return SourceVolume{}, err
+		}
+		mode = envinfo.PVCSourceVolume
+		if pref.GetEphemeralSourceVolume() {
+			mode = envinfo.EmptyDirSourceVolume
+		}
 	}
+	if size == "" && mode == envinfo.PVCSourceVolume {
+		size = storage.OdoSourceVolumeSize
+	}
+	if size != "" {
+		if _, err := resource.ParseQuantity(size); err != nil {
+			return SourceVolume{}, errors.Wrapf(err, "unable to parse source volume size: %v", size)
+		}
+	}
+	return SourceVolume{Mode: mode, Size: size}, nil
+}
 
-	selector := fmt.Sprintf("%v=%s,%s=%s", componentlabels.ComponentLabel, componentName, storagelabels.SourcePVCLabel, storage.OdoSourceVolume)
+// VolumeSource returns the source of the volume mounting the project sources in the containers
+func (s SourceVolume) VolumeSource() (corev1.VolumeSource, error) {
+	if s.Mode == envinfo.PVCSourceVolume {
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: s.PVCName},
+		}, nil
+	}
 
-	pvcs, err := client.ListPVCs(selector)
-	if err != nil && !kerrors.IsNotFound(err) {
-		return err
+	emptyDir := &corev1.EmptyDirVolumeSource{}
+	if s.Mode == envinfo.MemorySourceVolume {
+		emptyDir.Medium = corev1.StorageMediumMemory
+	}
+	if s.Size != "" {
+		sizeLimit, err := resource.ParseQuantity(s.Size)
+		if err != nil {
+			return corev1.VolumeSource{}, errors.Wrapf(err, "unable to parse source volume size: %v", s.Size)
+		}
+		emptyDir.SizeLimit = &sizeLimit
+	}
+	return corev1.VolumeSource{EmptyDir: emptyDir}, nil
+}
+
+// GetSourceVolumeMode returns the mode of the source volume of the deployment,
+// or an empty mode if the deployment has no source volume
+func GetSourceVolumeMode(deployment *appsv1.Deployment) envinfo.SourceVolumeMode {
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.Name != storage.OdoSourceVolume {
+			continue
+		}
+		if volume.PersistentVolumeClaim != nil {
+			return envinfo.PVCSourceVolume
+		}
+		if volume.EmptyDir != nil && volume.EmptyDir.Medium == corev1.StorageMediumMemory {
+			return envinfo.MemorySourceVolume
+		}
+		// a volume without source is an emptyDir
+		return envinfo.EmptyDirSourceVolume
+	}
+	return ""
+}
+
+// HandleSourceVolume creates the source PVC of the pvc mode, or expands it when its size is increased,
+// and sets its name on the source volume; the source PVCs of the other modes are deleted by DeleteUnusedSourcePVCs
+// once the component doesn't use them anymore
+func HandleSourceVolume(client kclient.Client, storageClient storage.Client, componentName string, sourceVolume *SourceVolume) error {
+	if sourceVolume.Mode != envinfo.PVCSourceVolume {
+		return nil
 	}
 
-	if !pref.GetEphemeralSourceVolume() {
-		if len(pvcs) == 0 {
-			err := storageClient.Create(storage.Storage{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: storage.OdoSourceVolume,
-				},
-				Spec: storage.StorageSpec{
-					Size: storage.OdoSourceVolumeSize,
-				},
-			})
+	pvcs, err := listSourcePVCs(client, componentName)
+	if err != nil {
+		return err
+	}
+	if len(pvcs) > 1 {
+		return fmt.Errorf("number of source volumes shouldn't be greater than 1")
+	}
 
+	if len(pvcs) == 0 {
+		err = storageClient.Create(storage.Storage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: storage.OdoSourceVolume,
+			},
+			Spec: storage.StorageSpec{
+				Size: sourceVolume.Size,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		pvcs, err = listSourcePVCs(client, componentName)
+		if err != nil {
+			return err
+		}
+		if len(pvcs) != 1 {
+			return fmt.Errorf("unable to find the source volume of component %s", componentName)
+		}
+	} else {
+		size, err := resource.ParseQuantity(sourceVolume.Size)
+		if err != nil {
+			return errors.Wrapf(err, "unable to parse source volume size: %v", sourceVolume.Size)
+		}
+		currentSize := pvcs[0].Spec.Resources.Requests[corev1.ResourceStorage]
+		switch size.Cmp(currentSize) {
+		case -1:
+			return fmt.Errorf("the size of the source volume of component %s can't be reduced from %s to %s", componentName, currentSize.String(), size.String())
+		case 1:
+			err = client.ExpandPVC(pvcs[0].Name, size)
 			if err != nil {
 				return err
 			}
-		} else if len(pvcs) > 1 {
-			return fmt.Errorf("number of source volumes shouldn't be greater than 1")
 		}
-	} else {
-		if len(pvcs) > 0 {
-			for _, pvc := range pvcs {
-				err := client.DeletePVC(pvc.Name)
-				if err != nil {
-					return err
-				}
-			}
+	}
+
+	sourceVolume.PVCName = pvcs[0].Name
+	return nil
+}
+
+// DeleteUnusedSourcePVCs deletes the source PVCs of the component which are not mounted by its deployment
+func DeleteUnusedSourcePVCs(client kclient.Client, componentName string, deployment *appsv1.Deployment) error {
+	usedPVC := ""
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.Name == storage.OdoSourceVolume && volume.PersistentVolumeClaim != nil {
+			usedPVC = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+
+	pvcs, err := listSourcePVCs(client, componentName)
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs {
+		if pvc.Name == usedPVC || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		klog.V(2).Infof("Deleting the unused source PVC %s", pvc.Name)
+		err = client.DeletePVC(pvc.Name)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "unable to delete the source volume %s", pvc.Name)
 		}
 	}
 	return nil
 }
+
+// listSourcePVCs lists the source PVCs of the component
+func listSourcePVCs(client kclient.Client, componentName string) ([]corev1.PersistentVolumeClaim, error) {
+	selector := fmt.Sprintf("%v=%s,%s=%s", componentlabels.ComponentLabel, componentName, storagelabels.SourcePVCLabel, storage.OdoSourceVolume)
+
+	pvcs, err := client.ListPVCs(selector)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	return pvcs, nil
+}
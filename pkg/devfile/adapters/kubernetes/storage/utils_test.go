@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"reflect"
 	"testing"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -9,8 +10,17 @@ import (
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/golang/mock/gomock"
+	componentlabels "github.com/openshift/odo/pkg/component/labels"
+	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes/utils"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/storage"
+	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/testingutil"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestGetPVC(t *testing.T) {
@@ -122,6 +132,24 @@ func TestAddVolumeMountToContainers(t *testing.T) {
 	}
 }
 
+func TestAddVolumeDeviceToContainers(t *testing.T) {
+	containers := []v1.Container{{Name: "container1"}, {Name: "container2"}}
+	addVolumeDeviceToContainers(containers, "myvolume", map[string][]string{
+		"container1": {"/dev/xvda"},
+	})
+
+	want := []v1.VolumeDevice{{Name: "myvolume", DevicePath: "/dev/xvda"}}
+	if !reflect.DeepEqual(containers[0].VolumeDevices, want) {
+		t.Errorf("got volume devices %v, want %v", containers[0].VolumeDevices, want)
+	}
+	if len(containers[0].VolumeMounts) != 0 {
+		t.Errorf("a block volume should not be mounted, got %v", containers[0].VolumeMounts)
+	}
+	if len(containers[1].VolumeDevices) != 0 {
+		t.Errorf("the volume should only be attached to container1, got %v", containers[1].VolumeDevices)
+	}
+}
+
 func TestGetVolumesAndVolumeMounts(t *testing.T) {
 
 	type testVolumeMountInfo struct {
@@ -355,3 +383,206 @@ func TestGetVolumesAndVolumeMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSourceVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    envinfo.SourceVolumeMode
+		size    string
+		want    SourceVolume
+		wantErr bool
+	}{
+		{
+			name: "Case 1: PVC with the default size",
+			mode: envinfo.PVCSourceVolume,
+			want: SourceVolume{Mode: envinfo.PVCSourceVolume, Size: storage.OdoSourceVolumeSize},
+		},
+		{
+			name: "Case 2: Memory backed emptyDir without size limit",
+			mode: envinfo.MemorySourceVolume,
+			want: SourceVolume{Mode: envinfo.MemorySourceVolume},
+		},
+		{
+			name: "Case 3: EmptyDir with a size limit",
+			mode: envinfo.EmptyDirSourceVolume,
+			size: "500Mi",
+			want: SourceVolume{Mode: envinfo.EmptyDirSourceVolume, Size: "500Mi"},
+		},
+		{
+			name:    "Case 4: Invalid size",
+			mode:    envinfo.PVCSourceVolume,
+			size:    "big",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSourceVolume(tt.mode, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSourceVolume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSourceVolume() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceVolumeVolumeSource(t *testing.T) {
+	sizeLimit := resource.MustParse("1Gi")
+
+	tests := []struct {
+		name         string
+		sourceVolume SourceVolume
+		want         v1.VolumeSource
+	}{
+		{
+			name:         "Case 1: PVC",
+			sourceVolume: SourceVolume{Mode: envinfo.PVCSourceVolume, Size: "2Gi", PVCName: "odo-projects-nodejs"},
+			want: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "odo-projects-nodejs"},
+			},
+		},
+		{
+			name:         "Case 2: EmptyDir",
+			sourceVolume: SourceVolume{Mode: envinfo.EmptyDirSourceVolume},
+			want:         v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		},
+		{
+			name:         "Case 3: Memory backed emptyDir with a size limit",
+			sourceVolume: SourceVolume{Mode: envinfo.MemorySourceVolume, Size: "1Gi"},
+			want:         v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory, SizeLimit: &sizeLimit}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sourceVolume.VolumeSource()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VolumeSource() = %+v, want %+v", got, tt.want)
+			}
+
+			deployment := &appsv1.Deployment{}
+			deployment.Spec.Template.Spec.Volumes = utils.GetOdoContainerVolumes(got)
+			if mode := GetSourceVolumeMode(deployment); mode != tt.sourceVolume.Mode {
+				t.Errorf("GetSourceVolumeMode() = %v, want %v", mode, tt.sourceVolume.Mode)
+			}
+		})
+	}
+}
+
+func TestHandleSourceVolume(t *testing.T) {
+	componentName := "nodejs"
+	sourceLabels := map[string]string{
+		componentlabels.ComponentLabel: componentName,
+		storagelabels.SourcePVCLabel:   storage.OdoSourceVolume,
+	}
+
+	tests := []struct {
+		name         string
+		sourceVolume SourceVolume
+		existingPVCs []*v1.PersistentVolumeClaim
+		wantCreate   bool
+		wantPVCName  string
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: Source PVC created",
+			sourceVolume: SourceVolume{Mode: envinfo.PVCSourceVolume, Size: "2Gi"},
+			wantCreate:   true,
+			wantPVCName:  "odo-projects-nodejs-abcd",
+		},
+		{
+			name:         "Case 2: Existing source PVC",
+			sourceVolume: SourceVolume{Mode: envinfo.PVCSourceVolume, Size: "2Gi"},
+			existingPVCs: []*v1.PersistentVolumeClaim{testingutil.FakePVC("odo-projects-nodejs-efgh", "2Gi", sourceLabels)},
+			wantPVCName:  "odo-projects-nodejs-efgh",
+		},
+		{
+			name:         "Case 3: Source PVC size reduced",
+			sourceVolume: SourceVolume{Mode: envinfo.PVCSourceVolume, Size: "1Gi"},
+			existingPVCs: []*v1.PersistentVolumeClaim{testingutil.FakePVC("odo-projects-nodejs-efgh", "2Gi", sourceLabels)},
+			wantErr:      true,
+		},
+		{
+			name:         "Case 4: Source PVC kept until the component is restarted with an emptyDir",
+			sourceVolume: SourceVolume{Mode: envinfo.EmptyDirSourceVolume},
+			existingPVCs: []*v1.PersistentVolumeClaim{testingutil.FakePVC("odo-projects-nodejs-efgh", "2Gi", sourceLabels)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeClient, _ := kclient.FakeNew()
+			for _, pvc := range tt.existingPVCs {
+				if _, err := fakeClient.CreatePVC(*pvc); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			storageClient := storage.NewMockClient(ctrl)
+			if tt.wantCreate {
+				storageClient.EXPECT().Create(gomock.Any()).DoAndReturn(func(s storage.Storage) error {
+					if s.Name != storage.OdoSourceVolume || s.Spec.Size != tt.sourceVolume.Size {
+						t.Errorf("unexpected source storage %+v", s)
+					}
+					_, err := fakeClient.CreatePVC(*testingutil.FakePVC(tt.wantPVCName, s.Spec.Size, sourceLabels))
+					return err
+				})
+			}
+
+			err := HandleSourceVolume(*fakeClient, storageClient, componentName, &tt.sourceVolume)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HandleSourceVolume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.sourceVolume.PVCName != tt.wantPVCName {
+				t.Errorf("got source PVC %q, want %q", tt.sourceVolume.PVCName, tt.wantPVCName)
+			}
+
+			pvcs, err := fakeClient.ListPVCs("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantCreate && len(pvcs) != len(tt.existingPVCs) {
+				t.Errorf("the existing source PVCs should be kept, got %v", pvcs)
+			}
+		})
+	}
+}
+
+func TestDeleteUnusedSourcePVCs(t *testing.T) {
+	componentName := "nodejs"
+	sourceLabels := map[string]string{
+		componentlabels.ComponentLabel: componentName,
+		storagelabels.SourcePVCLabel:   storage.OdoSourceVolume,
+	}
+
+	fakeClient, _ := kclient.FakeNew()
+	for _, name := range []string{"odo-projects-nodejs-abcd", "odo-projects-nodejs-efgh"} {
+		if _, err := fakeClient.CreatePVC(*testingutil.FakePVC(name, "2Gi", sourceLabels)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Volumes = utils.GetOdoContainerVolumes(v1.VolumeSource{
+		PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "odo-projects-nodejs-efgh"},
+	})
+	if err := DeleteUnusedSourcePVCs(*fakeClient, componentName, deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	pvcs, err := fakeClient.ListPVCNames("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pvcs, []string{"odo-projects-nodejs-efgh"}) {
+		t.Errorf("got PVCs %v, only the source PVC used by the deployment should be kept", pvcs)
+	}
+}
@@ -22,21 +22,12 @@ const (
 	containerNameMaxLen = 55
 )
 
-// GetOdoContainerVolumes returns the mandatory Kube volumes for an Odo component
-func GetOdoContainerVolumes(sourcePVCName string) []corev1.Volume {
-	var sourceVolume corev1.Volume
-
-	if sourcePVCName != "" {
-		sourceVolume = corev1.Volume{
-			Name: storage.OdoSourceVolume,
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: sourcePVCName},
-			},
-		}
-	} else {
-		sourceVolume = corev1.Volume{
-			Name: storage.OdoSourceVolume,
-		}
+// GetOdoContainerVolumes returns the mandatory Kube volumes for an Odo component,
+// the project sources are stored in a volume with the given source
+func GetOdoContainerVolumes(sourceVolumeSource corev1.VolumeSource) []corev1.Volume {
+	sourceVolume := corev1.Volume{
+		Name:         storage.OdoSourceVolume,
+		VolumeSource: sourceVolumeSource,
 	}
 
 	return []corev1.Volume{
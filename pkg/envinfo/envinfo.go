@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/odo/pkg/testingutil/filesystem"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 
@@ -44,6 +45,19 @@ type ComponentSettings struct {
 
 	// RunMode indicates the mode of run used for a successful push
 	RunMode *RUNMode `yaml:"RunMode,omitempty" json:"runMode,omitempty"`
+
+	// Provenance records the origin and the digest of the devfile and the starter project downloaded to create the component
+	Provenance *[]ResourceProvenance `yaml:"Provenance,omitempty" json:"provenance,omitempty"`
+
+	// SourceVolumeMode is the kind of volume storing the project sources in the component pod,
+	// the Ephemeral preference decides between emptyDir and pvc if not set
+	SourceVolumeMode *SourceVolumeMode `yaml:"SourceVolumeMode,omitempty" json:"sourceVolumeMode,omitempty"`
+
+	// SourceVolumeSize is the size of the source PVC, or the size limit of the source emptyDir
+	SourceVolumeSize *string `yaml:"SourceVolumeSize,omitempty" json:"sourceVolumeSize,omitempty"`
+
+	// Cluster records the kube context and the cluster the component was created for
+	Cluster *ClusterBinding `yaml:"Cluster,omitempty" json:"cluster,omitempty"`
 }
 
 type RUNMode string
@@ -53,6 +67,25 @@ const (
 	Debug RUNMode = "debug"
 )
 
+// SourceVolumeMode is the kind of volume storing the project sources
+type SourceVolumeMode string
+
+const (
+	// PVCSourceVolume stores the sources in a PVC, they are kept when the pod is restarted
+	PVCSourceVolume SourceVolumeMode = "pvc"
+	// EmptyDirSourceVolume stores the sources in an emptyDir on the disk of the node
+	EmptyDirSourceVolume SourceVolumeMode = "emptyDir"
+	// MemorySourceVolume stores the sources in a memory backed (tmpfs) emptyDir, for fast builds
+	MemorySourceVolume SourceVolumeMode = "memory"
+)
+
+// sourceVolumeModes maps the lower case source volume modes to the source volume modes
+var sourceVolumeModes = map[string]SourceVolumeMode{
+	"pvc":      PVCSourceVolume,
+	"emptydir": EmptyDirSourceVolume,
+	"memory":   MemorySourceVolume,
+}
+
 const (
 	envInfoEnvName  = "ENVINFO"
 	envInfoFileName = "env.yaml"
@@ -70,12 +103,18 @@ type EnvInfo struct {
 	isRouteSupported  bool
 	updateURL         bool              // this indicates that the URL create operation should be an update operation
 	componentSettings ComponentSettings `yaml:"ComponentSettings,omitempty"`
+	// environment is the name of the environment whose settings are componentSettings
+	environment string
+	// file holds all the environments of the env.yaml file
+	file proxyEnvInfo
 }
 
 // proxyEnvInfo holds all the parameter that envinfo does but exposes all
 // of it, used for serialization.
 type proxyEnvInfo struct {
 	ComponentSettings ComponentSettings `yaml:"ComponentSettings,omitempty"`
+	ActiveEnvironment string            `yaml:"ActiveEnvironment,omitempty"`
+	Environments      []Environment     `yaml:"Environments,omitempty"`
 }
 
 // EnvSpecificInfo wraps the envinfo and provides helpers to
@@ -97,6 +136,35 @@ type EnvInfoLink struct {
 	ServiceName string `yaml:"ServiceName,omitempty" json:"serviceName,omitempty"`
 }
 
+// ResourceProvenance records where a resource used to create the component was downloaded from
+type ResourceProvenance struct {
+	// Kind of the resource, either devfile or starterProject
+	Kind string `yaml:"Kind,omitempty" json:"kind,omitempty"`
+	// Source is the location the resource was downloaded from
+	Source string `yaml:"Source,omitempty" json:"source,omitempty"`
+	// Digest of the resource, sha256:<hex> for files and archives or git:<commit> for git repositories
+	Digest string `yaml:"Digest,omitempty" json:"digest,omitempty"`
+	// Verified is true if the signature of the resource was verified with a trusted key
+	Verified bool `yaml:"Verified,omitempty" json:"verified,omitempty"`
+	// Parameters are the values used to render the starter project templates
+	Parameters map[string]string `yaml:"Parameters,omitempty" json:"parameters,omitempty"`
+}
+
+// ClusterBinding records the cluster a component is deployed to, the commands refusing to run against another cluster
+type ClusterBinding struct {
+	// Context is the name of the kubeconfig context used when the binding was recorded
+	Context string `yaml:"Context,omitempty" json:"context,omitempty"`
+	// Server is the URL of the API server of the cluster
+	Server string `yaml:"Server,omitempty" json:"server,omitempty"`
+}
+
+const (
+	// DevfileResource is the kind of the provenance of devfiles
+	DevfileResource = "devfile"
+	// StarterProjectResource is the kind of the provenance of starter projects
+	StarterProjectResource = "starterProject"
+)
+
 func WrapForJSONOutput(compSettings ComponentSettings) JSONEnvInfoRepr {
 	return JSONEnvInfoRepr{
 		TypeMeta: metav1.TypeMeta{
@@ -178,7 +246,18 @@ func getFromFile(envinfo *EnvInfo, filename string) error {
 	if err != nil {
 		return err
 	}
-	envinfo.componentSettings = proxyei.ComponentSettings
+	envinfo.file = proxyei
+
+	// the environment selected with the --environment flag is used instead of the active environment
+	envinfo.environment = getSelectedEnvironment()
+	if envinfo.environment == "" {
+		envinfo.environment = envinfo.GetActiveEnvironment()
+	}
+	cs, ok := proxyei.getEnvironment(envinfo.environment)
+	if !ok {
+		return errors.Errorf("the environment %q doesn't exist in %s, run `odo env list` to list the environments", envinfo.environment, filename)
+	}
+	envinfo.componentSettings = cs
 	return nil
 }
 
@@ -209,6 +288,22 @@ func (esi *EnvSpecificInfo) SetConfiguration(parameter string, value interface{}
 				return errors.Wrap(err, "failed to set debug port")
 			}
 			esi.componentSettings.DebugPort = &val
+		case "sourcevolumemode":
+			val, ok := sourceVolumeModes[strings.ToLower(value.(string))]
+			if !ok {
+				return errors.Errorf("failed to set source volume mode: %q is not one of %s, %s or %s", value, PVCSourceVolume, EmptyDirSourceVolume, MemorySourceVolume)
+			}
+			esi.componentSettings.SourceVolumeMode = &val
+		case "sourcevolumesize":
+			val := value.(string)
+			quantity, err := resource.ParseQuantity(val)
+			if err != nil {
+				return errors.Wrap(err, "failed to set source volume size")
+			}
+			if quantity.Sign() <= 0 {
+				return errors.Errorf("failed to set source volume size: %s is not a positive size", val)
+			}
+			esi.componentSettings.SourceVolumeSize = &val
 		case "url":
 			urlValue := value.(localConfigProvider.LocalURL)
 			if esi.componentSettings.URL != nil {
@@ -337,10 +432,9 @@ func (esi *EnvSpecificInfo) SetComponentSettings(cs ComponentSettings) error {
 }
 
 func (esi *EnvSpecificInfo) writeToFile() error {
-	proxyei := newProxyEnvInfo()
-	proxyei.ComponentSettings = esi.componentSettings
+	esi.file.setEnvironment(esi.environment, esi.componentSettings)
 
-	return util.WriteToFile(&proxyei, esi.Filename)
+	return util.WriteToFile(&esi.file, esi.Filename)
 }
 
 // GetName returns the component name
@@ -356,6 +450,22 @@ func (ei *EnvInfo) GetDebugPort() int {
 	return *ei.componentSettings.DebugPort
 }
 
+// GetSourceVolumeMode returns the SourceVolumeMode, returns an empty mode if nil
+func (ei *EnvInfo) GetSourceVolumeMode() SourceVolumeMode {
+	if ei.componentSettings.SourceVolumeMode == nil {
+		return ""
+	}
+	return *ei.componentSettings.SourceVolumeMode
+}
+
+// GetSourceVolumeSize returns the SourceVolumeSize, returns an empty size if nil
+func (ei *EnvInfo) GetSourceVolumeSize() string {
+	if ei.componentSettings.SourceVolumeSize == nil {
+		return ""
+	}
+	return *ei.componentSettings.SourceVolumeSize
+}
+
 // GetContainers returns the Container components from the devfile
 // returns empty list if nil
 func (ei *EnvInfo) GetContainers() ([]localConfigProvider.LocalContainer, error) {
@@ -431,6 +541,25 @@ func (ei *EnvInfo) SetIsRouteSupported(isRouteSupported bool) {
 	ei.isRouteSupported = isRouteSupported
 }
 
+// GetProvenance returns the origin and the digest of the resources downloaded to create the component
+func (ei *EnvInfo) GetProvenance() []ResourceProvenance {
+	if ei.componentSettings.Provenance == nil {
+		return []ResourceProvenance{}
+	}
+	return *ei.componentSettings.Provenance
+}
+
+// GetClusterBinding returns the cluster the component is bound to, nil if none was recorded
+func (ei *EnvInfo) GetClusterBinding() *ClusterBinding {
+	return ei.componentSettings.Cluster
+}
+
+// SetClusterBinding binds the component to the cluster and writes to the file
+func (esi *EnvSpecificInfo) SetClusterBinding(binding ClusterBinding) error {
+	esi.componentSettings.Cluster = &binding
+	return esi.writeToFile()
+}
+
 // GetLink returns the EnvInfoLink, returns default if nil
 func (ei *EnvInfo) GetLink() []EnvInfoLink {
 	if ei.componentSettings.Link == nil {
@@ -464,6 +593,14 @@ const (
 	Link = "LINK"
 	// LinkDescription is the description of Link
 	LinkDescription = "Link to an Operator backed service"
+	// SourceVolumeModeSetting is the name of the setting controlling the kind of volume storing the project sources
+	SourceVolumeModeSetting = "SourceVolumeMode"
+	// SourceVolumeModeDescription is the human-readable description for the source volume mode setting
+	SourceVolumeModeDescription = "Set this value to pvc, emptyDir or memory to store the project sources in a PVC, an emptyDir or a memory backed emptyDir (Default: the Ephemeral preference)"
+	// SourceVolumeSizeSetting is the name of the setting controlling the size of the volume storing the project sources
+	SourceVolumeSizeSetting = "SourceVolumeSize"
+	// SourceVolumeSizeDescription is the human-readable description for the source volume size setting
+	SourceVolumeSizeDescription = "Set this value to the size of the source PVC, or the size limit of the source emptyDir (Default: 2Gi for a PVC)"
 )
 
 var (
@@ -474,6 +611,9 @@ var (
 		URL:       URLDescription,
 		Push:      PushDescription,
 		Link:      LinkDescription,
+
+		SourceVolumeModeSetting: SourceVolumeModeDescription,
+		SourceVolumeSizeSetting: SourceVolumeSizeDescription,
 	}
 
 	lowerCaseLocalParameters = util.GetLowerCaseParameters(GetLocallySupportedParameters())
@@ -59,6 +59,44 @@ func TestSetEnvInfo(t *testing.T) {
 			checkConfigSetting: []string{"URL"},
 			expectError:        true,
 		},
+		{
+			name:      fmt.Sprintf("Case 3: %s to test", SourceVolumeModeSetting),
+			parameter: SourceVolumeModeSetting,
+			value:     "emptydir",
+			existingEnvInfo: EnvInfo{
+				componentSettings: ComponentSettings{},
+			},
+			checkConfigSetting: []string{"SourceVolumeMode"},
+			expectError:        false,
+		},
+		{
+			name:      fmt.Sprintf("Case 4: invalid %s to test", SourceVolumeModeSetting),
+			parameter: SourceVolumeModeSetting,
+			value:     "hostPath",
+			existingEnvInfo: EnvInfo{
+				componentSettings: ComponentSettings{},
+			},
+			expectError: true,
+		},
+		{
+			name:      fmt.Sprintf("Case 5: %s to test", SourceVolumeSizeSetting),
+			parameter: SourceVolumeSizeSetting,
+			value:     "5Gi",
+			existingEnvInfo: EnvInfo{
+				componentSettings: ComponentSettings{},
+			},
+			checkConfigSetting: []string{"SourceVolumeSize"},
+			expectError:        false,
+		},
+		{
+			name:      fmt.Sprintf("Case 6: invalid %s to test", SourceVolumeSizeSetting),
+			parameter: SourceVolumeSizeSetting,
+			value:     "-1Gi",
+			existingEnvInfo: EnvInfo{
+				componentSettings: ComponentSettings{},
+			},
+			expectError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -68,6 +106,9 @@ func TestSetEnvInfo(t *testing.T) {
 			}
 			esi.EnvInfo = tt.existingEnvInfo
 			err = esi.SetConfiguration(tt.parameter, tt.value)
+			if tt.expectError && err == nil {
+				t.Errorf("expected an error for SetConfiguration with %s", tt.parameter)
+			}
 			if !tt.expectError && err != nil {
 				t.Errorf("unexpected error for SetConfiguration with %s: %v", tt.parameter, err)
 			} else if !tt.expectError && err == nil {
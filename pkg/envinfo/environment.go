@@ -0,0 +1,190 @@
+package envinfo
+
+import (
+	"os"
+
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultEnvironment is the name of the environment whose settings are the ComponentSettings of env.yaml
+	DefaultEnvironment = "default"
+
+	// EnvironmentFlagName is the name of the flag selecting the environment used by a command
+	EnvironmentFlagName = "environment"
+
+	// EnvironmentFlagAlias is the short name of the --environment flag, on the commands without their own --env flag
+	EnvironmentFlagAlias = "env"
+
+	// EnvironmentEnvName is the environment variable selecting the environment, when the flag is not set
+	EnvironmentEnvName = "ODO_ENV"
+)
+
+// Environment is a named set of component settings, used to deploy the same component to several projects or clusters
+type Environment struct {
+	Name              string            `yaml:"Name" json:"name"`
+	ComponentSettings ComponentSettings `yaml:"ComponentSettings,omitempty" json:"componentSettings"`
+}
+
+// environmentFlags holds the --environment flag, shared by all the commands
+var environmentFlags = newEnvironmentFlags()
+
+func newEnvironmentFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet(EnvironmentFlagName, pflag.ContinueOnError)
+	flags.String(EnvironmentFlagName, "", "Environment of the component to use instead of the active environment")
+	return flags
+}
+
+// AddEnvironmentFlag adds the --environment flag to the flags. The flag can also be passed as --env, unless the
+// flags already have an --env flag, e.g. for the environment variables of odo create or odo config set
+func AddEnvironmentFlag(flags *pflag.FlagSet) {
+	if flags.Lookup(EnvironmentFlagName) != nil {
+		return
+	}
+	flags.AddFlag(environmentFlags.Lookup(EnvironmentFlagName))
+	if flags.Lookup(EnvironmentFlagAlias) != nil {
+		return
+	}
+	normalize := flags.GetNormalizeFunc()
+	flags.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == EnvironmentFlagAlias {
+			name = EnvironmentFlagName
+		}
+		return normalize(f, name)
+	})
+}
+
+// getSelectedEnvironment returns the environment selected with the --environment flag or the ODO_ENV variable, empty if none
+func getSelectedEnvironment() string {
+	if flag := environmentFlags.Lookup(EnvironmentFlagName); flag.Changed {
+		return flag.Value.String()
+	}
+	return os.Getenv(EnvironmentEnvName)
+}
+
+// getEnvironment returns the settings of the environment, and false if it doesn't exist
+func (p *proxyEnvInfo) getEnvironment(name string) (ComponentSettings, bool) {
+	if name == DefaultEnvironment || name == "" {
+		return p.ComponentSettings, true
+	}
+	for _, environment := range p.Environments {
+		if environment.Name == name {
+			return environment.ComponentSettings, true
+		}
+	}
+	return ComponentSettings{}, false
+}
+
+// setEnvironment sets the settings of the environment, adding the environment if it doesn't exist
+func (p *proxyEnvInfo) setEnvironment(name string, cs ComponentSettings) {
+	if name == DefaultEnvironment || name == "" {
+		p.ComponentSettings = cs
+		return
+	}
+	for i := range p.Environments {
+		if p.Environments[i].Name == name {
+			p.Environments[i].ComponentSettings = cs
+			return
+		}
+	}
+	p.Environments = append(p.Environments, Environment{Name: name, ComponentSettings: cs})
+}
+
+// GetEnvironment returns the name of the environment whose settings are used
+func (ei *EnvInfo) GetEnvironment() string {
+	if ei.environment == "" {
+		return DefaultEnvironment
+	}
+	return ei.environment
+}
+
+// GetActiveEnvironment returns the name of the environment used when none is selected with the --env flag
+func (ei *EnvInfo) GetActiveEnvironment() string {
+	if ei.file.ActiveEnvironment == "" {
+		return DefaultEnvironment
+	}
+	return ei.file.ActiveEnvironment
+}
+
+// ListEnvironments returns the environments of the component, the default environment first
+func (ei *EnvInfo) ListEnvironments() []Environment {
+	environments := []Environment{{Name: DefaultEnvironment, ComponentSettings: ei.file.ComponentSettings}}
+	for _, environment := range ei.file.Environments {
+		if environment.Name == ei.GetEnvironment() {
+			environment.ComponentSettings = ei.componentSettings
+		}
+		environments = append(environments, environment)
+	}
+	if ei.GetEnvironment() == DefaultEnvironment {
+		environments[0].ComponentSettings = ei.componentSettings
+	}
+	return environments
+}
+
+// CreateEnvironment adds the environment with the settings and writes to the file
+func (esi *EnvSpecificInfo) CreateEnvironment(name string, cs ComponentSettings) error {
+	if err := util.ValidateK8sResourceName("environment name", name); err != nil {
+		return err
+	}
+	if _, ok := esi.file.getEnvironment(name); ok {
+		return errors.Errorf("the environment %q already exists", name)
+	}
+	esi.file.setEnvironment(name, cs)
+	return esi.writeToFile()
+}
+
+// UseEnvironment makes the environment the active environment and writes to the file
+func (esi *EnvSpecificInfo) UseEnvironment(name string) error {
+	cs, ok := esi.file.getEnvironment(name)
+	if !ok {
+		return errors.Errorf("the environment %q doesn't exist", name)
+	}
+	esi.file.setEnvironment(esi.environment, esi.componentSettings)
+	esi.file.ActiveEnvironment = name
+	if name == DefaultEnvironment {
+		esi.file.ActiveEnvironment = ""
+	}
+	esi.environment = name
+	esi.componentSettings = cs
+	return esi.writeToFile()
+}
+
+// DeleteEnvironment deletes the environment and writes to the file, the default environment and the environment in
+// use can't be deleted
+func (esi *EnvSpecificInfo) DeleteEnvironment(name string) error {
+	if name == DefaultEnvironment {
+		return errors.Errorf("the %s environment can't be deleted", DefaultEnvironment)
+	}
+	if name == esi.GetEnvironment() || name == esi.file.ActiveEnvironment {
+		return errors.Errorf("the environment %q is in use, run `odo env use` to use another environment first", name)
+	}
+	for i, environment := range esi.file.Environments {
+		if environment.Name == name {
+			esi.file.Environments = append(esi.file.Environments[:i], esi.file.Environments[i+1:]...)
+			return esi.writeToFile()
+		}
+	}
+	return errors.Errorf("the environment %q doesn't exist", name)
+}
+
+// EnvironmentList is the machine readable output of the environments of a component
+type EnvironmentList struct {
+	metav1.TypeMeta   `json:",inline"`
+	ActiveEnvironment string        `json:"activeEnvironment"`
+	Items             []Environment `json:"items"`
+}
+
+// NewEnvironmentList returns the machine readable output of the environments of the component
+func NewEnvironmentList(ei *EnvInfo) EnvironmentList {
+	return EnvironmentList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: "odo.dev/v1alpha1",
+		},
+		ActiveEnvironment: ei.GetActiveEnvironment(),
+		Items:             ei.ListEnvironments(),
+	}
+}
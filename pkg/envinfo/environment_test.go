@@ -0,0 +1,170 @@
+package envinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestEnvironments(t *testing.T) {
+	// the env.yaml file of the context directory is used
+	if envInfoFile, ok := os.LookupEnv(envInfoEnvName); ok {
+		os.Unsetenv(envInfoEnvName)
+		defer os.Setenv(envInfoEnvName, envInfoFile)
+	}
+	dir, err := ioutil.TempDir("", "envinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	envFile := filepath.Join(dir, ".odo", "env", envInfoFileName)
+	if err = os.MkdirAll(filepath.Dir(envFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	envInfo := "ComponentSettings:\n  Name: web\n  Project: personal\n  DebugPort: 7000\n"
+	if err = ioutil.WriteFile(envFile, []byte(envInfo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	esi, err := NewEnvSpecificInfo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if esi.GetEnvironment() != DefaultEnvironment || esi.GetNamespace() != "personal" {
+		t.Errorf("got the environment %q with the project %q, want the default environment", esi.GetEnvironment(), esi.GetNamespace())
+	}
+	if err = esi.CreateEnvironment("team", ComponentSettings{Name: "web", Project: "team-shared"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = esi.CreateEnvironment(DefaultEnvironment, ComponentSettings{}); err == nil {
+		t.Errorf("expected an error when the environment exists")
+	}
+	if err = esi.UseEnvironment("team"); err != nil {
+		t.Fatal(err)
+	}
+	if err = esi.SetConfiguration("debugport", "9000"); err != nil {
+		t.Fatal(err)
+	}
+	if err = esi.DeleteEnvironment("team"); err == nil {
+		t.Errorf("expected an error when deleting the environment in use")
+	}
+
+	tests := []struct {
+		name          string
+		selected      string
+		wantProject   string
+		wantDebugPort int
+		wantErr       bool
+	}{
+		{
+			name:          "Case 1: Active environment",
+			wantProject:   "team-shared",
+			wantDebugPort: 9000,
+		},
+		{
+			name:          "Case 2: Environment selected with the environment variable",
+			selected:      DefaultEnvironment,
+			wantProject:   "personal",
+			wantDebugPort: 7000,
+		},
+		{
+			name:     "Case 3: Missing environment",
+			selected: "demo",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(EnvironmentEnvName, tt.selected)
+			defer os.Unsetenv(EnvironmentEnvName)
+
+			esi, err := NewEnvSpecificInfo(dir)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got the error %v, want an error: %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if esi.GetNamespace() != tt.wantProject || esi.GetDebugPort() != tt.wantDebugPort {
+				t.Errorf("got the project %q and the debug port %d, want %q and %d", esi.GetNamespace(), esi.GetDebugPort(), tt.wantProject, tt.wantDebugPort)
+			}
+		})
+	}
+
+	if err = esi.UseEnvironment(DefaultEnvironment); err != nil {
+		t.Fatal(err)
+	}
+	if err = esi.DeleteEnvironment("team"); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, environment := range esi.ListEnvironments() {
+		names = append(names, environment.Name)
+	}
+	if !reflect.DeepEqual(names, []string{DefaultEnvironment}) {
+		t.Errorf("got the environments %v, want only the default environment", names)
+	}
+}
+
+func TestAddEnvironmentFlag(t *testing.T) {
+	tests := []struct {
+		name            string
+		ownEnvFlag      bool
+		args            []string
+		wantEnvironment string
+		wantOwnEnv      []string
+	}{
+		{
+			name:            "Case 1: Environment selected with --environment",
+			args:            []string{"--environment", "team"},
+			wantEnvironment: "team",
+		},
+		{
+			name:            "Case 2: Environment selected with --env",
+			args:            []string{"--env", "team"},
+			wantEnvironment: "team",
+		},
+		{
+			name:            "Case 3: Environment selected with --environment on a command with its own --env flag",
+			ownEnvFlag:      true,
+			args:            []string{"--environment", "team", "--env", "KEY=value"},
+			wantEnvironment: "team",
+			wantOwnEnv:      []string{"KEY=value"},
+		},
+		{
+			name:       "Case 4: --env flag of the command",
+			ownEnvFlag: true,
+			args:       []string{"--env", "KEY=value"},
+			wantOwnEnv: []string{"KEY=value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environmentFlags = newEnvironmentFlags()
+			defer func() {
+				environmentFlags = newEnvironmentFlags()
+			}()
+
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			var ownEnv []string
+			if tt.ownEnvFlag {
+				flags.StringSliceVar(&ownEnv, EnvironmentFlagAlias, nil, "Environment variables")
+			}
+			AddEnvironmentFlag(flags)
+
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := getSelectedEnvironment(); got != tt.wantEnvironment {
+				t.Errorf("got the environment %q, want %q", got, tt.wantEnvironment)
+			}
+			if !reflect.DeepEqual(ownEnv, tt.wantOwnEnv) {
+				t.Errorf("got the --env values %v, want %v", ownEnv, tt.wantOwnEnv)
+			}
+		})
+	}
+}
@@ -2,18 +2,45 @@ package envinfo
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"k8s.io/klog/v2"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/openshift/odo/pkg/localConfigProvider"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
 	// DefaultVolumeSize Default volume size for volumes defined in a devfile
 	DefaultVolumeSize = "1Gi"
+
+	// StorageClassAttribute is the attribute of a volume component setting the StorageClass of its PVC
+	StorageClassAttribute = "odo.dev/storage-class"
+	// AccessModeAttribute is the attribute of a volume component setting the access mode of its PVC
+	AccessModeAttribute = "odo.dev/access-mode"
+	// VolumeModeAttribute is the attribute of a volume component setting the volume mode of its PVC
+	VolumeModeAttribute = "odo.dev/volume-mode"
 )
 
+// accessModes maps the access modes and their short names to the access modes
+var accessModes = map[string]corev1.PersistentVolumeAccessMode{
+	"rwo":           corev1.ReadWriteOnce,
+	"rwx":           corev1.ReadWriteMany,
+	"rox":           corev1.ReadOnlyMany,
+	"readwriteonce": corev1.ReadWriteOnce,
+	"readwritemany": corev1.ReadWriteMany,
+	"readonlymany":  corev1.ReadOnlyMany,
+}
+
+// volumeModes maps the lower case volume modes to the volume modes
+var volumeModes = map[string]corev1.PersistentVolumeMode{
+	"filesystem": corev1.PersistentVolumeFilesystem,
+	"block":      corev1.PersistentVolumeBlock,
+}
+
 // CompleteStorage completes the given storage
 func (ei *EnvInfo) CompleteStorage(storage *localConfigProvider.LocalStorage) {
 	if storage.Size == "" {
@@ -23,6 +50,12 @@ func (ei *EnvInfo) CompleteStorage(storage *localConfigProvider.LocalStorage) {
 		// acc to the devfile schema, if the mount path is absent; it will be mounted at the dir with the mount name
 		storage.Path = "/" + storage.Name
 	}
+	if mode, ok := accessModes[strings.ToLower(storage.AccessMode)]; ok {
+		storage.AccessMode = string(mode)
+	}
+	if mode, ok := volumeModes[strings.ToLower(storage.VolumeMode)]; ok {
+		storage.VolumeMode = string(mode)
+	}
 }
 
 // ValidateStorage validates the given storage
@@ -38,6 +71,13 @@ func (ei *EnvInfo) ValidateStorage(storage localConfigProvider.LocalStorage) err
 		}
 	}
 
+	if _, err = normalizeAccessMode(storage.AccessMode); err != nil {
+		return err
+	}
+	if _, err = normalizeVolumeMode(storage.VolumeMode); err != nil {
+		return err
+	}
+
 	if storage.Container == "" {
 		return nil
 	}
@@ -84,7 +124,8 @@ func (ei *EnvInfo) CreateStorage(storage localConfigProvider.LocalStorage) error
 		},
 	}
 	vc := []devfilev1.Component{{
-		Name: storage.Name,
+		Name:       storage.Name,
+		Attributes: getVolumeAttributes(storage),
 		ComponentUnion: devfilev1.ComponentUnion{
 			Volume: &devfilev1.VolumeComponent{
 				Volume: devfilev1.Volume{
@@ -145,7 +186,7 @@ func (ei *EnvInfo) CreateStorage(storage localConfigProvider.LocalStorage) error
 func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 	var storageList []localConfigProvider.LocalStorage
 
-	volumeSizeMap := make(map[string]string)
+	volumeMap := make(map[string]localConfigProvider.LocalStorage)
 	components, err := ei.devfileObj.Data.GetComponents(common.DevfileOptions{})
 	if err != nil {
 		return storageList, err
@@ -155,10 +196,11 @@ func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 		if component.Volume == nil {
 			continue
 		}
-		if component.Volume.Size == "" {
-			component.Volume.Size = DefaultVolumeSize
+		volume, err := getVolumeStorage(component)
+		if err != nil {
+			return storageList, err
 		}
-		volumeSizeMap[component.Name] = component.Volume.Size
+		volumeMap[component.Name] = volume
 	}
 
 	for _, component := range components {
@@ -166,13 +208,16 @@ func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 			continue
 		}
 		for _, volumeMount := range component.Container.VolumeMounts {
-			size, ok := volumeSizeMap[volumeMount.Name]
+			volume, ok := volumeMap[volumeMount.Name]
 			if ok {
 				storageList = append(storageList, localConfigProvider.LocalStorage{
-					Name:      volumeMount.Name,
-					Size:      size,
-					Path:      GetVolumeMountPath(volumeMount),
-					Container: component.Name,
+					Name:         volumeMount.Name,
+					Size:         volume.Size,
+					Path:         GetVolumeMountPath(volumeMount),
+					Container:    component.Name,
+					StorageClass: volume.StorageClass,
+					AccessMode:   volume.AccessMode,
+					VolumeMode:   volume.VolumeMode,
 				})
 			}
 		}
@@ -235,3 +280,77 @@ func GetVolumeMountPath(volumeMount devfilev1.VolumeMount) string {
 
 	return volumeMount.Path
 }
+
+// getVolumeStorage returns the size and the PVC options of the volume component, read from its attributes
+func getVolumeStorage(component devfilev1.Component) (localConfigProvider.LocalStorage, error) {
+	volume := localConfigProvider.LocalStorage{
+		Name: component.Name,
+		Size: component.Volume.Size,
+	}
+	if volume.Size == "" {
+		volume.Size = DefaultVolumeSize
+	}
+
+	var err error
+	if volume.StorageClass, err = getStringAttribute(component.Attributes, StorageClassAttribute); err != nil {
+		return volume, err
+	}
+	accessMode, err := getStringAttribute(component.Attributes, AccessModeAttribute)
+	if err != nil {
+		return volume, err
+	}
+	if volume.AccessMode, err = normalizeAccessMode(accessMode); err != nil {
+		return volume, fmt.Errorf("volume %s: %v", component.Name, err)
+	}
+	volumeMode, err := getStringAttribute(component.Attributes, VolumeModeAttribute)
+	if err != nil {
+		return volume, err
+	}
+	if volume.VolumeMode, err = normalizeVolumeMode(volumeMode); err != nil {
+		return volume, fmt.Errorf("volume %s: %v", component.Name, err)
+	}
+	return volume, nil
+}
+
+// getVolumeAttributes returns the attributes of the volume component holding the PVC options of the storage
+func getVolumeAttributes(storage localConfigProvider.LocalStorage) attributes.Attributes {
+	if storage.StorageClass == "" && storage.AccessMode == "" && storage.VolumeMode == "" {
+		return nil
+	}
+	volumeAttributes := attributes.Attributes{}
+	if storage.StorageClass != "" {
+		volumeAttributes.PutString(StorageClassAttribute, storage.StorageClass)
+	}
+	if storage.AccessMode != "" {
+		volumeAttributes.PutString(AccessModeAttribute, storage.AccessMode)
+	}
+	if storage.VolumeMode != "" {
+		volumeAttributes.PutString(VolumeModeAttribute, storage.VolumeMode)
+	}
+	return volumeAttributes
+}
+
+// normalizeAccessMode returns the access mode matching the given access mode or short name (e.g. RWX),
+// an empty access mode is kept empty
+func normalizeAccessMode(accessMode string) (string, error) {
+	if accessMode == "" {
+		return "", nil
+	}
+	mode, ok := accessModes[strings.ToLower(accessMode)]
+	if !ok {
+		return "", fmt.Errorf("access mode only supports %v (RWO)|%v (RWX)|%v (ROX)", corev1.ReadWriteOnce, corev1.ReadWriteMany, corev1.ReadOnlyMany)
+	}
+	return string(mode), nil
+}
+
+// normalizeVolumeMode returns the volume mode matching the given volume mode, an empty volume mode is kept empty
+func normalizeVolumeMode(volumeMode string) (string, error) {
+	if volumeMode == "" {
+		return "", nil
+	}
+	mode, ok := volumeModes[strings.ToLower(volumeMode)]
+	if !ok {
+		return "", fmt.Errorf("volume mode only supports %v|%v", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock)
+	}
+	return string(mode), nil
+}
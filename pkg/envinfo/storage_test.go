@@ -7,6 +7,7 @@ import (
 	"github.com/devfile/library/pkg/devfile/parser/data"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/testingutil"
 	"github.com/kylelemons/godebug/pretty"
@@ -99,6 +100,25 @@ func TestEnvInfo_CompleteStorage(t *testing.T) {
 				Size: "1Gi",
 			},
 		},
+		{
+			name: "case 4: the access mode and volume mode are normalized",
+			args: args{
+				storage: &localConfigProvider.LocalStorage{
+					Name:       "storage-0",
+					Path:       "/data",
+					Size:       "1Gi",
+					AccessMode: "rwx",
+					VolumeMode: "block",
+				},
+			},
+			want: &localConfigProvider.LocalStorage{
+				Name:       "storage-0",
+				Path:       "/data",
+				Size:       "1Gi",
+				AccessMode: "ReadWriteMany",
+				VolumeMode: "Block",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -323,6 +343,80 @@ func TestEnvInfo_ListStorage(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "case 5: list the volumes with their storage class, access mode and volume mode",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APIVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						volume := testingutil.GetFakeVolumeComponent("volume-0", "5Gi")
+						volume.Attributes = attributes.Attributes{}.FromStringMap(map[string]string{
+							StorageClassAttribute: "fast",
+							AccessModeAttribute:   "RWX",
+							VolumeModeAttribute:   "block",
+						})
+						err = devfileData.AddComponents([]devfilev1.Component{
+							{
+								Name: "container-0",
+								ComponentUnion: devfilev1.ComponentUnion{
+									Container: &devfilev1.ContainerComponent{
+										Container: devfilev1.Container{
+											VolumeMounts: []devfilev1.VolumeMount{
+												{
+													Name: "volume-0",
+													Path: "/path",
+												},
+											},
+										},
+									},
+								},
+							},
+							volume,
+						})
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			want: []localConfigProvider.LocalStorage{
+				{
+					Name:         "volume-0",
+					Size:         "5Gi",
+					Path:         "/path",
+					Container:    "container-0",
+					StorageClass: "fast",
+					AccessMode:   "ReadWriteMany",
+					VolumeMode:   "Block",
+				},
+			},
+		},
+		{
+			name: "case 6: return an error when the access mode of a volume is invalid",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APIVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						volume := testingutil.GetFakeVolumeComponent("volume-0", "5Gi")
+						volume.Attributes = attributes.Attributes{}.PutString(AccessModeAttribute, "ReadWriteSometimes")
+						err = devfileData.AddComponents([]devfilev1.Component{volume})
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	"github.com/openshift/odo/pkg/localConfigProvider"
@@ -15,6 +16,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	// ProbeTypeAttribute is the devfile endpoint attribute selecting the probe checking the reachability of the URL
+	ProbeTypeAttribute = "odo.dev/probe-type"
+	// ProbePathAttribute is the devfile endpoint attribute setting the path requested by HTTP probes
+	ProbePathAttribute = "odo.dev/probe-path"
+	// ProbeStatusAttribute is the devfile endpoint attribute listing, comma separated, the status codes or classes accepted by HTTP probes
+	ProbeStatusAttribute = "odo.dev/probe-status"
+)
+
 //getPorts gets the ports from devfile
 func (ei *EnvInfo) getPorts(container string) ([]string, error) {
 	var portList []string
@@ -212,17 +222,58 @@ func (ei *EnvInfo) ValidateURL(url localConfigProvider.LocalURL) error {
 	if url.TLSSecret != "" && (url.Kind != localConfigProvider.INGRESS || !url.Secure) {
 		errorList = append(errorList, "TLS secret is only available for secure URLs of Ingress kind")
 	}
+	if url.IngressClass != "" && url.Kind != localConfigProvider.INGRESS {
+		errorList = append(errorList, "ingress class is only available for URLs of Ingress kind")
+	}
+
+	// check the Gateway of HTTPRoute based URLs
+	if url.Kind == localConfigProvider.HTTPROUTE {
+		if url.Gateway == "" {
+			errorList = append(errorList, "gateway must be provided in order to create URLs of HTTPRoute kind")
+		} else if err := validateGatewayReference(url.Gateway); err != nil {
+			errorList = append(errorList, err.Error())
+		}
+		if url.Secure {
+			errorList = append(errorList, "secure URLs of HTTPRoute kind are not supported, TLS is configured on the Gateway")
+		}
+	} else if url.Gateway != "" {
+		errorList = append(errorList, "gateway is only available for URLs of HTTPRoute kind")
+	}
+
+	// URLs of portforward kind are reached on localhost
+	if url.Kind == localConfigProvider.PORTFORWARD {
+		if url.Secure {
+			errorList = append(errorList, "secure URLs of portforward kind are not supported")
+		}
+		if url.ExposedPort <= 0 || url.ExposedPort > 65535 {
+			errorList = append(errorList, fmt.Sprintf("invalid local port %d for URLs of portforward kind", url.ExposedPort))
+		}
+		urls, err := ei.ListURLs()
+		if err != nil {
+			return err
+		}
+		for _, localURL := range urls {
+			if localURL.Name != url.Name && localURL.Kind == localConfigProvider.PORTFORWARD && localURL.ExposedPort == url.ExposedPort {
+				errorList = append(errorList, fmt.Sprintf("the local port %d is already used by the URL %s", url.ExposedPort, localURL.Name))
+			}
+		}
+	}
 
 	// check if a host is provided for route based URLs
 	if len(url.Host) > 0 {
 		if url.Kind == localConfigProvider.ROUTE {
 			errorList = append(errorList, "host is not supported for URLs of Route Kind")
 		}
+		if url.Kind == localConfigProvider.PORTFORWARD {
+			errorList = append(errorList, "host is not supported for URLs of portforward Kind, they are reached on localhost")
+		}
 		if err := validation.ValidateHost(url.Host); err != nil {
 			errorList = append(errorList, err.Error())
 		}
 	} else if url.Kind == localConfigProvider.INGRESS {
 		errorList = append(errorList, "host must be provided in order to create URLS of Ingress Kind")
+	} else if url.Kind == localConfigProvider.HTTPROUTE {
+		errorList = append(errorList, "host must be provided in order to create URLs of HTTPRoute Kind")
 	}
 
 	// check the protocol of the URL
@@ -301,7 +352,7 @@ func (esi *EnvSpecificInfo) CreateURL(url localConfigProvider.LocalURL) error {
 		}
 	}
 
-	err := esi.SetConfiguration("url", localConfigProvider.LocalURL{Name: url.Name, Host: url.Host, TLSSecret: url.TLSSecret, Kind: url.Kind})
+	err := esi.SetConfiguration("url", localConfigProvider.LocalURL{Name: url.Name, Host: url.Host, TLSSecret: url.TLSSecret, Kind: url.Kind, IngressClass: url.IngressClass, Gateway: url.Gateway, ExposedPort: url.ExposedPort})
 	if err != nil {
 		return errors.Wrapf(err, "failed to persist the component settings to env file")
 	}
@@ -353,10 +404,18 @@ func (ei *EnvInfo) ListURLs() ([]localConfigProvider.LocalURL, error) {
 				Container: comp.Name,
 			}
 
+			url.Probe, err = getEndpointProbe(localEndpoint)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid probe for endpoint %s", localEndpoint.Name)
+			}
+
 			if envInfoURL, exist := envMap[localEndpoint.Name]; exist {
 				url.Host = envInfoURL.Host
 				url.TLSSecret = envInfoURL.TLSSecret
 				url.Kind = envInfoURL.Kind
+				url.IngressClass = envInfoURL.IngressClass
+				url.Gateway = envInfoURL.Gateway
+				url.ExposedPort = envInfoURL.ExposedPort
 			} else {
 				url.Kind = localConfigProvider.ROUTE
 			}
@@ -477,6 +536,20 @@ func updateEndpointInDevfile(devObj parser.DevfileObj, url localConfigProvider.L
 	return fmt.Errorf("url %s not found for updating", url.Name)
 }
 
+// validateGatewayReference checks that the Gateway of an URL is referenced as [namespace/]name
+func validateGatewayReference(gateway string) error {
+	parts := strings.Split(gateway, "/")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid gateway %q, it should be [namespace/]name", gateway)
+	}
+	for _, part := range parts {
+		if err := validation.ValidateName(part); err != nil {
+			return errors.Wrapf(err, "invalid gateway %q", gateway)
+		}
+	}
+	return nil
+}
+
 // findInvalidEndpoint finds the URLs which are invalid for the current cluster e.g
 // route urls on a vanilla k8s based cluster
 // urls with no host information on a vanilla k8s based cluster
@@ -492,3 +565,81 @@ func findInvalidEndpoint(ei *EnvInfo, port int) (localConfigProvider.LocalURL, e
 	}
 	return localConfigProvider.LocalURL{}, nil
 }
+
+// getEndpointProbe returns the probe checking the reachability of the URL of the endpoint,
+// from its probe attributes or its protocol, or nil if the default HTTP probe is used
+func getEndpointProbe(endpoint devfilev1.Endpoint) (*localConfigProvider.URLProbe, error) {
+	probeType, err := getStringAttribute(endpoint.Attributes, ProbeTypeAttribute)
+	if err != nil {
+		return nil, err
+	}
+	path, err := getStringAttribute(endpoint.Attributes, ProbePathAttribute)
+	if err != nil {
+		return nil, err
+	}
+	status, err := getStringAttribute(endpoint.Attributes, ProbeStatusAttribute)
+	if err != nil {
+		return nil, err
+	}
+
+	if probeType == "" {
+		switch endpoint.Protocol {
+		case devfilev1.TCPEndpointProtocol:
+			probeType = string(localConfigProvider.TCPProbe)
+		case devfilev1.UDPEndpointProtocol:
+			probeType = string(localConfigProvider.NoProbe)
+		default:
+			if path == "" && status == "" {
+				return nil, nil
+			}
+			probeType = string(localConfigProvider.HTTPProbe)
+		}
+	}
+
+	probe := &localConfigProvider.URLProbe{
+		Type: localConfigProvider.URLProbeType(strings.ToLower(probeType)),
+		Path: path,
+	}
+	switch probe.Type {
+	case localConfigProvider.HTTPProbe, localConfigProvider.TCPProbe, localConfigProvider.GRPCProbe, localConfigProvider.NoProbe:
+	default:
+		return nil, fmt.Errorf("probe type only supports %v|%v|%v|%v", localConfigProvider.HTTPProbe, localConfigProvider.TCPProbe, localConfigProvider.GRPCProbe, localConfigProvider.NoProbe)
+	}
+	if probe.Type != localConfigProvider.HTTPProbe && (path != "" || status != "") {
+		return nil, fmt.Errorf("probe path and status can only be used with %v probes", localConfigProvider.HTTPProbe)
+	}
+
+	for _, expected := range strings.Split(status, ",") {
+		expected = strings.ToLower(strings.TrimSpace(expected))
+		if expected == "" {
+			continue
+		}
+		if !validProbeStatus(expected) {
+			return nil, fmt.Errorf("invalid probe status %q, status must be a code (e.g. 200) or a class (e.g. 2xx)", expected)
+		}
+		probe.ExpectedStatus = append(probe.ExpectedStatus, expected)
+	}
+	return probe, nil
+}
+
+// getStringAttribute returns the value of the string attribute, or an empty string if it isn't set
+func getStringAttribute(endpointAttributes attributes.Attributes, key string) (string, error) {
+	if !endpointAttributes.Exists(key) {
+		return "", nil
+	}
+	var err error
+	value := endpointAttributes.GetString(key, &err)
+	return value, err
+}
+
+// validProbeStatus returns true if status is an HTTP status code or class
+func validProbeStatus(status string) bool {
+	if len(status) != 3 || status[0] < '1' || status[0] > '5' {
+		return false
+	}
+	if status[1:] == "xx" {
+		return true
+	}
+	code, err := strconv.Atoi(status)
+	return err == nil && code >= 100 && code < 600
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser"
 	devfileCtx "github.com/devfile/library/pkg/devfile/parser/context"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
@@ -614,6 +615,147 @@ func TestEnvInfo_ValidateURL(t *testing.T) {
 			updateURL: true,
 			wantErr:   false,
 		},
+		{
+			name: "case 14: ingress class used for non ingress url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:         "http-3000",
+					IngressClass: "nginx",
+					Kind:         localConfigProvider.ROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 15: gateway not provided for HTTPRoute URL",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name: "http-3000",
+					Host: "com",
+					Kind: localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 16: gateway is not valid",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Gateway: "infra/external/gateway",
+					Kind:    localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 17: secure HTTPRoute URL",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Gateway: "external",
+					Secure:  true,
+					Kind:    localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 18: no error in the HTTPRoute url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Gateway: "infra/external",
+					Kind:    localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "case 19: no error in the ingress url with an ingress class",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:         "http-3000",
+					Host:         "com",
+					IngressClass: "nginx",
+					Kind:         localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "case 20: host used for portforward URL",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:        "http-3000",
+					Host:        "com",
+					ExposedPort: 3000,
+					Kind:        localConfigProvider.PORTFORWARD,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 21: local port used by another portforward URL",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+				componentSettings: ComponentSettings{
+					URL: &[]localConfigProvider.LocalURL{
+						{
+							Name:        "port-3030",
+							ExposedPort: 3000,
+							Kind:        localConfigProvider.PORTFORWARD,
+						},
+					},
+				},
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:        "http-3000",
+					ExposedPort: 3000,
+					Kind:        localConfigProvider.PORTFORWARD,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 22: no error in the portforward url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:        "http-3000",
+					ExposedPort: 3000,
+					Kind:        localConfigProvider.PORTFORWARD,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1187,3 +1329,81 @@ func Test_updateEndpointInDevfile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEndpointProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint v1.Endpoint
+		want     *localConfigProvider.URLProbe
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: HTTP endpoint without probe attributes",
+			endpoint: v1.Endpoint{Name: "http", Protocol: v1.HTTPEndpointProtocol},
+			want:     nil,
+		},
+		{
+			name:     "Case 2: TCP endpoint",
+			endpoint: v1.Endpoint{Name: "db", Protocol: v1.TCPEndpointProtocol},
+			want:     &localConfigProvider.URLProbe{Type: localConfigProvider.TCPProbe},
+		},
+		{
+			name:     "Case 3: UDP endpoint",
+			endpoint: v1.Endpoint{Name: "dns", Protocol: v1.UDPEndpointProtocol},
+			want:     &localConfigProvider.URLProbe{Type: localConfigProvider.NoProbe},
+		},
+		{
+			name: "Case 4: HTTP probe with path and status",
+			endpoint: v1.Endpoint{
+				Name:       "http",
+				Attributes: attributes.Attributes{}.PutString(ProbePathAttribute, "/health").PutString(ProbeStatusAttribute, "200, 3XX"),
+			},
+			want: &localConfigProvider.URLProbe{Type: localConfigProvider.HTTPProbe, Path: "/health", ExpectedStatus: []string{"200", "3xx"}},
+		},
+		{
+			name: "Case 5: gRPC probe",
+			endpoint: v1.Endpoint{
+				Name:       "grpc",
+				Protocol:   v1.HTTPEndpointProtocol,
+				Attributes: attributes.Attributes{}.PutString(ProbeTypeAttribute, "grpc"),
+			},
+			want: &localConfigProvider.URLProbe{Type: localConfigProvider.GRPCProbe},
+		},
+		{
+			name: "Case 6: Invalid probe type",
+			endpoint: v1.Endpoint{
+				Name:       "http",
+				Attributes: attributes.Attributes{}.PutString(ProbeTypeAttribute, "icmp"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 7: Invalid probe status",
+			endpoint: v1.Endpoint{
+				Name:       "http",
+				Attributes: attributes.Attributes{}.PutString(ProbeStatusAttribute, "20x"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 8: Probe path with a TCP probe",
+			endpoint: v1.Endpoint{
+				Name:       "db",
+				Protocol:   v1.TCPEndpointProtocol,
+				Attributes: attributes.Attributes{}.PutString(ProbePathAttribute, "/health"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getEndpointProbe(tt.endpoint)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("getEndpointProbe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getEndpointProbe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package kclient
 import (
 	fakeServiceCatalogClientSet "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
 	fakeKubeClientset "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -31,7 +34,24 @@ func FakeNew() (*Client, *FakeClientset) {
 	return &client, &fkclientset
 }
 
-//FakePodStatus returns a pod with the status
+// FakeNewWithoutOptionalAPIs creates new fake client for testing, like FakeNew, whose cluster serves none of the
+// optional APIs, such as the networking.k8s.io/v1 Ingresses or the Gateway API HTTPRoutes
+func FakeNewWithoutOptionalAPIs() (*Client, *FakeClientset) {
+	client, fkclientset := FakeNew()
+	client.discoveryClient = noOptionalAPIDiscovery{fkclientset.Kubernetes.Discovery().(*discoveryfake.FakeDiscovery)}
+	return client, fkclientset
+}
+
+// noOptionalAPIDiscovery is a fake discovery client for which no group version is served
+type noOptionalAPIDiscovery struct {
+	*discoveryfake.FakeDiscovery
+}
+
+func (d noOptionalAPIDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	return nil, kerrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+// FakePodStatus returns a pod with the status
 func FakePodStatus(status corev1.PodPhase, podName string) *corev1.Pod {
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,160 @@
+package kclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GatewayAPIGroup is the API group of the Kubernetes Gateway API
+	GatewayAPIGroup = "gateway.networking.k8s.io"
+	// HTTPRouteKind is the kind of the Gateway API resource routing HTTP requests
+	HTTPRouteKind     = "HTTPRoute"
+	httpRouteResource = "httproutes"
+)
+
+// gatewayAPIVersions are the versions of the Gateway API odo can use, the most recent first
+var gatewayAPIVersions = []string{"v1", "v1beta1"}
+
+// HTTPRoute is the subset of the Gateway API HTTPRoute resource used by odo
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              HTTPRouteSpec `json:"spec"`
+}
+
+// HTTPRouteSpec attaches the route to Gateways and describes the requests it routes
+type HTTPRouteSpec struct {
+	ParentRefs []GatewayReference `json:"parentRefs,omitempty"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule    `json:"rules,omitempty"`
+}
+
+// GatewayReference references the Gateway a route is attached to
+type GatewayReference struct {
+	// Namespace of the Gateway, the namespace of the route if empty
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// HTTPRouteRule routes the requests matching one of the matches to the backends
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch matches the requests by their path
+type HTTPRouteMatch struct {
+	Path *HTTPPathMatch `json:"path,omitempty"`
+}
+
+// HTTPPathMatch matches the path of requests, Type is one of Exact, PathPrefix or RegularExpression
+type HTTPPathMatch struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPBackendRef references the service the requests are routed to
+type HTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port,omitempty"`
+}
+
+// GetHTTPRouteAPIVersion returns the version of the Gateway API HTTPRoute resource served by the cluster,
+// it returns an empty string if the Gateway API isn't installed on the cluster
+func (c *Client) GetHTTPRouteAPIVersion() (string, error) {
+	for _, version := range gatewayAPIVersions {
+		supported, err := c.IsResourceSupported(GatewayAPIGroup, version, httpRouteResource)
+		if err != nil {
+			return "", err
+		}
+		if supported {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
+// getHTTPRouteResource returns the resource of the HTTPRoutes served by the cluster
+func (c *Client) getHTTPRouteResource() (schema.GroupVersionResource, error) {
+	version, err := c.GetHTTPRouteAPIVersion()
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	if version == "" {
+		return schema.GroupVersionResource{}, errors.New("the Gateway API is not installed on the cluster, URLs of HTTPRoute kind can't be used")
+	}
+	return schema.GroupVersionResource{Group: GatewayAPIGroup, Version: version, Resource: httpRouteResource}, nil
+}
+
+// CreateHTTPRoute creates the given HTTPRoute
+func (c *Client) CreateHTTPRoute(route HTTPRoute) (*HTTPRoute, error) {
+	if route.GetName() == "" {
+		return nil, fmt.Errorf("HTTPRoute name is empty")
+	}
+	gvr, err := c.getHTTPRouteResource()
+	if err != nil {
+		return nil, err
+	}
+	route.TypeMeta = metav1.TypeMeta{Kind: HTTPRouteKind, APIVersion: gvr.GroupVersion().String()}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&route)
+	if err != nil {
+		return nil, err
+	}
+	created, err := c.DynamicClient.Resource(gvr).Namespace(c.Namespace).Create(context.TODO(), &unstructured.Unstructured{Object: content}, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating HTTPRoute")
+	}
+
+	var createdRoute HTTPRoute
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(created.UnstructuredContent(), &createdRoute)
+	return &createdRoute, err
+}
+
+// DeleteHTTPRoute deletes the given HTTPRoute
+func (c *Client) DeleteHTTPRoute(name string) error {
+	gvr, err := c.getHTTPRouteResource()
+	if err != nil {
+		return err
+	}
+	err = c.DynamicClient.Resource(gvr).Namespace(c.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return errors.Wrap(err, "unable to delete HTTPRoute")
+	}
+	return nil
+}
+
+// ListHTTPRoutes lists all the HTTPRoutes based on the given label selector,
+// no route is returned if the Gateway API isn't installed on the cluster
+func (c *Client) ListHTTPRoutes(labelSelector string) ([]HTTPRoute, error) {
+	version, err := c.GetHTTPRouteAPIVersion()
+	if err != nil || version == "" {
+		return nil, err
+	}
+	gvr := schema.GroupVersionResource{Group: GatewayAPIGroup, Version: version, Resource: httpRouteResource}
+
+	list, err := c.DynamicClient.Resource(gvr).Namespace(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get HTTPRoute list")
+	}
+
+	routes := make([]HTTPRoute, 0, len(list.Items))
+	for _, item := range list.Items {
+		var route HTTPRoute
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &route); err != nil {
+			return nil, errors.Wrapf(err, "unable to read HTTPRoute %s", item.GetName())
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -48,3 +49,48 @@ func (c *Client) GetIngress(name string) (*extensionsv1.Ingress, error) {
 	ingress, err := c.KubeClient.ExtensionsV1beta1().Ingresses(c.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
 	return ingress, err
 }
+
+// IsNetworkingV1IngressSupported checks if the cluster serves the networking.k8s.io/v1 Ingress API,
+// the extensions/v1beta1 API is removed from newer clusters
+func (c *Client) IsNetworkingV1IngressSupported() (bool, error) {
+	return c.IsResourceSupported("networking.k8s.io", "v1", "ingresses")
+}
+
+// CreateIngressV1 creates a networking.k8s.io/v1 ingress object
+func (c *Client) CreateIngressV1(ingress networkingv1.Ingress) (*networkingv1.Ingress, error) {
+	if ingress.GetName() == "" {
+		return nil, fmt.Errorf("ingress name is empty")
+	}
+	ingressObj, err := c.KubeClient.NetworkingV1().Ingresses(c.Namespace).Create(context.TODO(), &ingress, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating ingress")
+	}
+	return ingressObj, nil
+}
+
+// DeleteIngressV1 deletes the given networking.k8s.io/v1 ingress
+func (c *Client) DeleteIngressV1(name string) error {
+	err := c.KubeClient.NetworkingV1().Ingresses(c.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return errors.Wrap(err, "unable to delete ingress")
+	}
+	return nil
+}
+
+// ListIngressesV1 lists all the networking.k8s.io/v1 ingresses based on the given label selector
+func (c *Client) ListIngressesV1(labelSelector string) ([]networkingv1.Ingress, error) {
+	ingressList, err := c.KubeClient.NetworkingV1().Ingresses(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get ingress list")
+	}
+
+	return ingressList.Items, nil
+}
+
+// GetIngressV1 gets a networking.k8s.io/v1 ingress based on the given name
+func (c *Client) GetIngressV1(name string) (*networkingv1.Ingress, error) {
+	ingress, err := c.KubeClient.NetworkingV1().Ingresses(c.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	return ingress, err
+}
@@ -218,6 +218,28 @@ func (c *Client) GetOnePodFromSelector(selector string) (*corev1.Pod, error) {
 	return &pods.Items[0], nil
 }
 
+// WaitForPodsDeletion waits until no pod matches the selector, e.g. after scaling down a deployment
+func (c *Client) WaitForPodsDeletion(selector string) error {
+	timeout := time.After(waitForComponentDeletionTimeout)
+	for {
+		pods, err := c.KubeClient.CoreV1().Pods(c.Namespace).List(context.TODO(), metav1.ListOptions{
+			LabelSelector: selector,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to list the pods with selector %s", selector)
+		}
+		if len(pods.Items) == 0 {
+			return nil
+		}
+
+		select {
+		case <-timeout:
+			return errors.Errorf("timeout while waiting for the pods with selector %s to be deleted", selector)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
 // GetPodLogs prints the log from pod to stdout
 func (c *Client) GetPodLogs(podName, containerName string, followLog bool) (io.ReadCloser, error) {
 
@@ -47,6 +47,21 @@ func (c *Client) CreateTLSSecret(tlsCertificate []byte, tlsPrivKey []byte, objec
 	return secret, nil
 }
 
+// UpdateTLSSecret replaces the certificate and private key of the given TLS Secret
+func (c *Client) UpdateTLSSecret(secret *corev1.Secret, tlsCertificate []byte, tlsPrivKey []byte) (*corev1.Secret, error) {
+	secret = secret.DeepCopy()
+	secret.Data = map[string][]byte{
+		"tls.crt": tlsCertificate,
+		"tls.key": tlsPrivKey,
+	}
+
+	updated, err := c.KubeClient.CoreV1().Secrets(c.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update secret %s", secret.Name)
+	}
+	return updated, nil
+}
+
 // SelfSignedCertificate struct is the return type of function GenerateSelfSignedCertificate
 // CertPem is the byte array for certificate pem encode
 // KeyPem is the byte array for key pem encode
@@ -87,6 +87,37 @@ func TestCreateTLSSecret(t *testing.T) {
 	}
 }
 
+func TestUpdateTLSSecret(t *testing.T) {
+	fkclient, fkclientset := FakeNew()
+	fkclient.Namespace = "default"
+
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testComponent-tlssecret",
+			Namespace: "default",
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{"tls.crt": []byte("old-cert"), "tls.key": []byte("old-key")},
+	}
+	fkclientset.Kubernetes.PrependReactor("update", "secrets", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, action.(ktesting.UpdateAction).GetObject(), nil
+	})
+
+	updated, err := fkclient.UpdateTLSSecret(existing, []byte("new-cert"), []byte("new-key"))
+	if err != nil {
+		t.Fatalf("fkclient.UpdateTLSSecret unexpected error %v", err)
+	}
+	if len(fkclientset.Kubernetes.Actions()) != 1 {
+		t.Errorf("expected 1 action, got: %v", fkclientset.Kubernetes.Actions())
+	}
+	if string(updated.Data["tls.crt"]) != "new-cert" || string(updated.Data["tls.key"]) != "new-key" {
+		t.Errorf("secret data not updated, got: %v", updated.Data)
+	}
+	if string(existing.Data["tls.crt"]) != "old-cert" {
+		t.Errorf("the given secret should not be modified")
+	}
+}
+
 func TestGenerateSelfSignedCertificate(t *testing.T) {
 
 	tests := []struct {
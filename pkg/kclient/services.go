@@ -2,10 +2,14 @@ package kclient
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // CreateService generates and creates the service
@@ -38,3 +42,61 @@ func (c *Client) ListServices(selector string) ([]corev1.Service, error) {
 	}
 	return serviceList.Items, nil
 }
+
+// GetService returns the service with the given name
+func (c *Client) GetService(serviceName string) (*corev1.Service, error) {
+	service, err := c.KubeClient.CoreV1().Services(c.Namespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get Service %s", serviceName)
+	}
+	return service, nil
+}
+
+// GetServicePod returns a running pod selected by the service
+func (c *Client) GetServicePod(service *corev1.Service) (*corev1.Pod, error) {
+	if len(service.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("the Service %s doesn't select any pod", service.Name)
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector).String()
+	pods, err := c.KubeClient.CoreV1().Pods(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list the pods of Service %s", service.Name)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning && pods.Items[i].DeletionTimestamp == nil {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, &PodNotFoundError{Selector: selector}
+}
+
+// GetServiceTargetPort returns the port of the pod to which the port of the service is routed,
+// the port is returned unchanged if it isn't a port of the service
+func GetServiceTargetPort(service *corev1.Service, pod *corev1.Pod, port int) (int, error) {
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) != port || servicePort.Protocol == corev1.ProtocolUDP {
+			continue
+		}
+		switch {
+		case servicePort.TargetPort.Type == intstr.String:
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return int(containerPort.ContainerPort), nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("the port %s of Service %s is not a port of pod %s", servicePort.TargetPort.StrVal, service.Name, pod.Name)
+		case servicePort.TargetPort.IntVal != 0:
+			return int(servicePort.TargetPort.IntVal), nil
+		default:
+			return port, nil
+		}
+	}
+	return port, nil
+}
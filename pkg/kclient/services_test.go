@@ -16,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	ktesting "k8s.io/client-go/testing"
 )
@@ -249,3 +250,124 @@ func TestListServices(t *testing.T) {
 		})
 	}
 }
+
+func TestGetServicePod(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydb"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "mydb"}},
+	}
+	pod := func(name string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": "mydb"}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		service      *corev1.Service
+		returnedPods []corev1.Pod
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: First running pod",
+			service:      service,
+			returnedPods: []corev1.Pod{pod("mydb-2", corev1.PodRunning), pod("mydb-0", corev1.PodPending), pod("mydb-1", corev1.PodRunning)},
+			want:         "mydb-1",
+		},
+		{
+			name:         "Case 2: No running pod",
+			service:      service,
+			returnedPods: []corev1.Pod{pod("mydb-0", corev1.PodPending)},
+			wantErr:      true,
+		},
+		{
+			name:    "Case 3: Service without selector",
+			service: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fkclient, fkclientset := FakeNew()
+			fkclientset.Kubernetes.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PodList{Items: tt.returnedPods}, nil
+			})
+
+			got, err := fkclient.GetServicePod(tt.service)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetServicePod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Name != tt.want {
+				t.Errorf("GetServicePod() = %v, want %v", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetServiceTargetPort(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydb"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(8080)},
+				{Port: 5432, TargetPort: intstr.FromString("postgres")},
+				{Port: 9000},
+				{Port: 9090, TargetPort: intstr.FromString("metrics")},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydb-0"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{Name: "postgres", ContainerPort: 5433}}}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		port    int
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Case 1: Numeric target port",
+			port: 80,
+			want: 8080,
+		},
+		{
+			name: "Case 2: Named target port",
+			port: 5432,
+			want: 5433,
+		},
+		{
+			name: "Case 3: Target port not set",
+			port: 9000,
+			want: 9000,
+		},
+		{
+			name: "Case 4: Port of the pod",
+			port: 8443,
+			want: 8443,
+		},
+		{
+			name:    "Case 5: Named target port not found",
+			port:    9090,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetServiceTargetPort(service, pod, tt.port)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetServiceTargetPort() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetServiceTargetPort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
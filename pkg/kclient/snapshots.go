@@ -0,0 +1,256 @@
+package kclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+)
+
+const (
+	// SnapshotAPIGroup is the API group of the CSI volume snapshots
+	SnapshotAPIGroup = "snapshot.storage.k8s.io"
+	// VolumeSnapshotKind is the kind of the snapshots of PVCs
+	VolumeSnapshotKind          = "VolumeSnapshot"
+	volumeSnapshotResource      = "volumesnapshots"
+	volumeSnapshotClassResource = "volumesnapshotclasses"
+
+	// defaultSnapshotClassAnnotation marks the default VolumeSnapshotClass of a CSI driver
+	defaultSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+
+	// snapshotReadyTimeout is how long to wait for a snapshot to be ready to use
+	snapshotReadyTimeout = 5 * time.Minute
+)
+
+// snapshotAPIVersions are the versions of the snapshot API odo can use, the most recent first
+var snapshotAPIVersions = []string{"v1", "v1beta1"}
+
+// VolumeSnapshot is the subset of the CSI VolumeSnapshot resource used by odo
+type VolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VolumeSnapshotSpec    `json:"spec"`
+	Status            *VolumeSnapshotStatus `json:"status,omitempty"`
+}
+
+// VolumeSnapshotSpec describes the PVC the snapshot is taken from and the class used to take it
+type VolumeSnapshotSpec struct {
+	Source                  VolumeSnapshotSource `json:"source"`
+	VolumeSnapshotClassName *string              `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// VolumeSnapshotSource is the PVC the snapshot is taken from
+type VolumeSnapshotSource struct {
+	PersistentVolumeClaimName *string `json:"persistentVolumeClaimName,omitempty"`
+}
+
+// VolumeSnapshotStatus is the status of the snapshot
+type VolumeSnapshotStatus struct {
+	ReadyToUse  *bool                `json:"readyToUse,omitempty"`
+	RestoreSize *string              `json:"restoreSize,omitempty"`
+	Error       *VolumeSnapshotError `json:"error,omitempty"`
+}
+
+// VolumeSnapshotError is the last error encountered while taking the snapshot
+type VolumeSnapshotError struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// volumeSnapshotClass is the subset of the VolumeSnapshotClass resource used by odo
+type volumeSnapshotClass struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Driver            string `json:"driver"`
+}
+
+// GetVolumeSnapshotAPIVersion returns the version of the snapshot API served by the cluster,
+// it returns an empty string if the cluster doesn't support CSI volume snapshots
+func (c *Client) GetVolumeSnapshotAPIVersion() (string, error) {
+	for _, version := range snapshotAPIVersions {
+		supported, err := c.IsResourceSupported(SnapshotAPIGroup, version, volumeSnapshotResource)
+		if err != nil {
+			return "", err
+		}
+		if supported {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
+// GetVolumeSnapshotClassForPVC returns the VolumeSnapshotClass able to snapshot the given PVC,
+// it returns an empty string if the PVC isn't bound to a CSI volume or no class exists for its driver
+func (c *Client) GetVolumeSnapshotClassForPVC(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	version, err := c.GetVolumeSnapshotAPIVersion()
+	if err != nil || version == "" {
+		return "", err
+	}
+	if pvc.Spec.VolumeName == "" {
+		return "", nil
+	}
+	pv, err := c.KubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		// regular users are often not allowed to get persistent volumes
+		klog.V(4).Infof("unable to get the volume %s of PVC %s: %v", pvc.Spec.VolumeName, pvc.Name, err)
+		return "", nil
+	}
+	if pv.Spec.CSI == nil {
+		return "", nil
+	}
+
+	gvr := schema.GroupVersionResource{Group: SnapshotAPIGroup, Version: version, Resource: volumeSnapshotClassResource}
+	list, err := c.DynamicClient.Resource(gvr).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to list the volume snapshot classes")
+	}
+	className := ""
+	for _, item := range list.Items {
+		var class volumeSnapshotClass
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &class); err != nil {
+			return "", errors.Wrapf(err, "unable to read volume snapshot class %s", item.GetName())
+		}
+		if class.Driver != pv.Spec.CSI.Driver {
+			continue
+		}
+		if class.Annotations[defaultSnapshotClassAnnotation] == "true" {
+			return class.Name, nil
+		}
+		if className == "" {
+			className = class.Name
+		}
+	}
+	return className, nil
+}
+
+// getVolumeSnapshotResource returns the resource of the VolumeSnapshots served by the cluster
+func (c *Client) getVolumeSnapshotResource() (schema.GroupVersionResource, error) {
+	version, err := c.GetVolumeSnapshotAPIVersion()
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	if version == "" {
+		return schema.GroupVersionResource{}, errors.New("the cluster doesn't support volume snapshots")
+	}
+	return schema.GroupVersionResource{Group: SnapshotAPIGroup, Version: version, Resource: volumeSnapshotResource}, nil
+}
+
+// CreateVolumeSnapshot creates the given VolumeSnapshot
+func (c *Client) CreateVolumeSnapshot(snapshot VolumeSnapshot) (*VolumeSnapshot, error) {
+	if snapshot.GetName() == "" {
+		return nil, fmt.Errorf("VolumeSnapshot name is empty")
+	}
+	gvr, err := c.getVolumeSnapshotResource()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.TypeMeta = metav1.TypeMeta{Kind: VolumeSnapshotKind, APIVersion: gvr.GroupVersion().String()}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+	created, err := c.DynamicClient.Resource(gvr).Namespace(c.Namespace).Create(context.TODO(), &unstructured.Unstructured{Object: content}, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating VolumeSnapshot")
+	}
+	return volumeSnapshotFromUnstructured(created)
+}
+
+// GetVolumeSnapshot returns the VolumeSnapshot with the given name
+func (c *Client) GetVolumeSnapshot(name string) (*VolumeSnapshot, error) {
+	gvr, err := c.getVolumeSnapshotResource()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := c.DynamicClient.Resource(gvr).Namespace(c.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get VolumeSnapshot %s", name)
+	}
+	return volumeSnapshotFromUnstructured(snapshot)
+}
+
+// ListVolumeSnapshots lists all the VolumeSnapshots based on the given label selector,
+// no snapshot is returned if the cluster doesn't support volume snapshots
+func (c *Client) ListVolumeSnapshots(labelSelector string) ([]VolumeSnapshot, error) {
+	version, err := c.GetVolumeSnapshotAPIVersion()
+	if err != nil || version == "" {
+		return nil, err
+	}
+	gvr := schema.GroupVersionResource{Group: SnapshotAPIGroup, Version: version, Resource: volumeSnapshotResource}
+
+	list, err := c.DynamicClient.Resource(gvr).Namespace(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get VolumeSnapshot list")
+	}
+
+	snapshots := make([]VolumeSnapshot, 0, len(list.Items))
+	for i := range list.Items {
+		snapshot, err := volumeSnapshotFromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+	return snapshots, nil
+}
+
+// WaitForVolumeSnapshotReady waits until the VolumeSnapshot is ready to be restored
+func (c *Client) WaitForVolumeSnapshotReady(name string) (*VolumeSnapshot, error) {
+	timeout := time.After(snapshotReadyTimeout)
+	for {
+		snapshot, err := c.GetVolumeSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Status != nil {
+			if snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse {
+				return snapshot, nil
+			}
+			if snapshot.Status.Error != nil && snapshot.Status.Error.Message != nil {
+				klog.V(3).Infof("VolumeSnapshot %s is not ready: %s", name, *snapshot.Status.Error.Message)
+			}
+		}
+
+		select {
+		case <-timeout:
+			return nil, errors.Errorf("timeout while waiting for VolumeSnapshot %s to be ready", name)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// WaitForPVCDeletion waits until the PVC with the given name doesn't exist anymore
+func (c *Client) WaitForPVCDeletion(pvcName string) error {
+	timeout := time.After(snapshotReadyTimeout)
+	for {
+		_, err := c.KubeClient.CoreV1().PersistentVolumeClaims(c.Namespace).Get(context.TODO(), pvcName, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "unable to get PVC %s", pvcName)
+		}
+
+		select {
+		case <-timeout:
+			return errors.Errorf("timeout while waiting for PVC %s to be deleted", pvcName)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func volumeSnapshotFromUnstructured(object *unstructured.Unstructured) (*VolumeSnapshot, error) {
+	var snapshot VolumeSnapshot
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), &snapshot); err != nil {
+		return nil, errors.Wrapf(err, "unable to read VolumeSnapshot %s", object.GetName())
+	}
+	return &snapshot, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/devfile/library/pkg/devfile/generator"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -61,6 +62,44 @@ func (c *Client) GetPVCFromName(pvcName string) (*corev1.PersistentVolumeClaim,
 	return c.KubeClient.CoreV1().PersistentVolumeClaims(c.Namespace).Get(context.TODO(), pvcName, metav1.GetOptions{})
 }
 
+// ExpandPVC increases the size of the given PVC, its StorageClass must allow volume expansion;
+// the volume of a PVC used by a running pod is expanded online by its CSI driver
+func (c *Client) ExpandPVC(pvcName string, size resource.Quantity) error {
+	pvc, err := c.GetPVCFromName(pvcName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get PVC %s", pvcName)
+	}
+	currentSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(currentSize) < 0 {
+		return errors.Errorf("the size of PVC %s can't be reduced from %s to %s", pvcName, currentSize.String(), size.String())
+	}
+	if size.Cmp(currentSize) == 0 {
+		return nil
+	}
+
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return errors.Errorf("the PVC %s has no StorageClass, its size can't be increased from %s to %s", pvcName, currentSize.String(), size.String())
+	}
+	className := *pvc.Spec.StorageClassName
+	storageClass, err := c.KubeClient.StorageV1().StorageClasses().Get(context.TODO(), className, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "unable to get StorageClass %s", className)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return errors.Errorf("the StorageClass %s doesn't allow volume expansion, the size of PVC %s can't be increased from %s to %s", className, pvcName, currentSize.String(), size.String())
+	}
+
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	_, err = c.KubeClient.CoreV1().PersistentVolumeClaims(c.Namespace).Update(context.TODO(), pvc, metav1.UpdateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return errors.Wrapf(err, "unable to expand PVC %s", pvcName)
+	}
+	return nil
+}
+
 // UpdatePVCLabels updates the given PVC with the given labels
 func (c *Client) UpdatePVCLabels(pvc *corev1.PersistentVolumeClaim, labels map[string]string) error {
 	pvc.Labels = labels
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktesting "k8s.io/client-go/testing"
 
@@ -293,6 +294,108 @@ func TestGetPVCFromName(t *testing.T) {
 	}
 }
 
+func TestExpandPVC(t *testing.T) {
+	allowed, notAllowed := true, false
+	storageClass := func(name string, allowVolumeExpansion *bool) *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: name},
+			AllowVolumeExpansion: allowVolumeExpansion,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		className    string
+		storageClass *storagev1.StorageClass
+		size         string
+		wantUpdate   bool
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: StorageClass allowing volume expansion",
+			className:    "fast",
+			storageClass: storageClass("fast", &allowed),
+			size:         "2Gi",
+			wantUpdate:   true,
+		},
+		{
+			name:         "Case 2: StorageClass not allowing volume expansion",
+			className:    "slow",
+			storageClass: storageClass("slow", &notAllowed),
+			size:         "2Gi",
+			wantErr:      true,
+		},
+		{
+			name:         "Case 3: StorageClass without volume expansion setting",
+			className:    "slow",
+			storageClass: storageClass("slow", nil),
+			size:         "2Gi",
+			wantErr:      true,
+		},
+		{
+			name:    "Case 4: PVC without StorageClass",
+			size:    "2Gi",
+			wantErr: true,
+		},
+		{
+			name:         "Case 5: Size reduced",
+			className:    "fast",
+			storageClass: storageClass("fast", &allowed),
+			size:         "500Mi",
+			wantErr:      true,
+		},
+		{
+			name:         "Case 6: Same size",
+			className:    "fast",
+			storageClass: storageClass("fast", &allowed),
+			size:         "1024Mi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, fakeClientSet := FakeNew()
+
+			pvc := testingutil.FakePVC("storage-1-nodejs", "1Gi", nil)
+			if tt.className != "" {
+				pvc.Spec.StorageClassName = &tt.className
+			}
+			fakeClientSet.Kubernetes.PrependReactor("get", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, pvc, nil
+			})
+			fakeClientSet.Kubernetes.PrependReactor("get", "storageclasses", func(action ktesting.Action) (bool, runtime.Object, error) {
+				if tt.storageClass == nil {
+					return true, nil, fmt.Errorf("storageclass not found")
+				}
+				return true, tt.storageClass, nil
+			})
+			fakeClientSet.Kubernetes.PrependReactor("update", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, action.(ktesting.UpdateAction).GetObject(), nil
+			})
+
+			err := fakeClient.ExpandPVC(pvc.Name, resource.MustParse(tt.size))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpandPVC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var updated *corev1.PersistentVolumeClaim
+			for _, action := range fakeClientSet.Kubernetes.Actions() {
+				if action.GetVerb() == "update" {
+					updated = action.(ktesting.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim)
+				}
+			}
+			if (updated != nil) != tt.wantUpdate {
+				t.Fatalf("got PVC update %v, want update %v", updated != nil, tt.wantUpdate)
+			}
+			if updated != nil {
+				size := updated.Spec.Resources.Requests[corev1.ResourceStorage]
+				if size.String() != tt.size {
+					t.Errorf("got size %s, want %s", size.String(), tt.size)
+				}
+			}
+		})
+	}
+}
+
 func TestListPVCNames(t *testing.T) {
 	type args struct {
 		selector string
@@ -1,11 +1,14 @@
 package localConfigProvider
 
-// URLKind is an enum to indicate the type of the URL i.e ingress/route
+// URLKind is an enum to indicate the type of the URL i.e ingress/route/httproute/portforward
 type URLKind string
 
 const (
-	INGRESS URLKind = "ingress"
-	ROUTE   URLKind = "route"
+	INGRESS   URLKind = "ingress"
+	ROUTE     URLKind = "route"
+	HTTPROUTE URLKind = "httproute"
+	// PORTFORWARD URLs are reached on localhost by forwarding their port to the pod of the component
+	PORTFORWARD URLKind = "portforward"
 )
 
 // LocalURL holds URL related information
@@ -20,16 +23,47 @@ type LocalURL struct {
 	Host string `yaml:"Host,omitempty" json:"host,omitempty"`
 	// TLS secret name to create ingress to provide a secure URL
 	TLSSecret string `yaml:"TLSSecret,omitempty" json:"tlsSecret,omitempty"`
-	// Exposed port number for docker container, required for local scenarios
+	// Exposed port number for docker container, required for local scenarios, and local port of the URLs of portforward kind
 	ExposedPort int `yaml:"ExposedPort,omitempty" json:"exposedPort,omitempty"`
 	// Kind is the kind of the URL
 	Kind URLKind `yaml:"Kind,omitempty" json:"kind,omitempty"`
+	// IngressClass is the class of the ingress controller serving the URLs of ingress kind
+	IngressClass string `yaml:"IngressClass,omitempty" json:"ingressClass,omitempty"`
+	// Gateway is the Gateway, as [namespace/]name, the URLs of httproute kind are attached to
+	Gateway string `yaml:"Gateway,omitempty" json:"gateway,omitempty"`
 	// Path is the path of the URL
 	Path string `yaml:"-" json:"-"`
 	// Container is the container of the URL
 	Container string `yaml:"-" json:"-"`
 	// Protocol is the protocol of the URL
 	Protocol string `yaml:"-" json:"-"`
+	// Probe describes how the reachability of the URL is checked, an HTTP probe accepting any response is used when nil
+	Probe *URLProbe `yaml:"-" json:"-"`
+}
+
+// URLProbeType is the kind of check used to find out if a URL is reachable
+type URLProbeType string
+
+const (
+	// HTTPProbe sends an HTTP GET request to the URL
+	HTTPProbe URLProbeType = "http"
+	// TCPProbe opens a TCP connection to the host and port of the URL
+	TCPProbe URLProbeType = "tcp"
+	// GRPCProbe calls the standard gRPC health checking service of the URL
+	GRPCProbe URLProbeType = "grpc"
+	// NoProbe disables the reachability check, e.g. for UDP endpoints
+	NoProbe URLProbeType = "none"
+)
+
+// URLProbe describes how the reachability of a URL is checked
+type URLProbe struct {
+	// Type is the kind of check
+	Type URLProbeType `json:"type"`
+	// Path is requested by HTTP probes instead of the path of the URL
+	Path string `json:"path,omitempty"`
+	// ExpectedStatus lists the HTTP status codes (e.g. 200) or classes (e.g. 2xx) accepted by HTTP probes,
+	// any response is accepted when empty
+	ExpectedStatus []string `json:"expectedStatus,omitempty"`
 }
 
 // LocalStorage holds storage related information
@@ -42,6 +76,12 @@ type LocalStorage struct {
 	Path string `yaml:"Path,omitempty"`
 	// Container is the container name on which this storage is mounted
 	Container string `yaml:"-" json:"-"`
+	// StorageClass is the name of the StorageClass of the storage, the default class of the cluster is used if empty
+	StorageClass string `yaml:"-" json:"-"`
+	// AccessMode is the access mode of the storage (ReadWriteOnce, ReadWriteMany or ReadOnlyMany)
+	AccessMode string `yaml:"-" json:"-"`
+	// VolumeMode is the volume mode of the storage (Filesystem or Block)
+	VolumeMode string `yaml:"-" json:"-"`
 }
 
 // LocalContainer holds the container related information
@@ -385,11 +385,12 @@ func ExplicitSpinner(status string, preventSpinning bool) *Status {
 
 // IsJSON returns true if we are in machine output mode..
 // under NO circumstances should we output any logging.. as we are only outputting json
+// (or any of the other machine readable formats, such as yaml or jsonpath)
 func IsJSON() bool {
 
 	flag := pflag.Lookup("o")
 	if flag != nil && flag.Changed {
-		return strings.Contains(pflag.Lookup("o").Value.String(), "json")
+		return pflag.Lookup("o").Value.String() != ""
 	}
 
 	return false
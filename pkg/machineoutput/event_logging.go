@@ -80,7 +80,7 @@ func (c *NoOpMachineEventLoggingClient) ContainerStatus(statuses []ContainerStat
 }
 
 // URLReachable ignores the provided event.
-func (c *NoOpMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string) {
+func (c *NoOpMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe string, latencyMillis int64, timestamp string) {
 
 }
 
@@ -89,6 +89,11 @@ func (c *NoOpMachineEventLoggingClient) KubernetesPodStatus(pods []KubernetesPod
 
 }
 
+// SupervisordCrashLoop ignores the provided event.
+func (c *NoOpMachineEventLoggingClient) SupervisordCrashLoop(crashLoop SupervisordCrashLoop, timestamp string) {
+
+}
+
 // NewConsoleMachineEventLoggingClient creates a new instance of ConsoleMachineEventLoggingClient,
 // which will output events as JSON to the console.
 func NewConsoleMachineEventLoggingClient() *ConsoleMachineEventLoggingClient {
@@ -154,8 +159,8 @@ func (c *ConsoleMachineEventLoggingClient) DevFileCommandExecutionComplete(comma
 // Returned channels will each contain a single nil entry once the underlying reader has closed.
 func (c *ConsoleMachineEventLoggingClient) CreateContainerOutputWriter() (*io.PipeWriter, chan interface{}, *io.PipeWriter, chan interface{}) {
 
-	stdoutWriter, stdoutChannel := createWriterAndChannel(false)
-	stderrWriter, stderrChannel := createWriterAndChannel(true)
+	stdoutWriter, stdoutChannel := c.createWriterAndChannel(false)
+	stderrWriter, stderrChannel := c.createWriterAndChannel(true)
 
 	return stdoutWriter, stdoutChannel, stderrWriter, stderrChannel
 
@@ -199,7 +204,7 @@ func (c *ConsoleMachineEventLoggingClient) ContainerStatus(statuses []ContainerS
 }
 
 // URLReachable outputs the provided event as JSON to the console.
-func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string) {
+func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe string, latencyMillis int64, timestamp string) {
 	json := MachineEventWrapper{
 		URLReachable: &URLReachable{
 			Name:             name,
@@ -208,6 +213,8 @@ func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string,
 			Secure:           secure,
 			Kind:             kind,
 			Reachable:        reachable,
+			Probe:            probe,
+			LatencyMillis:    latencyMillis,
 			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
 		},
 	}
@@ -227,7 +234,17 @@ func (c *ConsoleMachineEventLoggingClient) KubernetesPodStatus(pods []Kubernetes
 	c.outputJSON(json)
 }
 
+// SupervisordCrashLoop outputs the provided event as JSON to the console.
+func (c *ConsoleMachineEventLoggingClient) SupervisordCrashLoop(crashLoop SupervisordCrashLoop, timestamp string) {
+	crashLoop.AbstractLogEvent = AbstractLogEvent{Timestamp: timestamp}
+	json := MachineEventWrapper{
+		SupervisordCrashLoop: &crashLoop,
+	}
+	c.outputJSON(json)
+}
+
 func (c *ConsoleMachineEventLoggingClient) outputJSON(machineOutput MachineEventWrapper) {
+	machineOutput.SchemaVersion = SchemaVersion
 
 	if c.logFunc != nil {
 		c.logFunc(machineOutput)
@@ -265,6 +282,9 @@ func (w MachineEventWrapper) GetEntry() (MachineEventLogEntry, error) {
 	} else if w.URLReachable != nil {
 		return w.URLReachable, nil
 
+	} else if w.SupervisordCrashLoop != nil {
+		return w.SupervisordCrashLoop, nil
+
 	} else {
 		return nil, errors.New("unexpected machine event log entry")
 	}
@@ -302,6 +322,9 @@ func (c URLReachable) GetType() MachineEventLogEntryType { return TypeURLReachab
 // GetType returns the event type for this event.
 func (c KubernetesPodStatus) GetType() MachineEventLogEntryType { return TypeKubernetesPodStatus }
 
+// GetType returns the event type for this event.
+func (c SupervisordCrashLoop) GetType() MachineEventLogEntryType { return TypeSupervisordCrashLoop }
+
 // MachineEventLogEntryType indicates the machine-readable event type from an ODO operation
 type MachineEventLogEntryType int
 
@@ -322,6 +345,8 @@ const (
 	TypeURLReachable MachineEventLogEntryType = 6
 	// TypeKubernetesPodStatus is the entry type for that event.
 	TypeKubernetesPodStatus MachineEventLogEntryType = 7
+	// TypeSupervisordCrashLoop is the entry type for that event.
+	TypeSupervisordCrashLoop MachineEventLogEntryType = 8
 )
 
 // GetCommandName returns a command if the MLE supports that field (otherwise empty string is returned).
@@ -339,7 +364,7 @@ func GetCommandName(entry MachineEventLogEntry) string {
 }
 
 // createWriterAndChannel is similar to the exec.CreateConsoleOutputWriterAndChannel(); see that function's comment for details.
-func createWriterAndChannel(stderr bool) (*io.PipeWriter, chan interface{}) {
+func (c *ConsoleMachineEventLoggingClient) createWriterAndChannel(stderr bool) (*io.PipeWriter, chan interface{}) {
 	reader, writer := io.Pipe()
 
 	closeChannel := make(chan interface{})
@@ -369,7 +394,7 @@ func createWriterAndChannel(stderr bool) (*io.PipeWriter, chan interface{}) {
 					Stream:           stream,
 				},
 			}
-			OutputSuccessUnindented(json)
+			c.outputJSON(json)
 		}
 
 		// Output a single nil event on the channel to inform that the last line of text has been
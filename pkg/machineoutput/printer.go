@@ -0,0 +1,221 @@
+package machineoutput
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// JSONFormat is the output format printing the objects as indented JSON
+	JSONFormat = "json"
+	// YAMLFormat is the output format printing the objects as YAML
+	YAMLFormat = "yaml"
+	// NameFormat is the output format printing the <kind>/<name> of the objects, one per line
+	NameFormat = "name"
+	// JSONPathFormat is the prefix of the output format printing the result of a JSONPath template on the objects
+	JSONPathFormat = "jsonpath"
+	// GoTemplateFormat is the prefix of the output format printing the result of a Go template on the objects
+	GoTemplateFormat = "go-template"
+)
+
+// OutputFormats is the description of the supported output formats, as displayed to the user
+const OutputFormats = "json, yaml, name, jsonpath=<template>, go-template=<template>"
+
+// Printer prints the machine readable output of a command
+type Printer interface {
+	// PrintObj prints the object to the writer
+	PrintObj(obj interface{}, w io.Writer) error
+}
+
+// PrinterFunc is a function which implements the Printer interface
+type PrinterFunc func(obj interface{}, w io.Writer) error
+
+// PrintObj implements Printer
+func (fn PrinterFunc) PrintObj(obj interface{}, w io.Writer) error {
+	return fn(obj, w)
+}
+
+// GetOutputFormat returns the output format requested with the -o flag, empty if no machine readable output is requested
+func GetOutputFormat() string {
+	flag := pflag.Lookup("o")
+	if flag == nil || !flag.Changed {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// NewPrinter returns the printer of the given output format, the templates of the jsonpath and go-template
+// formats being given after an '=' sign, e.g. jsonpath={.items[*].metadata.name}
+func NewPrinter(format string) (Printer, error) {
+	name, tmpl := format, ""
+	if i := strings.Index(format, "="); i >= 0 {
+		name, tmpl = format[:i], format[i+1:]
+	}
+
+	switch name {
+	case JSONFormat:
+		return PrinterFunc(printJSON), nil
+	case YAMLFormat:
+		return PrinterFunc(printYAML), nil
+	case NameFormat:
+		return PrinterFunc(printNames), nil
+	case JSONPathFormat:
+		return newJSONPathPrinter(tmpl)
+	case GoTemplateFormat:
+		return newGoTemplatePrinter(tmpl)
+	}
+	return nil, fmt.Errorf("unsupported output format %q, available formats: %s", format, OutputFormats)
+}
+
+// IsTemplateFormat returns true if the output format prints selected fields of the objects, rather than the objects
+func IsTemplateFormat(format string) bool {
+	return format == NameFormat || strings.HasPrefix(format, JSONPathFormat+"=") || strings.HasPrefix(format, GoTemplateFormat+"=")
+}
+
+func printJSON(obj interface{}, w io.Writer) error {
+	data, err := marshalJSONIndented(obj)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", string(data))
+	return err
+}
+
+func printYAML(obj interface{}, w io.Writer) error {
+	data, err := marshalVersioned(obj)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// printNames prints the <kind>/<name> of the object, or of the objects of its lists
+func printNames(obj interface{}, w io.Writer) error {
+	generic, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	var names []string
+	collectNames(generic, &names)
+	for _, name := range names {
+		if _, err = fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectNames appends the <kind>/<name> of the named objects found in the generic value to names
+func collectNames(value interface{}, names *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if name := getObjectName(v); name != "" {
+			if kind, ok := v["kind"].(string); ok && kind != "" && kind != "List" {
+				name = strings.ToLower(kind) + "/" + name
+			}
+			*names = append(*names, name)
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := v[key].([]interface{}); ok {
+				collectNames(v[key], names)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectNames(item, names)
+		}
+	}
+}
+
+// getObjectName returns the metadata.name of the object, or its name field when it has no metadata
+func getObjectName(obj map[string]interface{}) string {
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		name, _ := metadata["name"].(string)
+		return name
+	}
+	for _, key := range []string{"name", "Name"} {
+		if name, ok := obj[key].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func newJSONPathPrinter(tmpl string) (Printer, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("the jsonpath template is missing, use jsonpath=<template>")
+	}
+	parser := jsonpath.New("output")
+	if err := parser.Parse(tmpl); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the jsonpath template %q", tmpl)
+	}
+	return PrinterFunc(func(obj interface{}, w io.Writer) error {
+		generic, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		if err = parser.Execute(w, generic); err != nil {
+			return errors.Wrapf(err, "unable to execute the jsonpath template %q", tmpl)
+		}
+		_, err = fmt.Fprintln(w)
+		return err
+	}), nil
+}
+
+func newGoTemplatePrinter(tmpl string) (Printer, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("the go-template template is missing, use go-template=<template>")
+	}
+	t, err := template.New("output").Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the go-template template %q", tmpl)
+	}
+	return PrinterFunc(func(obj interface{}, w io.Writer) error {
+		generic, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		// the template is executed in a buffer, so that nothing is printed when it fails
+		var buf bytes.Buffer
+		if err = t.Execute(&buf, generic); err != nil {
+			return errors.Wrapf(err, "unable to execute the go-template template %q", tmpl)
+		}
+		_, err = buf.WriteTo(w)
+		return err
+	}), nil
+}
+
+// toGeneric converts the object to its generic JSON representation, so that the templates refer to the fields
+// by their JSON names, as in the JSON output
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := marshalVersioned(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
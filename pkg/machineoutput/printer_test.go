@@ -0,0 +1,114 @@
+package machineoutput
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeItem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Port              int `json:"port"`
+}
+
+type fakeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []fakeItem `json:"items"`
+}
+
+func TestNewPrinter(t *testing.T) {
+	list := fakeList{
+		TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: APIVersion},
+		Items: []fakeItem{
+			{TypeMeta: metav1.TypeMeta{Kind: "url", APIVersion: APIVersion}, ObjectMeta: metav1.ObjectMeta{Name: "http"}, Port: 8080},
+			{TypeMeta: metav1.TypeMeta{Kind: "url", APIVersion: APIVersion}, ObjectMeta: metav1.ObjectMeta{Name: "debug"}, Port: 5858},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Case 1: YAML",
+			format: "yaml",
+			want: `apiVersion: odo.dev/v1alpha1
+items:
+- apiVersion: odo.dev/v1alpha1
+  kind: url
+  metadata:
+    creationTimestamp: null
+    name: http
+  port: 8080
+- apiVersion: odo.dev/v1alpha1
+  kind: url
+  metadata:
+    creationTimestamp: null
+    name: debug
+  port: 5858
+kind: List
+metadata: {}
+schemaVersion: 1.0.0
+`,
+		},
+		{
+			name:   "Case 2: Names",
+			format: "name",
+			want:   "url/http\nurl/debug\n",
+		},
+		{
+			name:   "Case 3: JSONPath",
+			format: "jsonpath={.items[*].port}",
+			want:   "8080 5858\n",
+		},
+		{
+			name:   "Case 4: Go template",
+			format: `go-template={{range .items}}{{.metadata.name}}={{.port}};{{end}}`,
+			want:   "http=8080;debug=5858;",
+		},
+		{
+			name:    "Case 5: Unsupported format",
+			format:  "wide",
+			wantErr: true,
+		},
+		{
+			name:    "Case 6: Missing template",
+			format:  "jsonpath",
+			wantErr: true,
+		},
+		{
+			name:    "Case 7: Invalid template",
+			format:  "go-template={{.items",
+			wantErr: true,
+		},
+		{
+			name:   "Case 8: Schema version of the document",
+			format: "jsonpath={.schemaVersion}",
+			want:   SchemaVersion + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer, err := NewPrinter(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPrinter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var out bytes.Buffer
+			if err = printer.PrintObj(list, &out); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got output %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
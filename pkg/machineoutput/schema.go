@@ -0,0 +1,331 @@
+package machineoutput
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SchemaVersion is the version of the schemas of the machine readable documents and events; its major version
+// must be increased when a schema changes incompatibly, for example when a field is removed or changes type
+const SchemaVersion = "1.0.0"
+
+// SchemaVersionField is the field carrying the SchemaVersion in the machine readable documents and events
+const SchemaVersionField = "schemaVersion"
+
+// jsonSchemaDraft is the JSON Schema draft of the generated schemas
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is a JSON Schema, restricted to the keywords used for the schemas generated from the Go types
+type JSONSchema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Version is the SchemaVersion of the document described by the schema
+	Version string `json:"version,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+	// Type is the JSON type, or the list of the JSON types, of the value
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	metaTimeType  = reflect.TypeOf(metav1.Time{})
+	quantityType  = reflect.TypeOf(resource.Quantity{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// GenerateSchema generates the JSON Schema of the JSON representation of the Go value, the named structures
+// being described in the definitions of the schema
+func GenerateSchema(title string, obj interface{}) *JSONSchema {
+	g := schemaGenerator{definitions: map[string]*JSONSchema{}}
+	schema := g.typeSchema(reflect.TypeOf(obj))
+	// the document itself is described at the root of the schema
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/definitions/")
+		schema = g.definitions[name]
+		delete(g.definitions, name)
+	}
+	schema.Schema = jsonSchemaDraft
+	schema.Title = title
+	schema.Version = SchemaVersion
+	if len(g.definitions) > 0 {
+		schema.Definitions = g.definitions
+	}
+	return schema
+}
+
+// GenerateDocumentSchema generates the JSON Schema of a machine readable document, which carries the SchemaVersion
+func GenerateDocumentSchema(title string, obj interface{}) *JSONSchema {
+	schema := GenerateSchema(title, obj)
+	if schema.Properties != nil {
+		schema.Properties[SchemaVersionField] = &JSONSchema{Type: "string"}
+		schema.Required = append([]string{SchemaVersionField}, schema.Required...)
+	}
+	return schema
+}
+
+type schemaGenerator struct {
+	definitions map[string]*JSONSchema
+}
+
+// versionedPackage matches the names of the versioned API packages, e.g. v1 or v1alpha2
+var versionedPackage = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// definitionName returns the name of the definition of a named type, e.g. url.URL or meta.v1.ObjectMeta; the
+// versioned API packages are prefixed by their group, as core.v1 and meta.v1 share the v1 name
+func definitionName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	if versionedPackage.MatchString(pkg) {
+		pkg = path.Base(path.Dir(t.PkgPath())) + "." + pkg
+	}
+	return pkg + "." + t.Name()
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	switch t {
+	case timeType, metaTimeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	case quantityType:
+		return &JSONSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string
+			return nullable(&JSONSchema{Type: "string"})
+		}
+		return nullable(&JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())})
+	case reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return nullable(&JSONSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())})
+	case reflect.Struct:
+		// the JSON representation of the types with a custom encoding is unknown
+		if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+			return &JSONSchema{}
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := definitionName(t)
+		if _, ok := g.definitions[name]; !ok {
+			// the definition is registered before being generated, for the recursive types
+			g.definitions[name] = &JSONSchema{}
+			*g.definitions[name] = *g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/definitions/" + name}
+	}
+	// interfaces can hold any value
+	return &JSONSchema{}
+}
+
+// structSchema returns the schema of the fields of the structure, following the encoding/json rules
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, options = tag[:i], tag[i+1:]
+		}
+
+		// the fields of the embedded structures are inlined, unless they have a JSON name
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inlined := g.structSchema(embedded)
+				for property, propertySchema := range inlined.Properties {
+					schema.Properties[property] = propertySchema
+				}
+				schema.Required = append(schema.Required, inlined.Required...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.typeSchema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// nullable returns a schema accepting the values of the given schema and null, as nil pointers, slices and maps
+// are encoded as null
+func nullable(schema *JSONSchema) *JSONSchema {
+	if schema.Ref != "" {
+		return &JSONSchema{AnyOf: []*JSONSchema{schema, {Type: "null"}}}
+	}
+	if jsonType, ok := schema.Type.(string); ok {
+		schema.Type = []string{jsonType, "null"}
+	}
+	return schema
+}
+
+// CheckSchemaCompatibility returns the changes of the new schema which are incompatible with the old one, for
+// the clients reading the documents: the removed properties, the properties which are not always present anymore
+// and the properties whose type changed
+func CheckSchemaCompatibility(old, new *JSONSchema) []string {
+	c := compatibilityChecker{old: old, new: new, checked: map[string]bool{}}
+	c.check("", old, new)
+	sort.Strings(c.incompatibilities)
+	return c.incompatibilities
+}
+
+type compatibilityChecker struct {
+	old, new          *JSONSchema
+	checked           map[string]bool
+	incompatibilities []string
+}
+
+// resolve returns the definition referenced by the schema, and the name of the definition
+func resolve(root, schema *JSONSchema) (*JSONSchema, string) {
+	if schema == nil || schema.Ref == "" {
+		return schema, ""
+	}
+	name := strings.TrimPrefix(schema.Ref, "#/definitions/")
+	if definition, ok := root.Definitions[name]; ok {
+		return definition, name
+	}
+	return &JSONSchema{}, name
+}
+
+// types returns the JSON types accepted by the schema, empty if any type is accepted
+func types(root, schema *JSONSchema) []string {
+	schema, _ = resolve(root, schema)
+	var result []string
+	switch t := schema.Type.(type) {
+	case string:
+		result = append(result, t)
+	case []string:
+		result = append(result, t...)
+	case []interface{}:
+		for _, item := range t {
+			result = append(result, fmt.Sprint(item))
+		}
+	}
+	for _, alternative := range schema.AnyOf {
+		alternativeTypes := types(root, alternative)
+		if len(alternativeTypes) == 0 {
+			return nil
+		}
+		result = append(result, alternativeTypes...)
+	}
+	return result
+}
+
+func (c *compatibilityChecker) report(location string, format string, args ...interface{}) {
+	if location == "" {
+		location = "(root)"
+	}
+	c.incompatibilities = append(c.incompatibilities, location+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *compatibilityChecker) check(location string, old, new *JSONSchema) {
+	oldResolved, oldName := resolve(c.old, old)
+	newResolved, _ := resolve(c.new, new)
+	// an alternative of the nullable values is the actual schema
+	for _, alternative := range oldResolved.AnyOf {
+		if len(alternative.AnyOf) == 0 && alternative.Ref != "" {
+			oldResolved, oldName = resolve(c.old, alternative)
+		}
+	}
+	for _, alternative := range newResolved.AnyOf {
+		if len(alternative.AnyOf) == 0 && alternative.Ref != "" {
+			newResolved, _ = resolve(c.new, alternative)
+		}
+	}
+	if oldName != "" {
+		// the definitions are checked once, as they are reused and can be recursive
+		if c.checked[oldName+location] || c.checked[oldName] {
+			return
+		}
+		c.checked[oldName] = true
+	}
+
+	oldTypes, newTypes := types(c.old, old), types(c.new, new)
+	if len(newTypes) > 0 {
+		accepted := map[string]bool{}
+		for _, t := range newTypes {
+			accepted[t] = true
+		}
+		if len(oldTypes) == 0 {
+			c.report(location, "the type changed from any type to %v", newTypes)
+		}
+		for _, t := range oldTypes {
+			if !accepted[t] {
+				c.report(location, "the type changed from %v to %v", oldTypes, newTypes)
+				break
+			}
+		}
+	}
+
+	required := map[string]bool{}
+	for _, property := range newResolved.Required {
+		required[property] = true
+	}
+	for _, property := range oldResolved.Required {
+		if !required[property] {
+			if _, ok := newResolved.Properties[property]; ok {
+				c.report(location+"/"+property, "the property is not always present anymore")
+			}
+		}
+	}
+	for property, oldProperty := range oldResolved.Properties {
+		newProperty, ok := newResolved.Properties[property]
+		if !ok {
+			c.report(location+"/"+property, "the property was removed")
+			continue
+		}
+		c.check(location+"/"+property, oldProperty, newProperty)
+	}
+	if oldResolved.Items != nil && newResolved.Items != nil {
+		c.check(location+"/items", oldResolved.Items, newResolved.Items)
+	}
+	if oldResolved.AdditionalProperties != nil && newResolved.AdditionalProperties != nil {
+		c.check(location+"/additionalProperties", oldResolved.AdditionalProperties, newResolved.AdditionalProperties)
+	}
+}
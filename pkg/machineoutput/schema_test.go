@@ -0,0 +1,106 @@
+package machineoutput
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fakeSchemaNode struct {
+	Name     string            `json:"name"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Parent   *fakeSchemaNode   `json:"parent,omitempty"`
+	Children []fakeSchemaNode  `json:"children"`
+	Ignored  string            `json:"-"`
+}
+
+type fakeSchemaDocument struct {
+	fakeItem `json:",inline"`
+	Nodes    []fakeSchemaNode `json:"nodes"`
+}
+
+func TestGenerateSchema(t *testing.T) {
+	schema := GenerateDocumentSchema("fake", fakeSchemaDocument{})
+
+	if schema.Version != SchemaVersion || schema.Title != "fake" {
+		t.Errorf("unexpected title %q and version %q", schema.Title, schema.Version)
+	}
+	wantRequired := []string{SchemaVersionField, "nodes", "port"}
+	if !reflect.DeepEqual(schema.Required, wantRequired) {
+		t.Errorf("got the required properties %v, want %v", schema.Required, wantRequired)
+	}
+	for _, property := range []string{"apiVersion", "kind", "metadata", "port", "nodes", SchemaVersionField} {
+		if _, ok := schema.Properties[property]; !ok {
+			t.Errorf("the property %q is missing", property)
+		}
+	}
+
+	node, ok := schema.Definitions["machineoutput.fakeSchemaNode"]
+	if !ok {
+		t.Fatalf("the definition of fakeSchemaNode is missing from %v", schema.Definitions)
+	}
+	if len(node.Properties) != 4 {
+		t.Errorf("got the properties %v, want name, labels, parent and children", node.Properties)
+	}
+	if node.Properties["parent"].AnyOf[0].Ref != "#/definitions/machineoutput.fakeSchemaNode" {
+		t.Errorf("the recursive reference is %+v", node.Properties["parent"])
+	}
+	if !reflect.DeepEqual(node.Properties["labels"].Type, []string{"object", "null"}) {
+		t.Errorf("the type of the map is %v", node.Properties["labels"].Type)
+	}
+}
+
+func TestCheckSchemaCompatibility(t *testing.T) {
+	type oldType struct {
+		Name  string `json:"name"`
+		Port  int    `json:"port"`
+		State string `json:"state"`
+	}
+
+	tests := []struct {
+		name string
+		new  interface{}
+		want []string
+	}{
+		{
+			name: "Case 1: Unchanged structure",
+			new:  oldType{},
+		},
+		{
+			name: "Case 2: Added property",
+			new: struct {
+				Name  string `json:"name"`
+				Port  int    `json:"port"`
+				State string `json:"state"`
+				Path  string `json:"path,omitempty"`
+			}{},
+		},
+		{
+			name: "Case 3: Removed property",
+			new: struct {
+				Name string `json:"name"`
+				Port int    `json:"port"`
+			}{},
+			want: []string{"/state: the property was removed"},
+		},
+		{
+			name: "Case 4: Changed type and optional property",
+			new: struct {
+				Name  string `json:"name"`
+				Port  string `json:"port"`
+				State string `json:"state,omitempty"`
+			}{},
+			want: []string{
+				"/port: the type changed from [integer] to [string]",
+				"/state: the property is not always present anymore",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckSchemaCompatibility(GenerateSchema("old", oldType{}), GenerateSchema("new", tt.new))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got the incompatibilities %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
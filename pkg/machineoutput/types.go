@@ -1,6 +1,7 @@
 package machineoutput
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -50,31 +51,80 @@ func OutputSuccessUnindented(machineOutput interface{}) {
 	}
 }
 
-// OutputSuccess outputs a "successful" machine-readable output format in json
+// OutputSuccess outputs a "successful" machine-readable output in the format requested with -o, json by default
 func OutputSuccess(machineOutput interface{}) {
-	printableOutput, err := marshalJSONIndented(machineOutput)
+	printer, err := getPrinter()
+	if err == nil {
+		err = printer.PrintObj(machineOutput, log.GetStdout())
+	}
 
 	// If we error out... there's no way to output it (since we disable logging when using -o json)
 	if err != nil {
-		fmt.Fprintf(log.GetStderr(), "Unable to unmarshal JSON: %s\n", err.Error())
-	} else {
-		fmt.Fprintf(log.GetStdout(), "%s\n", string(printableOutput))
+		fmt.Fprintf(log.GetStderr(), "Unable to print the output: %s\n", err.Error())
 	}
 }
 
-// OutputError outputs a "successful" machine-readable output format in json
+// OutputError outputs an "error" machine-readable output; the error is printed as JSON or YAML, or only its
+// message is printed when the output format is a template or the names of the objects
 func OutputError(machineOutput interface{}) {
-	printableOutput, err := marshalJSONIndented(machineOutput)
+	format := GetOutputFormat()
+	if genericError, ok := machineOutput.(GenericError); ok && IsTemplateFormat(format) {
+		fmt.Fprintf(log.GetStderr(), "%s\n", genericError.Message)
+		return
+	}
+
+	printer := Printer(PrinterFunc(printJSON))
+	if format == YAMLFormat {
+		printer = PrinterFunc(printYAML)
+	}
 
 	// If we error out... there's no way to output it (since we disable logging when using -o json)
-	if err != nil {
-		fmt.Fprintf(log.GetStderr(), "Unable to unmarshal JSON: %s\n", err.Error())
-	} else {
-		fmt.Fprintf(log.GetStderr(), "%s\n", string(printableOutput))
+	if err := printer.PrintObj(machineOutput, log.GetStderr()); err != nil {
+		fmt.Fprintf(log.GetStderr(), "Unable to print the output: %s\n", err.Error())
+	}
+}
+
+// getPrinter returns the printer of the output format requested with -o, json by default
+func getPrinter() (Printer, error) {
+	format := GetOutputFormat()
+	if format == "" {
+		format = JSONFormat
 	}
+	return NewPrinter(format)
 }
 
-// marshalJSONIndented returns indented json representation of obj
+// marshalJSONIndented returns indented json representation of obj, carrying the SchemaVersion
 func marshalJSONIndented(obj interface{}) ([]byte, error) {
-	return json.MarshalIndent(obj, "", "	")
+	data, err := marshalVersioned(obj)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, data, "", "	"); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalDocument returns the json representation of the machine readable document carrying the SchemaVersion, as the
+// documents printed with -o json, for the documents which are not printed on the standard output
+func MarshalDocument(obj interface{}) ([]byte, error) {
+	return marshalVersioned(obj)
+}
+
+// marshalVersioned returns the json representation of obj; the SchemaVersion is added as the first field of the
+// objects, the lists of objects are left as is
+func marshalVersioned(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	version := fmt.Sprintf("{%q:%q", SchemaVersionField, SchemaVersion)
+	if len(data) < 2 || data[0] != '{' || bytes.HasPrefix(data, []byte(version)) {
+		return data, nil
+	}
+	if len(data) > 2 {
+		version += ","
+	}
+	return append([]byte(version), data[1:]...), nil
 }
@@ -19,10 +19,12 @@ type MachineEventLoggingClient interface {
 	SupervisordStatus(statuses []SupervisordStatusEntry, timestamp string)
 	ContainerStatus(statuses []ContainerStatusEntry, timestamp string)
 
-	URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string)
+	URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe string, latencyMillis int64, timestamp string)
 
 	KubernetesPodStatus(pods []KubernetesPodStatusEntry, timestamp string)
 
+	SupervisordCrashLoop(crashLoop SupervisordCrashLoop, timestamp string)
+
 	// CreateContainerOutputWriter is used to capture output from container processes, and synchronously write it to the screen as LogText. See implementation comments for details.
 	CreateContainerOutputWriter() (*io.PipeWriter, chan interface{}, *io.PipeWriter, chan interface{})
 }
@@ -30,6 +32,9 @@ type MachineEventLoggingClient interface {
 // MachineEventWrapper - a single line of machine-readable event console output must contain only one
 // of these commands; the MachineEventWrapper is used to create (and parse, for tests) these lines.
 type MachineEventWrapper struct {
+	// SchemaVersion is the version of the schema of the events, set when the event is written
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+
 	DevFileCommandExecutionBegin    *DevFileCommandExecutionBegin    `json:"devFileCommandExecutionBegin,omitempty"`
 	DevFileCommandExecutionComplete *DevFileCommandExecutionComplete `json:"devFileCommandExecutionComplete,omitempty"`
 	LogText                         *LogText                         `json:"logText,omitempty"`
@@ -38,6 +43,7 @@ type MachineEventWrapper struct {
 	ContainerStatus                 *ContainerStatus                 `json:"containerStatus,omitempty"`
 	URLReachable                    *URLReachable                    `json:"urlReachable,omitempty"`
 	KubernetesPodStatus             *KubernetesPodStatus             `json:"kubernetesPodStatus,omitempty"`
+	SupervisordCrashLoop            *SupervisordCrashLoop            `json:"supervisordCrashLoop,omitempty"`
 }
 
 // DevFileCommandExecutionBegin is the JSON event that is emitted when a dev file command begins execution.
@@ -88,18 +94,35 @@ type SupervisordStatus struct {
 
 // SupervisordStatusEntry is an individual program's status
 type SupervisordStatusEntry struct {
-	Program string `json:"program"`
-	Status  string `json:"status"`
+	Program  string `json:"program"`
+	Status   string `json:"status"`
+	Restarts int    `json:"restarts,omitempty"`
+	ExitCode *int   `json:"exitCode,omitempty"`
+	// CrashLoop indicates that supervisord keeps restarting the program
+	CrashLoop bool `json:"crashLoop,omitempty"`
+}
+
+// SupervisordCrashLoop is the JSON event that is emitted when a supervisord program of an odo-managed component
+// keeps crashing and being restarted
+type SupervisordCrashLoop struct {
+	Container string `json:"container"`
+	Program   string `json:"program"`
+	Status    string `json:"status"`
+	Restarts  int    `json:"restarts"`
+	ExitCode  *int   `json:"exitCode,omitempty"`
+	AbstractLogEvent
 }
 
 // URLReachable is the JSON event that is emitted to indicate whether one of the component's URL's could be reached.
 type URLReachable struct {
-	Name      string `json:"name"`
-	URL       string `json:"url"`
-	Port      int    `json:"port"`
-	Secure    bool   `json:"secure"`
-	Kind      string `json:"kind"`
-	Reachable bool   `json:"reachable"`
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	Port          int    `json:"port"`
+	Secure        bool   `json:"secure"`
+	Kind          string `json:"kind"`
+	Reachable     bool   `json:"reachable"`
+	Probe         string `json:"probe,omitempty"`
+	LatencyMillis int64  `json:"latencyMs,omitempty"`
 	AbstractLogEvent
 }
 
@@ -138,6 +161,7 @@ var _ MachineEventLogEntry = &SupervisordStatus{}
 var _ MachineEventLogEntry = &ContainerStatus{}
 var _ MachineEventLogEntry = &URLReachable{}
 var _ MachineEventLogEntry = &KubernetesPodStatus{}
+var _ MachineEventLogEntry = &SupervisordCrashLoop{}
 
 // MachineEventLogEntry contains the expected methods for every event that is emitted.
 // (This is mainly used for test purposes.)
@@ -23,21 +23,25 @@ const RecommendedCommandName = "app"
 func NewCmdApplication(name, fullName string) *cobra.Command {
 	delete := NewCmdDelete(deleteRecommendedCommandName, odoutil.GetFullName(fullName, deleteRecommendedCommandName))
 	describe := NewCmdDescribe(describeRecommendedCommandName, odoutil.GetFullName(fullName, describeRecommendedCommandName))
+	export := NewCmdExport(exportRecommendedCommandName, odoutil.GetFullName(fullName, exportRecommendedCommandName))
+	importCmd := NewCmdImport(importRecommendedCommandName, odoutil.GetFullName(fullName, importRecommendedCommandName))
 	list := NewCmdList(listRecommendedCommandName, odoutil.GetFullName(fullName, listRecommendedCommandName))
 	applicationCmd := &cobra.Command{
 		Use:   name,
 		Short: "Perform application operations",
 		Long:  `Performs application operations related to your project.`,
-		Example: fmt.Sprintf("%s\n\n%s\n\n%s",
+		Example: fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s",
 			delete.Example,
 			describe.Example,
+			export.Example,
+			importCmd.Example,
 			list.Example),
 		Aliases: []string{"application"},
 		Run: func(cmd *cobra.Command, args []string) {
 		},
 	}
 
-	applicationCmd.AddCommand(delete, describe, list)
+	applicationCmd.AddCommand(delete, describe, export, importCmd, list)
 
 	// Add a defined annotation in order to appear in the help menu
 	applicationCmd.Annotations = map[string]string{"command": "main"}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	odoUtil "github.com/openshift/odo/pkg/odo/util"
@@ -13,7 +14,9 @@ import (
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/odo/util/completion"
 	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/workspace"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 )
 
@@ -21,13 +24,20 @@ const deleteRecommendedCommandName = "delete"
 
 var (
 	deleteExample = ktemplates.Examples(`  # Delete the application
-  %[1]s myapp`)
+  %[1]s myapp
+
+  # Delete all the components of the workspace of the current directory
+  %[1]s --workspace`)
 )
 
 // DeleteOptions encapsulates the options for the odo command
 type DeleteOptions struct {
 	appName string
 	force   bool
+
+	// workspace deletes the components of the workspace instead of an application
+	workspace           bool
+	workspaceComponents []workspace.Component
 	*genericclioptions.Context
 }
 
@@ -38,6 +48,18 @@ func NewDeleteOptions() *DeleteOptions {
 
 // Complete completes DeleteOptions after they've been created
 func (o *DeleteOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if o.workspace {
+		if len(args) != 0 {
+			return fmt.Errorf("an application name can't be given with --workspace")
+		}
+		wi, err := workspace.Find(".")
+		if err != nil {
+			return err
+		}
+		o.workspaceComponents, err = wi.GetComponents()
+		return err
+	}
+
 	if util.CheckPathExists(filepath.Join(".odo", "config.yaml")) {
 		o.Context, err = genericclioptions.NewContext(cmd)
 	} else {
@@ -57,6 +79,12 @@ func (o *DeleteOptions) Complete(name string, cmd *cobra.Command, args []string)
 
 // Validate validates the DeleteOptions based on completed values
 func (o *DeleteOptions) Validate() (err error) {
+	if o.workspace {
+		if log.IsJSON() {
+			return fmt.Errorf("the machine readable output is not supported with --workspace")
+		}
+		return nil
+	}
 	if o.Context.Project == "" || o.appName == "" {
 		return odoUtil.ThrowContextError()
 	}
@@ -73,6 +101,10 @@ func (o *DeleteOptions) Validate() (err error) {
 
 // Run contains the logic for the odo command
 func (o *DeleteOptions) Run(cmd *cobra.Command) (err error) {
+	if o.workspace {
+		return o.deleteWorkspace(cmd)
+	}
+
 	if log.IsJSON() {
 		err = application.Delete(o.Client, o.appName)
 		if err != nil {
@@ -99,6 +131,30 @@ func (o *DeleteOptions) Run(cmd *cobra.Command) (err error) {
 	return
 }
 
+// deleteWorkspace deletes the components of the workspace, each component after the components depending on it
+func (o *DeleteOptions) deleteWorkspace(cmd *cobra.Command) error {
+	log.Info("The workspace has following components that will be deleted")
+	for _, component := range o.workspaceComponents {
+		log.Infof("component named %s in application %s of project %s", component.Name, component.App, component.Project)
+	}
+	if !o.force && !ui.Proceed("Are you sure you want to delete the components of the workspace") {
+		log.Info("Aborting deletion of the components of the workspace")
+		return nil
+	}
+	err := workspace.RunOrdered(o.workspaceComponents, true, os.Stdout, workspace.Command(workspaceDeleteArgs(cmd.Flags())...))
+	if err != nil {
+		return err
+	}
+	log.Info("Deleted the components of the workspace")
+	return nil
+}
+
+// workspaceDeleteArgs returns the arguments of the command deleting each component of the workspace, with the flags
+// selecting the components, such as the environment and the project, as the components listed for the confirmation
+func workspaceDeleteArgs(flags *pflag.FlagSet) []string {
+	return append([]string{"delete", "--force"}, workspace.ForwardedFlags(flags, "workspace", "force")...)
+}
+
 // NewCmdDelete implements the odo command.
 func NewCmdDelete(name, fullName string) *cobra.Command {
 	o := NewDeleteOptions()
@@ -114,6 +170,8 @@ func NewCmdDelete(name, fullName string) *cobra.Command {
 	}
 
 	command.Flags().BoolVarP(&o.force, "force", "f", false, "Delete application without prompting")
+	command.Flags().BoolVar(&o.workspace, "workspace", false, "Delete all the components of the workspace of the current directory instead of an application")
+	command.Flags().Bool(genericclioptions.IgnoreClusterBindingFlagName, false, "With --workspace, delete the components even if the current kube context points to another cluster than the one they are bound to")
 
 	project.AddProjectFlag(command)
 	completion.RegisterCommandHandler(command, completion.AppCompletionHandler)
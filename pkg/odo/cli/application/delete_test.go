@@ -0,0 +1,26 @@
+package application
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+)
+
+func TestWorkspaceDeleteArgs(t *testing.T) {
+	cmd := NewCmdDelete(deleteRecommendedCommandName, "odo app delete")
+	envinfo.AddEnvironmentFlag(cmd.Flags())
+	args := []string{"--workspace", "--force", "--env", "team", "--project", "myproject", "--" + genericclioptions.IgnoreClusterBindingFlagName}
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+
+	got := workspaceDeleteArgs(cmd.Flags())
+	sort.Strings(got[2:])
+	want := []string{"delete", "--force", "--environment=team", "--" + genericclioptions.IgnoreClusterBindingFlagName + "=true", "--project=myproject"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got the arguments %v, want %v", got, want)
+	}
+}
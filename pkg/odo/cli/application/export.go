@@ -0,0 +1,220 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/application"
+	applabels "github.com/openshift/odo/pkg/application/labels"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/project"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	odoutil "github.com/openshift/odo/pkg/odo/util"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/service"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const exportRecommendedCommandName = "export"
+
+var (
+	exportLongDesc = ktemplates.LongDesc(`
+	Export the application to a bundle directory.
+
+	The bundle contains the devfile and the environment settings, with the URLs, storage and links, of each component
+	of the application found in the workspace of the current directory, and the manifests of the operator backed
+	services of the application which are not defined in the devfiles. It can be imported in another project or
+	cluster with 'odo app import'.`)
+
+	exportExample = ktemplates.Examples(`  # Export the application to the myapp-bundle directory
+  %[1]s myapp
+
+  # Export the application to the given directory
+  %[1]s myapp --dir /tmp/myapp`)
+)
+
+// ExportOptions encapsulates the options for the odo command
+type ExportOptions struct {
+	appName string
+	dir     string
+
+	root       string
+	components []workspace.Component
+	services   []unstructured.Unstructured
+	*genericclioptions.Context
+}
+
+// NewExportOptions creates a new ExportOptions instance
+func NewExportOptions() *ExportOptions {
+	return &ExportOptions{}
+}
+
+// Complete completes ExportOptions after they've been created
+func (o *ExportOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if util.CheckPathExists(filepath.Join(".odo", "config.yaml")) {
+		o.Context, err = genericclioptions.NewContext(cmd)
+	} else {
+		o.Context, err = genericclioptions.NewDevfileContext(cmd)
+	}
+	if err != nil {
+		return err
+	}
+	o.appName = o.Application
+	if len(args) == 1 {
+		o.appName = args[0]
+	}
+	if o.dir == "" {
+		o.dir = o.appName + "-bundle"
+	}
+	return
+}
+
+// Validate validates the ExportOptions based on completed values
+func (o *ExportOptions) Validate() (err error) {
+	if o.Context.Project == "" || o.appName == "" {
+		return odoutil.ThrowContextError()
+	}
+	exist, err := application.Exists(o.appName, o.Client, o.KClient)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("%s app does not exists", o.appName)
+	}
+	if util.CheckPathExists(filepath.Join(o.dir, application.BundleFileName)) {
+		return fmt.Errorf("%s already contains a bundle", o.dir)
+	}
+
+	o.root, o.components, err = o.localComponents()
+	if err != nil {
+		return err
+	}
+	if len(o.components) == 0 {
+		return fmt.Errorf("no component of the application %s was found in the workspace of the current directory", o.appName)
+	}
+	return nil
+}
+
+// localComponents returns the root of the workspace of the current directory and its components of the application,
+// or the component of the current directory when it is not in a workspace
+func (o *ExportOptions) localComponents() (string, []workspace.Component, error) {
+	wi, err := workspace.Find(".")
+	if err != nil {
+		root, err := filepath.Abs(".")
+		if err != nil {
+			return "", nil, err
+		}
+		envInfo, err := envinfo.NewEnvSpecificInfo(root)
+		if err != nil {
+			return "", nil, err
+		}
+		if !envInfo.Exists() || envInfo.GetApplication() != o.appName {
+			return root, nil, nil
+		}
+		return root, []workspace.Component{{
+			Name:    envInfo.GetName(),
+			Context: root,
+			Project: envInfo.GetNamespace(),
+			App:     envInfo.GetApplication(),
+		}}, nil
+	}
+
+	components, err := wi.GetComponents()
+	if err != nil {
+		return "", nil, err
+	}
+	var found []workspace.Component
+	for _, component := range components {
+		if component.App == o.appName && component.Project == o.Project {
+			found = append(found, component)
+		}
+	}
+	return wi.Root, found, nil
+}
+
+// Run contains the logic for the odo command
+func (o *ExportOptions) Run(cmd *cobra.Command) (err error) {
+	o.warnMissingComponents()
+
+	if csvSupported, _ := service.IsCSVSupported(); csvSupported {
+		services, failed, err := service.ListOperatorServices(o.KClient)
+		if err != nil {
+			return errors.Wrap(err, "unable to list the operator backed services")
+		}
+		for _, name := range failed {
+			log.Warningf("The services of %s could not be listed and are not exported", name)
+		}
+		for _, svc := range services {
+			if svc.GetLabels()[applabels.ApplicationLabel] == o.appName {
+				o.services = append(o.services, svc)
+			}
+		}
+	}
+
+	s := log.Spinnerf("Exporting the application %s to %s", o.appName, o.dir)
+	defer s.End(false)
+	bundle, err := application.Export(o.dir, o.appName, o.Project, o.root, o.components, o.services)
+	if err != nil {
+		return err
+	}
+	s.End(true)
+
+	for _, component := range bundle.Components {
+		log.Infof("Exported the component %s", component.Name)
+	}
+	for _, svc := range bundle.Services {
+		log.Infof("Exported the service %s", svc)
+	}
+	return nil
+}
+
+// warnMissingComponents warns about the components of the application deployed in the cluster which are not in the
+// workspace, as they can't be exported without their devfile
+func (o *ExportOptions) warnMissingComponents() {
+	componentList, err := component.List(o.Client, o.appName, nil)
+	if err != nil {
+		klog.V(4).Infof("unable to list the components of the application %s: %v", o.appName, err)
+		return
+	}
+	for _, deployed := range componentList.Items {
+		found := false
+		for _, local := range o.components {
+			if local.Name == deployed.Name {
+				found = true
+			}
+		}
+		if !found {
+			log.Warningf("The component %s is not in the workspace and is not exported", deployed.Name)
+		}
+	}
+}
+
+// NewCmdExport implements the odo command.
+func NewCmdExport(name, fullName string) *cobra.Command {
+	o := NewExportOptions()
+	command := &cobra.Command{
+		Use:     fmt.Sprintf("%s [application_name]", name),
+		Short:   "Export the application to a bundle",
+		Long:    exportLongDesc,
+		Example: fmt.Sprintf(exportExample, fullName),
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	command.Flags().StringVar(&o.dir, "dir", "", "Directory the bundle is written to, defaults to <application_name>-bundle")
+	completion.RegisterCommandHandler(command, completion.AppCompletionHandler)
+	completion.RegisterCommandFlagHandler(command, "dir", completion.FileCompletionHandler)
+
+	project.AddProjectFlag(command)
+	return command
+}
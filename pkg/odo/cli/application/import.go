@@ -0,0 +1,168 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/application"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/project"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/service"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const importRecommendedCommandName = "import"
+
+var (
+	importLongDesc = ktemplates.LongDesc(`
+	Import an application bundle created by 'odo app export'.
+
+	The components of the bundle are written to their context directories, relative to the context directory of the
+	command, and added to its workspace, then its operator backed services are created in the project. The components
+	are deployed with 'odo push --all'. The links of the components are not imported, as their service bindings are not in
+	the bundle: the commands recreating the links to the services of the bundle are printed, the links to other services
+	are dropped.`)
+
+	importExample = ktemplates.Examples(`  # Import the bundle in the current directory and the active project
+  %[1]s myapp-bundle
+
+  # Import the bundle as the application 'staging' of the project 'myproject'
+  %[1]s myapp-bundle --app staging --project myproject
+
+  # Rename the component 'backend' and move the URLs of the domain 'example.com' to 'staging.example.com'
+  %[1]s myapp-bundle --name backend=api --host example.com=staging.example.com`)
+)
+
+// ImportOptions encapsulates the options for the odo command
+type ImportOptions struct {
+	bundleDir string
+	context   string
+	appName   string
+	names     map[string]string
+	hosts     map[string]string
+	force     bool
+
+	bundle application.Bundle
+	*genericclioptions.Context
+}
+
+// NewImportOptions creates a new ImportOptions instance
+func NewImportOptions() *ImportOptions {
+	return &ImportOptions{}
+}
+
+// Complete completes ImportOptions after they've been created
+func (o *ImportOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.bundleDir = args[0]
+	if o.context == "" {
+		o.context = "."
+	}
+	o.context, err = filepath.Abs(o.context)
+	if err != nil {
+		return err
+	}
+	o.Context, err = genericclioptions.NewDevfileContext(cmd)
+	return err
+}
+
+// Validate validates the ImportOptions based on completed values
+func (o *ImportOptions) Validate() (err error) {
+	o.bundle, err = application.ReadBundle(o.bundleDir)
+	if err != nil {
+		return err
+	}
+	for oldName := range o.names {
+		found := false
+		for _, component := range o.bundle.Components {
+			if component.Name == oldName {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("the bundle has no component named %s", oldName)
+		}
+	}
+	if o.Project == "" {
+		return fmt.Errorf("there is no project to import the application to, use --%s", genericclioptions.ProjectFlagName)
+	}
+	return nil
+}
+
+// Run contains the logic for the odo command
+func (o *ImportOptions) Run(cmd *cobra.Command) (err error) {
+	result, err := application.Import(o.bundleDir, o.context, o.bundle, application.ImportParameters{
+		Application: o.appName,
+		Project:     o.Project,
+		Names:       o.names,
+		Hosts:       o.hosts,
+		Force:       o.force,
+	})
+	if err != nil {
+		return err
+	}
+	appName := o.appName
+	if appName == "" {
+		appName = o.bundle.Application
+	}
+	log.Successf("Imported %d component(s) of the application %s", len(o.bundle.Components), appName)
+
+	for _, link := range result.DroppedLinks {
+		log.Warningf("The link of the component %s to the service %s is not imported, as the service is not in the bundle", link.Component, link.Service)
+	}
+
+	for _, manifest := range result.Manifests {
+		cr, csv, err := service.GetCSV(o.KClient, manifest)
+		if err != nil {
+			return err
+		}
+		group, version, resource, err := service.GetGVRFromOperator(csv, cr)
+		if err != nil {
+			return err
+		}
+		s := log.Spinnerf("Creating the service %s/%s", cr, (&unstructured.Unstructured{Object: manifest}).GetName())
+		if err = service.CreateOperatorService(o.KClient, group, version, resource, manifest); err != nil {
+			s.End(false)
+			return errors.Wrapf(err, "unable to import the service %s", cr)
+		}
+		s.End(true)
+	}
+
+	log.Italic("\nTo deploy the components, run 'odo push --all'")
+	if len(result.Links) != 0 {
+		log.Italic("Then, to recreate the links of the components, run:")
+		for _, link := range result.Links {
+			log.Italicf("  odo link %s --context %s", link.Service, filepath.Join(o.context, filepath.FromSlash(link.Context)))
+		}
+	}
+	return nil
+}
+
+// NewCmdImport implements the odo command.
+func NewCmdImport(name, fullName string) *cobra.Command {
+	o := NewImportOptions()
+	command := &cobra.Command{
+		Use:     fmt.Sprintf("%s <bundle_directory>", name),
+		Short:   "Import an application bundle",
+		Long:    importLongDesc,
+		Example: fmt.Sprintf(importExample, fullName),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	command.Flags().StringVar(&o.appName, genericclioptions.ApplicationFlagName, "", "Name of the imported application, defaults to the exported name")
+	command.Flags().StringToStringVar(&o.names, "name", nil, "Rename a component, as old=new")
+	command.Flags().StringToStringVar(&o.hosts, "host", nil, "Replace the host, or the domain, of URLs, as old=new")
+	command.Flags().BoolVarP(&o.force, "force", "f", false, "Overwrite the components existing in the context directories")
+	completion.RegisterCommandHandler(command, completion.FileCompletionHandler)
+
+	genericclioptions.AddContextFlag(command, &o.context)
+	project.AddProjectFlag(command)
+	return command
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/odo/pkg/devfile/validate"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/machineoutput"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/util"
 
@@ -198,7 +199,16 @@ func GetDevfile(devfileComponent catalog.DevfileComponentType) (parser.DevfileOb
 	var devObj parser.DevfileObj
 	var err error
 
-	if strings.Contains(devfileComponent.Registry.URL, "github") {
+	if registryUtil.IsFileBasedRegistry(devfileComponent.Registry.URL) {
+		devfileData, err := catalog.GetFileBasedRegistryDevfile(devfileComponent.Registry, devfileComponent.Name)
+		if err != nil {
+			return devObj, errors.Wrapf(err, "Failed to read devfile.yaml from file-based registry for devfile component: %s", devfileComponent.Name)
+		}
+		devObj, err = devfile.ParseFromDataAndValidate(devfileData)
+		if err != nil {
+			return devObj, errors.Wrapf(err, "Failed to parse devfile.yaml from file-based registry for devfile component: %s", devfileComponent.Name)
+		}
+	} else if strings.Contains(devfileComponent.Registry.URL, "github") {
 		devObj, err = devfile.ParseFromURLAndValidate(devfileComponent.Registry.URL + devfileComponent.Link)
 		if err != nil {
 			return devObj, errors.Wrapf(err, "Failed to download devfile.yaml from Github-based registry for devfile component: %s", devfileComponent.Name)
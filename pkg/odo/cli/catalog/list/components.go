@@ -85,11 +85,14 @@ func (o *ListComponentsOptions) Validate() (err error) {
 	return err
 }
 
-type combinedCatalogList struct {
+// CombinedCatalogList is the machine readable output of odo catalog list components
+type CombinedCatalogList struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	S2iItems          []catalog.ComponentType        `json:"s2iItems,omitempty"`
 	DevfileItems      []catalog.DevfileComponentType `json:"devfileItems,omitempty"`
+	// DevfileConflicts lists the devfile stacks published by several registries
+	DevfileConflicts []catalog.StackConflict `json:"devfileConflicts,omitempty"`
 }
 
 // Run contains the logic for the command associated with ListComponentsOptions
@@ -100,13 +103,14 @@ func (o *ListComponentsOptions) Run(cmd *cobra.Command) (err error) {
 			supported, _ := catalog.SliceSupportedTags(image)
 			o.catalogList.Items[i].Spec.SupportedTags = supported
 		}
-		combinedList := combinedCatalogList{
+		combinedList := CombinedCatalogList{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       "List",
 				APIVersion: "odo.dev/v1alpha1",
 			},
-			S2iItems:     o.catalogList.Items,
-			DevfileItems: o.catalogDevfileList.Items,
+			S2iItems:         o.catalogList.Items,
+			DevfileItems:     o.catalogDevfileList.Items,
+			DevfileConflicts: catalog.GetStackConflicts(o.catalogDevfileList.Items),
 		}
 		machineoutput.OutputSuccess(combinedList)
 	} else {
@@ -132,6 +136,13 @@ func (o *ListComponentsOptions) Run(cmd *cobra.Command) (err error) {
 			fmt.Fprintln(w, "NAME", "\t", "DESCRIPTION", "\t", "REGISTRY")
 
 			o.printDevfileCatalogList(w, o.catalogDevfileList.Items, "")
+
+			if conflicts := catalog.GetStackConflicts(o.catalogDevfileList.Items); len(conflicts) != 0 {
+				fmt.Fprintln(w)
+				fmt.Fprintln(w, "Devfile Components Published By Several Registries:")
+				fmt.Fprintln(w, "NAME", "\t", "SELECTED REGISTRY", "\t", "OTHER REGISTRIES")
+				printStackConflicts(w, conflicts)
+			}
 		}
 
 		if len(supCatalogList) != 0 || len(unsupCatalogList) != 0 {
@@ -198,12 +209,25 @@ func (o *ListComponentsOptions) printCatalogList(w io.Writer, catalogList []cata
 	}
 }
 
+// printStackConflicts prints the registry used for each conflicting stack, the other registries
+// can be selected with the <registry>/<stack> form in odo create
+func printStackConflicts(w io.Writer, conflicts []catalog.StackConflict) {
+	for _, conflict := range conflicts {
+		fmt.Fprintln(w, conflict.Name, "\t", conflict.Selected, "\t", strings.Join(conflict.Registries[1:], ","))
+	}
+}
+
 func (o *ListComponentsOptions) printDevfileCatalogList(w io.Writer, catalogDevfileList []catalog.DevfileComponentType, supported string) {
 	for _, devfileComponent := range catalogDevfileList {
+		registryName := devfileComponent.Registry.Name
+		if devfileComponent.Stale {
+			// the entry comes from a cached index that couldn't be revalidated
+			registryName = fmt.Sprintf("%s (stale)", registryName)
+		}
 		if supported != "" {
-			fmt.Fprintln(w, devfileComponent.Name, "\t", util.TruncateString(devfileComponent.Description, 60, "..."), "\t", devfileComponent.Registry.Name, "\t", supported)
+			fmt.Fprintln(w, devfileComponent.Name, "\t", util.TruncateString(devfileComponent.Description, 60, "..."), "\t", registryName, "\t", supported)
 		} else {
-			fmt.Fprintln(w, devfileComponent.Name, "\t", util.TruncateString(devfileComponent.Description, 60, "..."), "\t", devfileComponent.Registry.Name)
+			fmt.Fprintln(w, devfileComponent.Name, "\t", util.TruncateString(devfileComponent.Description, 60, "..."), "\t", registryName)
 		}
 	}
 }
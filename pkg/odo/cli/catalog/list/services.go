@@ -99,7 +99,8 @@ func NewCmdCatalogListServices(name, fullName string) *cobra.Command {
 	}
 }
 
-type catalogListOutput struct {
+// CatalogListOutput is the machine readable output of odo catalog list services
+type CatalogListOutput struct {
 	v1.TypeMeta   `json:",inline"`
 	v1.ObjectMeta `json:"metadata,omitempty"`
 	Services      *catalog.ServiceTypeList `json:"services,omitempty"`
@@ -107,8 +108,8 @@ type catalogListOutput struct {
 	Operators *olm.ClusterServiceVersionList `json:"operators,omitempty"`
 }
 
-func newCatalogListOutput(services *catalog.ServiceTypeList, operators *olm.ClusterServiceVersionList) catalogListOutput {
-	return catalogListOutput{
+func newCatalogListOutput(services *catalog.ServiceTypeList, operators *olm.ClusterServiceVersionList) CatalogListOutput {
+	return CatalogListOutput{
 		TypeMeta: v1.TypeMeta{
 			Kind:       "List",
 			APIVersion: machineoutput.APIVersion,
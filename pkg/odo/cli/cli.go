@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/odo/cli/telemetry"
 
 	"github.com/openshift/odo/pkg/odo/cli/application"
@@ -16,15 +17,19 @@ import (
 	"github.com/openshift/odo/pkg/odo/cli/env"
 	"github.com/openshift/odo/pkg/odo/cli/login"
 	"github.com/openshift/odo/pkg/odo/cli/logout"
+	"github.com/openshift/odo/pkg/odo/cli/plugin"
 	"github.com/openshift/odo/pkg/odo/cli/plugins"
 	"github.com/openshift/odo/pkg/odo/cli/preference"
 	"github.com/openshift/odo/pkg/odo/cli/project"
 	"github.com/openshift/odo/pkg/odo/cli/registry"
+	"github.com/openshift/odo/pkg/odo/cli/serve"
 	"github.com/openshift/odo/pkg/odo/cli/service"
 	"github.com/openshift/odo/pkg/odo/cli/storage"
 	"github.com/openshift/odo/pkg/odo/cli/url"
 	"github.com/openshift/odo/pkg/odo/cli/utils"
 	"github.com/openshift/odo/pkg/odo/cli/version"
+	"github.com/openshift/odo/pkg/odo/cli/workspace"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/odo/util"
 
 	"github.com/spf13/cobra"
@@ -108,8 +113,6 @@ More information such as logs or what components you've deployed can be accessed
 To see a full list of commands, run 'odo --help'`
 )
 
-const pluginPrefix = "odo"
-
 // NewCmdOdo creates a new root command for odo
 func NewCmdOdo(name, fullName string) *cobra.Command {
 	rootCmd := odoRootCmd(name, fullName)
@@ -122,7 +125,7 @@ func NewCmdOdo(name, fullName string) *cobra.Command {
 		if err == nil && cmd != rootCmd {
 			return rootCmd
 		}
-		handleErr := plugins.HandleCommand(plugins.NewExecHandler(pluginPrefix), cmdPathPieces)
+		handleErr := plugins.HandleCommand(plugins.NewExecHandler(plugins.Prefix), cmdPathPieces)
 		if handleErr != nil {
 			return rootCmd
 		}
@@ -192,6 +195,8 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 		component.NewCmdWatch(component.WatchRecommendedCommandName, util.GetFullName(fullName, component.WatchRecommendedCommandName)),
 		component.NewCmdStatus(component.StatusRecommendedCommandName, util.GetFullName(fullName, component.StatusRecommendedCommandName)),
 		component.NewCmdExec(component.ExecRecommendedCommandName, util.GetFullName(fullName, component.ExecRecommendedCommandName)),
+		component.NewCmdPortForward(component.PortForwardRecommendedCommandName, util.GetFullName(fullName, component.PortForwardRecommendedCommandName)),
+		serve.NewCmdServe(serve.RecommendedCommandName, util.GetFullName(fullName, serve.RecommendedCommandName)),
 		login.NewCmdLogin(login.RecommendedCommandName, util.GetFullName(fullName, login.RecommendedCommandName)),
 		logout.NewCmdLogout(logout.RecommendedCommandName, util.GetFullName(fullName, logout.RecommendedCommandName)),
 		project.NewCmdProject(project.RecommendedCommandName, util.GetFullName(fullName, project.RecommendedCommandName)),
@@ -206,6 +211,8 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 		registry.NewCmdRegistry(registry.RecommendedCommandName, util.GetFullName(fullName, registry.RecommendedCommandName)),
 		component.NewCmdTest(component.TestRecommendedCommandName, util.GetFullName(fullName, component.TestRecommendedCommandName)),
 		env.NewCmdEnv(env.RecommendedCommandName, util.GetFullName(fullName, env.RecommendedCommandName)),
+		plugin.NewCmdPlugin(plugin.RecommendedCommandName, util.GetFullName(fullName, plugin.RecommendedCommandName)),
+		workspace.NewCmdWorkspace(workspace.RecommendedCommandName, util.GetFullName(fullName, workspace.RecommendedCommandName)),
 		telemetry.NewCmdTelemetry(telemetry.RecommendedCommandName),
 	)
 
@@ -213,6 +220,12 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 	rootCmd.AddCommand(rootCmdList...)
 
 	util.VisitCommands(rootCmd, reconfigureCmdWithSubcmd)
+	// Add the flag selecting the environment of the component to all commands, and the flag overriding the cluster
+	// binding to the commands working on a component
+	util.VisitCommands(rootCmd, func(cmd *cobra.Command) {
+		envinfo.AddEnvironmentFlag(cmd.Flags())
+		genericclioptions.AddIgnoreClusterBindingFlag(cmd)
+	})
 
 	return rootCmd
 }
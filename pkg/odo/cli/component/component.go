@@ -38,6 +38,9 @@ func (co *ComponentOptions) Complete(name string, cmd *cobra.Command, args []str
 	} else {
 		co.Context, err = genericclioptions.NewContext(cmd)
 	}
+	if _, ok := err.(*genericclioptions.ClusterBindingError); ok {
+		return err
+	}
 	if err != nil {
 		co.Context = genericclioptions.NewOfflineDevfileContext(cmd)
 		err = nil
@@ -64,6 +67,7 @@ func NewCmdComponent(name, fullName string) *cobra.Command {
 	testCmd := NewCmdTest(TestRecommendedCommandName, odoutil.GetFullName(fullName, TestRecommendedCommandName))
 	execCmd := NewCmdExec(ExecRecommendedCommandName, odoutil.GetFullName(fullName, ExecRecommendedCommandName))
 	statusCmd := NewCmdStatus(StatusRecommendedCommandName, odoutil.GetFullName(fullName, StatusRecommendedCommandName))
+	portForwardCmd := NewCmdPortForward(PortForwardRecommendedCommandName, odoutil.GetFullName(fullName, PortForwardRecommendedCommandName))
 
 	// componentCmd represents the component command
 	var componentCmd = &cobra.Command{
@@ -80,7 +84,7 @@ func NewCmdComponent(name, fullName string) *cobra.Command {
 	componentCmd.Flags().AddFlagSet(componentGetCmd.Flags())
 
 	componentCmd.AddCommand(componentGetCmd, createCmd, deleteCmd, describeCmd, linkCmd, unlinkCmd, listCmd, logCmd, pushCmd, updateCmd, watchCmd, execCmd)
-	componentCmd.AddCommand(testCmd, statusCmd)
+	componentCmd.AddCommand(testCmd, statusCmd, portForwardCmd)
 
 	// Add a defined annotation in order to appear in the help menu
 	componentCmd.Annotations = map[string]string{"command": "main"}
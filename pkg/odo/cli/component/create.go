@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	scontext "github.com/openshift/odo/pkg/segment/context"
 
@@ -14,7 +15,6 @@ import (
 	"github.com/zalando/go-keyring"
 
 	"github.com/devfile/library/pkg/devfile"
-	registryLibrary "github.com/devfile/registry-support/registry-library/library"
 	"github.com/openshift/odo/pkg/catalog"
 	"github.com/openshift/odo/pkg/component"
 	"github.com/openshift/odo/pkg/config"
@@ -31,8 +31,10 @@ import (
 	odoutil "github.com/openshift/odo/pkg/odo/util"
 	"github.com/openshift/odo/pkg/odo/util/completion"
 	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/signature"
 	"github.com/openshift/odo/pkg/util"
 
+	"k8s.io/klog"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 )
 
@@ -49,6 +51,9 @@ type CreateOptions struct {
 	interactive       bool
 	now               bool
 	forceS2i          bool
+	// unknownRegistry is the text before "/" in the component type when it isn't a registry, the component type
+	// being then a namespaced s2i component type
+	unknownRegistry string
 	*PushOptions
 
 	devfileMetadata DevfileMetadata
@@ -63,6 +68,7 @@ type devfilePath struct {
 // DevfileMetadata includes devfile component metadata
 type DevfileMetadata struct {
 	componentType      string
+	componentVersion   string
 	componentName      string
 	componentNamespace string
 	devfileSupport     bool
@@ -73,6 +79,7 @@ type DevfileMetadata struct {
 	starter            string
 	token              string
 	starterToken       string
+	starterParams      []string
 }
 
 // CreateRecommendedCommandName is the recommended watch command name
@@ -109,6 +116,9 @@ odo catalog list components
 # Download an example devfile and application before deploying
 %[1]s nodejs --starter
 
+# Download a templated starter project and set the values of its parameters
+%[1]s java-springboot --starter --starter-param GroupId=com.example --starter-param ArtifactId=demo
+
 # Using a specific devfile
 %[1]s mynodejs --devfile ./devfile.yaml
 %[1]s mynodejs --devfile https://raw.githubusercontent.com/odo-devfiles/registry/master/devfiles/nodejs/devfile.yaml
@@ -202,6 +212,26 @@ func (co *CreateOptions) setComponentSourceAttributes() (err error) {
 	return
 }
 
+// parseComponentType parses the component type given as [registry/]stack[@version]. The text before "/" names a
+// registry only when it is one of the registries, otherwise the component type is kept whole as a namespaced s2i
+// component type, such as openshift/nodejs, and the text is returned as the unknown registry
+func parseComponentType(componentType string, registries []catalog.Registry) (stackRef catalog.StackReference, unknownRegistry string, err error) {
+	stackRef, err = catalog.ParseStackReference(componentType)
+	if err != nil || stackRef.Registry == "" {
+		return stackRef, "", err
+	}
+	for _, registry := range registries {
+		if registry.Name == stackRef.Registry {
+			return stackRef, "", nil
+		}
+	}
+	// s2i component types don't have versions
+	if stackRef.Version != "" {
+		return catalog.StackReference{}, "", errors.Errorf("unknown registry %s in the stack %s, please run `odo registry list` for a list of the registries", stackRef.Registry, componentType)
+	}
+	return catalog.StackReference{Name: componentType}, stackRef.Registry, nil
+}
+
 func (co *CreateOptions) setComponentName(args []string) (err error) {
 	componentImageName, componentType, _, _ := util.ParseComponentImageName(args[0])
 	co.componentSettings.Type = &componentImageName
@@ -327,6 +357,8 @@ func (co *CreateOptions) checkConflictingDevfileFlags() error {
 			flagName = "token"
 		} else if len(co.devfileMetadata.starter) != 0 {
 			flagName = "starter"
+		} else if len(co.devfileMetadata.starterParams) != 0 {
+			flagName = "starter-param"
 		}
 
 		if len(flagName) != 0 {
@@ -502,8 +534,25 @@ func (co *CreateOptions) Complete(name string, cmd *cobra.Command, args []string
 			} else if len(args) > 0 {
 				// Download devfile from registry
 
-				// Component type: Get from full command's first argument (mandatory in direct mode)
-				componentType = args[0]
+				// Component type: Get from full command's first argument (mandatory in direct mode),
+				// it can pin the registry and the version of the stack as [registry/]stack[@version]
+				registries, err := catalog.GetDevfileRegistries("")
+				if err != nil {
+					return err
+				}
+				stackRef, unknownRegistry, err := parseComponentType(args[0], registries)
+				if err != nil {
+					return err
+				}
+				co.unknownRegistry = unknownRegistry
+				if stackRef.Registry != "" {
+					if co.devfileMetadata.devfileRegistry.Name != "" && co.devfileMetadata.devfileRegistry.Name != stackRef.Registry {
+						return errors.Errorf("registry %s of the stack %s doesn't match the registry %s specified via --registry", stackRef.Registry, args[0], co.devfileMetadata.devfileRegistry.Name)
+					}
+					co.devfileMetadata.devfileRegistry.Name = stackRef.Registry
+				}
+				componentType = stackRef.Name
+				co.devfileMetadata.componentVersion = stackRef.Version
 
 				// Component name: Get from full command's second argument (optional in direct mode), by default it is a generated name if second arg is not provided
 				if len(args) == 2 {
@@ -590,14 +639,19 @@ func (co *CreateOptions) Complete(name string, cmd *cobra.Command, args []string
 			defer devfileExistSpinner.End(false)
 		}
 
-		for _, devfileComponent := range catalogDevfileList.Items {
-			if co.devfileMetadata.componentType == devfileComponent.Name {
-				hasComponent = true
-				co.devfileMetadata.devfileSupport = true
-				co.devfileMetadata.devfileLink = devfileComponent.Link
-				co.devfileMetadata.devfileRegistry = devfileComponent.Registry
-				break
-			}
+		stackRef := catalog.StackReference{
+			Registry: co.devfileMetadata.devfileRegistry.Name,
+			Name:     co.devfileMetadata.componentType,
+			Version:  co.devfileMetadata.componentVersion,
+		}
+		if devfileComponent, ok := catalog.ResolveStack(catalogDevfileList.Items, stackRef); ok {
+			hasComponent = true
+			co.devfileMetadata.devfileSupport = true
+			co.devfileMetadata.devfileLink = devfileComponent.Link
+			co.devfileMetadata.devfileRegistry = devfileComponent.Registry
+			co.devfileMetadata.componentVersion = devfileComponent.Version
+		} else if stackRef.Version != "" {
+			return errors.Errorf("devfile component type %s is not supported, please run `odo catalog list components` for a list of supported devfile component types", stackRef)
 		}
 
 		if co.forceS2i && hasComponent {
@@ -664,6 +718,9 @@ func (co *CreateOptions) Complete(name string, cmd *cobra.Command, args []string
 	var openshiftCluster bool
 	openshiftCluster, _ = co.Client.IsImageStreamSupported()
 	if !openshiftCluster {
+		if co.unknownRegistry != "" {
+			return errors.Errorf("unknown registry %s in the component type %s, please run `odo registry list` for a list of the registries", co.unknownRegistry, args[0])
+		}
 		return errors.New("component type not found")
 	}
 
@@ -771,6 +828,32 @@ func (co *CreateOptions) s2iRun() (err error) {
 // Run has the logic to perform the required actions as part of command
 func (co *CreateOptions) devfileRun() (err error) {
 	var devfileData []byte
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+
+	// the digests of the downloaded devfile and starter project are recorded in env.yaml
+	var provenance []envinfo.ResourceProvenance
+	verifier, err := signature.NewVerifier(cfg.GetTrustedKeys())
+	if err != nil {
+		return err
+	}
+	requireSignature := co.devfileMetadata.devfileRegistry.RequireSignature
+	checkDevfile := func(source string, sig []byte) error {
+		result, err := verifier.Check(source, devfileData, sig, requireSignature)
+		if err != nil {
+			return err
+		}
+		provenance = append(provenance, envinfo.ResourceProvenance{
+			Kind:     envinfo.DevfileResource,
+			Source:   source,
+			Digest:   result.Digest,
+			Verified: result.Verified,
+		})
+		return nil
+	}
+
 	devfileExist := util.CheckPathExists(DevfilePath)
 	// Use existing devfile directly from --devfile flag
 	if co.devfileMetadata.devfilePath.value != "" {
@@ -784,6 +867,10 @@ func (co *CreateOptions) devfileRun() (err error) {
 			if err != nil {
 				return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfilePath.value)
 			}
+			err = checkDevfile(params.URL, signature.FetchSignature(params.URL, params.Token, 0, false))
+			if err != nil {
+				return err
+			}
 		} else if co.devfileMetadata.devfilePath.protocol == "file" {
 			devfileData, err = ioutil.ReadFile(co.devfileMetadata.devfilePath.value)
 			if err != nil {
@@ -800,11 +887,9 @@ func (co *CreateOptions) devfileRun() (err error) {
 			}
 		} else {
 			// Download devfile from registry
-			var params util.HTTPRequestParams
-
-			if strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
+			if strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") && !registryUtil.IsFileBasedRegistry(co.devfileMetadata.devfileRegistry.URL) {
 				// Github-based registry
-				params = util.HTTPRequestParams{
+				params := util.HTTPRequestParams{
 					URL: co.devfileMetadata.devfileRegistry.URL + co.devfileMetadata.devfileLink,
 				}
 				if registryUtil.IsSecure(co.devfileMetadata.devfileRegistry.Name) {
@@ -814,25 +899,37 @@ func (co *CreateOptions) devfileRun() (err error) {
 					}
 					params.Token = token
 				}
-			} else {
-				err = registryLibrary.PullStackFromRegistry(co.devfileMetadata.devfileRegistry.URL, co.devfileMetadata.componentType, co.componentContext)
+				resp, err := util.HTTPGetCachedRequest(params, cfg.GetRegistryCacheTime(), cfg.GetOffline())
+				if err != nil {
+					return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfileRegistry.URL+co.devfileMetadata.devfileLink)
+				}
+				if resp.Stale {
+					log.Warningf("Using the cached devfile from %s", resp.FetchedAt.Format(time.RFC1123))
+				}
+				devfileData = resp.Body
+				err = checkDevfile(params.URL, signature.FetchSignature(params.URL, params.Token, cfg.GetRegistryCacheTime(), cfg.GetOffline()))
 				if err != nil {
 					return err
 				}
-			}
-
-			cfg, err := preference.New()
-			if err != nil {
-				return err
-			}
-
-			if strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
-				devfileData, err = util.DownloadFileInMemoryWithCache(params, cfg.GetRegistryCacheTime())
 			} else {
+				devfileSig, err := catalog.PullStackFromRegistry(co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, co.devfileMetadata.componentVersion, co.componentContext, cfg.GetOffline())
+				if err != nil {
+					return err
+				}
 				devfileData, err = ioutil.ReadFile(DevfilePath)
-			}
-			if err != nil {
-				return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfileRegistry.URL+co.devfileMetadata.devfileLink)
+				if err != nil {
+					return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfileRegistry.URL+co.devfileMetadata.devfileLink)
+				}
+				// the signature of the devfile is shipped with the other resources of the stack
+				stackRef := catalog.StackReference{
+					Registry: co.devfileMetadata.devfileRegistry.Name,
+					Name:     co.devfileMetadata.componentType,
+					Version:  co.devfileMetadata.componentVersion,
+				}
+				err = checkDevfile(stackRef.String(), devfileSig)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -845,6 +942,14 @@ func (co *CreateOptions) devfileRun() (err error) {
 		return err
 	}
 
+	// starter projects shipped within a file-based registry are read from the registry
+	if co.devfileMetadata.devfilePath.value == "" && !devfileExist && registryUtil.IsFileBasedRegistry(co.devfileMetadata.devfileRegistry.URL) {
+		err = catalog.ResolveStarterProjects(co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, co.devfileMetadata.componentVersion, devObj)
+		if err != nil {
+			return err
+		}
+	}
+
 	if co.devfileMetadata.starterToken == "" && registryUtil.IsSecure(co.devfileMetadata.devfileRegistry.Name) {
 		token, err := keyring.Get(fmt.Sprintf("%s%s", util.CredentialPrefix, co.devfileMetadata.devfileRegistry.Name), registryUtil.RegistryUser)
 		if err != nil {
@@ -853,10 +958,17 @@ func (co *CreateOptions) devfileRun() (err error) {
 		co.devfileMetadata.starterToken = token
 	}
 
-	err = decideAndDownloadStarterProject(devObj, co.devfileMetadata.starter, co.devfileMetadata.starterToken, co.interactive, co.componentContext)
+	starterParams, err := component.ParseStarterParams(co.devfileMetadata.starterParams)
+	if err != nil {
+		return err
+	}
+	starterProvenance, err := decideAndDownloadStarterProject(devObj, co.devfileMetadata.starter, co.devfileMetadata.starterToken, co.interactive, co.componentContext, cfg.GetOffline(), verifier, requireSignature, starterParams)
 	if err != nil {
 		return errors.Wrap(err, "failed to download project for devfile component")
 	}
+	if starterProvenance != nil {
+		provenance = append(provenance, *starterProvenance)
+	}
 
 	// save devfile and corresponding resources if possible
 	// use original devfileData to persist original formatting of the devfile file
@@ -864,20 +976,30 @@ func (co *CreateOptions) devfileRun() (err error) {
 	if err != nil {
 		return errors.Wrapf(err, "unable to save devfile to %s", DevfilePath)
 	}
-	if co.devfileMetadata.devfilePath.value == "" && !devfileExist && !strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
-		err = registryLibrary.PullStackFromRegistry(co.devfileMetadata.devfileRegistry.URL, co.devfileMetadata.componentType, co.componentContext)
+	if co.devfileMetadata.devfilePath.value == "" && !devfileExist && (!strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") || registryUtil.IsFileBasedRegistry(co.devfileMetadata.devfileRegistry.URL)) {
+		_, err = catalog.PullStackFromRegistry(co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, co.devfileMetadata.componentVersion, co.componentContext, cfg.GetOffline())
 		if err != nil {
 			return err
 		}
 	}
 
 	// Generate env file
-	err = co.EnvSpecificInfo.SetComponentSettings(envinfo.ComponentSettings{
+	componentSettings := envinfo.ComponentSettings{
 		Name:               co.devfileMetadata.componentName,
 		Project:            co.devfileMetadata.componentNamespace,
 		AppName:            co.appName,
 		UserCreatedDevfile: co.devfileMetadata.userCreatedDevfile,
-	})
+	}
+	if len(provenance) != 0 {
+		componentSettings.Provenance = &provenance
+	}
+	// bind the component to the cluster of the current context, the first push binds it if there is none yet
+	if binding, err := genericclioptions.GetClusterBinding(nil); err == nil {
+		componentSettings.Cluster = &binding
+	} else {
+		klog.V(4).Infof("the component is not bound to a cluster: %v", err)
+	}
+	err = co.EnvSpecificInfo.SetComponentSettings(componentSettings)
 	if err != nil {
 		return errors.Wrap(err, "failed to create env file for devfile component")
 	}
@@ -1020,6 +1142,7 @@ func NewCmdCreate(name, fullName string) *cobra.Command {
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.devfilePath.value, "devfile", "", "Path to the user specified devfile")
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.token, "token", "", "Token to be used when downloading devfile from the devfile path that is specified via --devfile")
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.starterToken, "starter-token", "", "Token to be used when downloading starter project")
+	componentCreateCmd.Flags().StringArrayVar(&co.devfileMetadata.starterParams, "starter-param", nil, "Value of a parameter of a templated starter project, in the key=value form (can be repeated)")
 	componentCreateCmd.Flags().BoolVar(&co.forceS2i, "s2i", false, "Enforce S2I type components")
 
 	componentCreateCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
@@ -8,7 +8,9 @@ import (
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/component/ui"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/signature"
 )
 
 func (co *CreateOptions) SetComponentSettings(args []string) error {
@@ -43,16 +45,17 @@ func (co *CreateOptions) SetComponentSettings(args []string) error {
 }
 
 // decideAndDownloadStarterProject decides the starter project from the value passed by the user and
-// downloads it
-func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed string, token string, interactive bool, contextDir string) error {
+// downloads it, or takes it from the cache in offline mode. Templated starter projects are rendered with starterParams,
+// the missing parameters are prompted in interactive mode. It returns the provenance of the downloaded project, if any.
+func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed string, token string, interactive bool, contextDir string, offline bool, verifier *signature.Verifier, requireSignature bool, starterParams map[string]string) (*envinfo.ResourceProvenance, error) {
 	if projectPassed == "" && !interactive {
-		return nil
+		return nil, nil
 	}
 
 	// Retrieve starter projects
 	starterProjects, err := devObj.Data.GetStarterProjects(parsercommon.DevfileOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var starterProject *devfilev1.StarterProject
@@ -61,15 +64,38 @@ func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed str
 	} else {
 		starterProject, err = component.GetStarterProject(starterProjects, projectPassed)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if starterProject == nil {
-		return nil
+		return nil, nil
+	}
+
+	var parameters map[string]string
+	renderTemplate := func(projectDir string) error {
+		starterTemplate, err := component.GetStarterTemplate(projectDir)
+		if err != nil || starterTemplate == nil {
+			return err
+		}
+		var prompt func(parameter component.StarterParameter) string
+		if interactive {
+			prompt = ui.EnterStarterParameter
+		}
+		parameters, err = starterTemplate.ResolveParameters(starterParams, prompt)
+		if err != nil {
+			return err
+		}
+		return starterTemplate.Render(projectDir, parameters)
 	}
 
-	return component.DownloadStarterProject(starterProject, token, contextDir)
+	provenance, err := component.DownloadStarterProject(starterProject, token, contextDir, offline, verifier, requireSignature, renderTemplate)
+	if err != nil {
+		return nil, err
+	}
+	// the values are recorded so that the project can be generated again
+	provenance.Parameters = parameters
+	return &provenance, nil
 }
 
 // DevfileJSON creates the full json description of a devfile component is prints it
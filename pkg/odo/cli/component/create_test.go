@@ -0,0 +1,62 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/catalog"
+)
+
+func TestParseComponentType(t *testing.T) {
+	registries := []catalog.Registry{{Name: "DefaultDevfileRegistry"}, {Name: "internal"}}
+
+	tests := []struct {
+		name          string
+		componentType string
+		want          catalog.StackReference
+		wantUnknown   string
+		wantErr       bool
+	}{
+		{
+			name:          "Case 1: stack without registry",
+			componentType: "nodejs",
+			want:          catalog.StackReference{Name: "nodejs"},
+		},
+		{
+			name:          "Case 2: stack pinned to a registry and a version",
+			componentType: "internal/nodejs@1.0.1",
+			want:          catalog.StackReference{Registry: "internal", Name: "nodejs", Version: "1.0.1"},
+		},
+		{
+			name:          "Case 3: namespaced s2i component type",
+			componentType: "openshift/nodejs",
+			want:          catalog.StackReference{Name: "openshift/nodejs"},
+			wantUnknown:   "openshift",
+		},
+		{
+			name:          "Case 4: namespaced s2i component type with a tag",
+			componentType: "openshift/nodejs:12",
+			want:          catalog.StackReference{Name: "openshift/nodejs:12"},
+			wantUnknown:   "openshift",
+		},
+		{
+			name:          "Case 5: stack version pinned to an unknown registry",
+			componentType: "unknown/nodejs@1.0.1",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown, err := parseComponentType(tt.componentType, registries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got the stack reference %+v, want %+v", got, tt.want)
+			}
+			if unknown != tt.wantUnknown {
+				t.Errorf("got the unknown registry %q, want %q", unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
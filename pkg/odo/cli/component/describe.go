@@ -84,7 +84,7 @@ func NewCmdDescribe(name, fullName string) *cobra.Command {
 		Long:        `Describe component.`,
 		Example:     fmt.Sprintf(describeExample, fullName),
 		Args:        cobra.RangeArgs(0, 1),
-		Annotations: map[string]string{"machineoutput": "json", "command": "component"},
+		Annotations: map[string]string{"machineoutput": "all", "command": "component"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(do, cmd, args)
 		},
@@ -70,6 +70,17 @@ func (po *PushOptions) DevfilePush() error {
 		return err
 	}
 
+	// bind the component to the cluster it was pushed to, if it was created without a current context
+	if po.EnvSpecificInfo.GetClusterBinding() == nil {
+		binding, err := genericclioptions.GetClusterBinding(po.KClient.KubeConfig)
+		if err != nil {
+			return err
+		}
+		if err = po.EnvSpecificInfo.SetClusterBinding(binding); err != nil {
+			return err
+		}
+	}
+
 	// push is successful, save the runMode used
 	runMode := envinfo.Run
 	if po.debugRun {
@@ -0,0 +1,218 @@
+package component
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	odoutil "github.com/openshift/odo/pkg/odo/util"
+	"github.com/spf13/cobra"
+
+	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// PortForwardRecommendedCommandName is the recommended port-forward command name
+const PortForwardRecommendedCommandName = "port-forward"
+
+var (
+	portForwardLongDesc = ktemplates.LongDesc(`Forward one or more local ports to the pod of the component, or to a pod of a service linked to the component.
+
+	The ports are given in the [LOCAL_PORT:]REMOTE_PORT format, the local port being the remote port by default.
+	With --service, the remote ports are the ports of the linked service, or of its pods.
+	The ports are forwarded until the command is interrupted, and forwarded again when the pod is replaced.
+	The active port forwarding sessions are listed by 'odo debug info'.`)
+
+	portForwardExample = ktemplates.Examples(`  # Forward the local port 9090 to the port 9090 of the component pod
+  %[1]s 9090
+
+  # Forward the local ports 8081 and 9000 to the ports 8080 and 9000 of the component pod
+  %[1]s 8081:8080 9000
+
+  # Forward the local port 5432 to the port 5432 of a pod of the linked service mydb
+  %[1]s 5432 --service mydb
+	`)
+)
+
+// PortForwardOptions encapsulates the options for the odo port-forward command
+type PortForwardOptions struct {
+	componentContext string
+	serviceFlag      string
+
+	portPairs []string
+	// target is "component", or the name of the Kubernetes service of the linked service
+	target string
+
+	portForwarder *debug.DefaultPortForwarder
+	// stopChannel is used to stop port forwarding
+	stopChannel chan struct{}
+
+	*genericclioptions.Context
+}
+
+// NewPortForwardOptions returns new instance of PortForwardOptions
+func NewPortForwardOptions() *PortForwardOptions {
+	return &PortForwardOptions{}
+}
+
+// Complete completes PortForwardOptions after they've been created
+func (o *PortForwardOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = genericclioptions.New(genericclioptions.CreateParameters{
+		Cmd:              cmd,
+		DevfilePath:      DevfilePath,
+		ComponentContext: o.componentContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	o.portPairs, err = getPortPairs(args)
+	if err != nil {
+		return err
+	}
+
+	// Using Discard streams because nothing important is logged
+	if o.serviceFlag == "" {
+		o.target = debug.ComponentTarget
+		o.portForwarder = debug.NewComponentPortForwarder(o.LocalConfigProvider, "", o.Client, o.KClient, k8sgenclioptions.NewTestIOStreamsDiscard())
+	} else {
+		envInfo, ok := o.LocalConfigProvider.(*envinfo.EnvSpecificInfo)
+		if !ok {
+			return fmt.Errorf("forwarding ports to linked services is only supported by devfile components")
+		}
+		o.target, err = getLinkedServiceName(o.KClient, envInfo.GetLink(), o.serviceFlag)
+		if err != nil {
+			return err
+		}
+		o.portForwarder = debug.NewComponentPortForwarder(envInfo, o.target, nil, o.KClient, k8sgenclioptions.NewTestIOStreamsDiscard())
+	}
+	o.stopChannel = make(chan struct{}, 1)
+	return nil
+}
+
+// Validate validates the PortForwardOptions based on completed values
+func (o *PortForwardOptions) Validate() (err error) {
+	// the local ports must be free
+	for _, portPair := range o.portPairs {
+		localPort := strings.Split(portPair, ":")[0]
+		listener, err := net.Listen("tcp", "localhost:"+localPort)
+		if err != nil {
+			return fmt.Errorf("the local port %s is not free, cause: %v", localPort, err)
+		}
+		if err = listener.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run contains the logic for the odo port-forward command
+func (o *PortForwardOptions) Run(cmd *cobra.Command) (err error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	defer signal.Stop(signals)
+	defer os.RemoveAll(debug.GetPortForwardFilePath(o.portForwarder))
+
+	go func() {
+		<-signals
+		close(o.stopChannel)
+	}()
+
+	err = debug.CreatePortForwardFile(o.portForwarder, o.target, o.portPairs)
+	if err != nil {
+		return err
+	}
+
+	_, isDevfile := o.LocalConfigProvider.(*envinfo.EnvSpecificInfo)
+	return o.portForwarder.KeepForwarding(o.portPairs, o.stopChannel, isDevfile)
+}
+
+// getPortPairs returns the port pairs, in the "localPort:remotePort" format, of the [LOCAL_PORT:]REMOTE_PORT arguments
+func getPortPairs(args []string) ([]string, error) {
+	var portPairs []string
+	for _, arg := range args {
+		ports := strings.Split(arg, ":")
+		if len(ports) > 2 {
+			return nil, fmt.Errorf("the port %q should be of the format [LOCAL_PORT:]REMOTE_PORT", arg)
+		}
+		for _, port := range ports {
+			if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+				return nil, fmt.Errorf("the port %q of %q is not a valid port number", port, arg)
+			}
+		}
+		if len(ports) == 1 {
+			ports = append(ports, ports[0])
+		}
+		portPairs = append(portPairs, strings.Join(ports, ":"))
+	}
+	return portPairs, nil
+}
+
+// getLinkedServiceName returns the name of the Kubernetes service of the service linked to the component,
+// the service being given by the name of the link, the name of the service or its "<kind>/<name>" form
+func getLinkedServiceName(client *kclient.Client, links []envinfo.EnvInfoLink, service string) (string, error) {
+	var link *envinfo.EnvInfoLink
+	for i := range links {
+		if links[i].Name == service || links[i].ServiceName == service || links[i].ServiceKind+"/"+links[i].ServiceName == service {
+			link = &links[i]
+			break
+		}
+	}
+	if link == nil {
+		return "", fmt.Errorf("the service %s is not linked to the component, use 'odo link' to link it", service)
+	}
+
+	if _, err := client.GetService(link.ServiceName); err == nil {
+		return link.ServiceName, nil
+	}
+	// operators often name the Kubernetes services of their instances after the instance
+	services, err := client.ListServices("")
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, s := range services {
+		if strings.HasPrefix(s.Name, link.ServiceName+"-") {
+			names = append(names, s.Name)
+		}
+	}
+	if len(names) != 1 {
+		return "", fmt.Errorf("unable to find the Kubernetes service of the linked service %s/%s, found %d candidate services", link.ServiceKind, link.ServiceName, len(names))
+	}
+	return names[0], nil
+}
+
+// NewCmdPortForward implements the odo port-forward command
+func NewCmdPortForward(name, fullName string) *cobra.Command {
+	o := NewPortForwardOptions()
+
+	portForwardCmd := &cobra.Command{
+		Use:         fmt.Sprintf("%s [LOCAL_PORT:]REMOTE_PORT...", name),
+		Short:       "Forward one or more local ports to the component or to a linked service",
+		Long:        portForwardLongDesc,
+		Example:     fmt.Sprintf(portForwardExample, fullName),
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: map[string]string{"command": "component"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	portForwardCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
+	portForwardCmd.Flags().StringVar(&o.serviceFlag, "service", "", "Forward the ports to a pod of this linked service, given by its link name, name or <kind>/<name>")
+	genericclioptions.AddContextFlag(portForwardCmd, &o.componentContext)
+
+	return portForwardCmd
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/openshift/odo/pkg/component"
@@ -18,6 +19,7 @@ import (
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/odo/util/completion"
 	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/workspace"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -36,6 +38,9 @@ var pushCmdExample = (`  # Push source code to the current component
 
 # Push source code with custom devfile commands using --build-command and --run-command for experimental mode
 %[1]s --build-command="mybuild" --run-command="myrun"
+
+# Push all the components of the workspace, each component after the components it depends on
+%[1]s --all
   `)
 
 var pushCmdExampleExperimentalOnly = (`
@@ -60,6 +65,10 @@ type PushOptions struct {
 	devfileRunCommand   string
 	devfileDebugCommand string
 	debugRun            bool
+
+	// all pushes all the components of the workspace
+	all                 bool
+	workspaceComponents []workspace.Component
 }
 
 // NewPushOptions returns new instance of PushOptions
@@ -86,6 +95,11 @@ func (po *PushOptions) GetComponentContext() string {
 
 // Complete completes push args
 func (po *PushOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if po.all {
+		po.workspaceComponents, err = getWorkspaceComponents(cmd, args)
+		return err
+	}
+
 	po.CompleteDevfilePath()
 
 	if util.CheckPathExists(po.DevfilePath) {
@@ -215,6 +229,9 @@ func (po *PushOptions) Complete(name string, cmd *cobra.Command, args []string)
 
 // Validate validates the push parameters
 func (po *PushOptions) Validate() (err error) {
+	if po.all {
+		return nil
+	}
 
 	// If Devfile is present we do not need to validate the below S2I checks
 	// TODO: Perhaps one day move Devfile validation to here instead?
@@ -250,6 +267,10 @@ func (po *PushOptions) Validate() (err error) {
 
 // Run has the logic to perform the required actions as part of command
 func (po *PushOptions) Run(cmd *cobra.Command) (err error) {
+	if po.all {
+		return workspace.RunOrdered(po.workspaceComponents, false, os.Stdout, workspaceCommand(cmd))
+	}
+
 	// If experimental mode is enabled, use devfile push
 	if util.CheckPathExists(po.DevfilePath) {
 		// Return Devfile push
@@ -294,6 +315,7 @@ func NewCmdPush(name, fullName string) *cobra.Command {
 	pushCmd.Flags().StringVar(&po.devfileRunCommand, "run-command", "", "Devfile Run Command to execute")
 	pushCmd.Flags().BoolVar(&po.debugRun, "debug", false, "Runs the component in debug mode")
 	pushCmd.Flags().StringVar(&po.devfileDebugCommand, "debug-command", "", "Devfile Debug Command to execute")
+	pushCmd.Flags().BoolVar(&po.all, allFlagName, false, "Push all the components of the workspace concurrently, each component after the components it depends on")
 
 	//Adding `--project` flag
 	projectCmd.AddProjectFlag(pushCmd)
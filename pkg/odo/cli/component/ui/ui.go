@@ -77,6 +77,22 @@ func EnterDevfileComponentName(defaultComponentName string) string {
 	return componentName
 }
 
+// EnterStarterParameter lets the user specify the value of a parameter of the starter project in the prompt
+func EnterStarterParameter(parameter component.StarterParameter) string {
+	var value string
+	message := fmt.Sprintf("Enter the value of %s", parameter.Name)
+	if parameter.Description != "" {
+		message = fmt.Sprintf("%s (%s)", message, parameter.Description)
+	}
+	prompt := &survey.Input{
+		Message: message,
+		Default: parameter.Default,
+	}
+	err := survey.AskOne(prompt, &value, survey.Required)
+	ui.HandleError(err)
+	return value
+}
+
 // EnterDevfileComponentProject lets the user to specify the component project in the prompt
 func EnterDevfileComponentProject(defaultComponentNamespace string) string {
 	var name string
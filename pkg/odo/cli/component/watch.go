@@ -29,6 +29,7 @@ import (
 	odoutil "github.com/openshift/odo/pkg/odo/util"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/openshift/odo/pkg/watch"
+	"github.com/openshift/odo/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -41,6 +42,9 @@ var watchExampleWithDevfile = ktemplates.Examples(`  # Watch for changes in dire
 
 # Watch source code changes with custom devfile commands using --build-command, --run-command and --debug-command for devfile based components
 %[1]s --build-command="mybuild" --run-command="myrun" --debug-command="mydebug"
+
+# Push all the components of the workspace in dependency order, then watch for changes in each of them
+%[1]s --all
   `)
 
 // WatchOptions contains attributes of the watch command
@@ -67,6 +71,10 @@ type WatchOptions struct {
 	devfileRunCommand   string
 	devfileDebugCommand string
 
+	// all watches all the components of the workspace
+	all                 bool
+	workspaceComponents []workspace.Component
+
 	*genericclioptions.Context
 }
 
@@ -77,6 +85,11 @@ func NewWatchOptions() *WatchOptions {
 
 // Complete completes watch args
 func (wo *WatchOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if wo.all {
+		wo.workspaceComponents, err = getWorkspaceComponents(cmd, args)
+		return err
+	}
+
 	wo.devfilePath = filepath.Join(wo.componentContext, DevfilePath)
 
 	// if experimental mode is enabled and devfile is present
@@ -162,6 +175,10 @@ func (wo *WatchOptions) Validate() (err error) {
 		klog.V(4).Infof("delay=0 means changes will be pushed as soon as they are detected which can cause performance issues")
 	}
 
+	if wo.all {
+		return nil
+	}
+
 	// if experimental mode is enabled and devfile is present, return. The rest of the validation is for non-devfile components
 	if util.CheckPathExists(wo.devfilePath) {
 		if wo.devfileDebugCommand != "" && wo.EnvSpecificInfo != nil && wo.EnvSpecificInfo.GetRunMode() != envinfo.Debug {
@@ -207,6 +224,13 @@ func (wo *WatchOptions) Validate() (err error) {
 
 // Run has the logic to perform the required actions as part of command
 func (wo *WatchOptions) Run(cmd *cobra.Command) (err error) {
+	if wo.all {
+		// each component is pushed once the components it depends on are pushed, then watched
+		push := workspace.Command(append([]string{"push"}, workspace.ForwardedFlags(cmd.Flags(),
+			allFlagName, genericclioptions.ContextFlagName, genericclioptions.ApplicationFlagName, "delay")...)...)
+		return workspace.RunAll(wo.workspaceComponents, os.Stdout, push, workspaceCommand(cmd))
+	}
+
 	// if experimental mode is enabled and devfile is present
 	if util.CheckPathExists(wo.devfilePath) {
 
@@ -286,6 +310,7 @@ func NewCmdWatch(name, fullName string) *cobra.Command {
 	watchCmd.Flags().StringVar(&wo.devfileBuildCommand, "build-command", "", "Devfile Build Command to execute")
 	watchCmd.Flags().StringVar(&wo.devfileRunCommand, "run-command", "", "Devfile Run Command to execute")
 	watchCmd.Flags().StringVar(&wo.devfileDebugCommand, "debug-command", "", "Devfile Debug Command to execute")
+	watchCmd.Flags().BoolVar(&wo.all, allFlagName, false, "Watch all the components of the workspace, each one once it and the components it depends on are pushed")
 
 	// Adding context flag
 	genericclioptions.AddContextFlag(watchCmd, &wo.componentContext)
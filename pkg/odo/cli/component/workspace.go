@@ -0,0 +1,33 @@
+package component
+
+import (
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// allFlagName is the name of the flag running a command on all the components of the workspace
+const allFlagName = "all"
+
+// getWorkspaceComponents returns the components of the workspace of the context directory, in dependency order
+func getWorkspaceComponents(cmd *cobra.Command, args []string) ([]workspace.Component, error) {
+	if len(args) != 0 {
+		return nil, errors.Errorf("a component name can't be given with --%s", allFlagName)
+	}
+	if log.IsJSON() {
+		return nil, errors.Errorf("the machine readable output is not supported with --%s", allFlagName)
+	}
+	wi, err := workspace.Find(genericclioptions.GetContextFlagValue(cmd))
+	if err != nil {
+		return nil, err
+	}
+	return wi.GetComponents()
+}
+
+// workspaceCommand returns the command run for each component of the workspace, with the flags of cmd
+func workspaceCommand(cmd *cobra.Command) workspace.CommandFunc {
+	args := append([]string{cmd.Name()}, workspace.ForwardedFlags(cmd.Flags(), allFlagName, genericclioptions.ContextFlagName)...)
+	return workspace.Command(args...)
+}
@@ -3,13 +3,16 @@ package debug
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -20,13 +23,16 @@ type InfoOptions struct {
 	applicationName string
 	Namespace       string
 	PortForwarder   *debug.DefaultPortForwarder
+	// provider is the local configuration of the component, its devfile environment or its s2i configuration
+	provider localConfigProvider.LocalConfigProvider
 	*genericclioptions.Context
 	contextDir string
 }
 
 var (
 	infoLong = templates.LongDesc(`
-			Gets information regarding any debug session of the component.
+			Gets information regarding any debug session of the component, and the ports forwarded to the component
+			or to its linked services by 'odo port-forward'.
 	`)
 
 	infoExample = templates.Examples(`
@@ -57,6 +63,7 @@ func (o *InfoOptions) Complete(name string, cmd *cobra.Command, args []string) (
 
 		o.componentName = env.GetName()
 		o.Namespace = env.GetNamespace()
+		o.provider = env
 	} else {
 		o.Context, err = genericclioptions.NewContext(cmd)
 		if err != nil {
@@ -68,10 +75,11 @@ func (o *InfoOptions) Complete(name string, cmd *cobra.Command, args []string) (
 		o.componentName = cfg.GetName()
 		o.applicationName = cfg.GetApplication()
 		o.Namespace = cfg.GetProject()
+		o.provider = cfg
 	}
 
 	// Using Discard streams because nothing important is logged
-	o.PortForwarder = debug.NewDefaultPortForwarder(o.componentName, o.applicationName, o.Namespace, o.Client, o.KClient, k8sgenclioptions.NewTestIOStreamsDiscard())
+	o.PortForwarder = debug.NewComponentPortForwarder(o.provider, "", o.Client, o.KClient, k8sgenclioptions.NewTestIOStreamsDiscard())
 
 	return err
 }
@@ -83,18 +91,52 @@ func (o InfoOptions) Validate() error {
 
 // Run implements all the necessary functionality for port-forward cmd.
 func (o InfoOptions) Run(cmd *cobra.Command) error {
-	if debugFileInfo, debugging := debug.GetDebugInfo(o.PortForwarder); debugging {
-		if log.IsJSON() {
-			machineoutput.OutputSuccess(debugFileInfo)
-		} else {
-			log.Infof("Debug is running for the component on the local port : %v", debugFileInfo.Spec.LocalPort)
+	debugFileInfo, debugging := debug.GetDebugInfo(o.PortForwarder)
+	portForwards := debug.GetPortForwardSessions(o.PortForwarder)
+	if !debugging && len(portForwards) == 0 {
+		return fmt.Errorf("debug is not running for the component %v", o.componentName)
+	}
+
+	if log.IsJSON() {
+		if !debugging {
+			debugFileInfo.TypeMeta = metav1.TypeMeta{Kind: "OdoDebugInfo", APIVersion: "v1"}
+			debugFileInfo.ObjectMeta = metav1.ObjectMeta{Name: o.componentName, Namespace: o.Namespace}
+			debugFileInfo.Spec.App = o.applicationName
+		}
+		debugFileInfo.Spec.PortForwards = portForwards
+		machineoutput.OutputSuccess(debugFileInfo)
+		return nil
+	}
+
+	if debugging {
+		log.Infof("Debug is running for the component on the local port : %v", debugFileInfo.Spec.LocalPort)
+		if debugFileInfo.Spec.Runtime != "" {
+			log.Infof("Debug runtime : %v", debugFileInfo.Spec.Runtime)
+		}
+		for _, pair := range debugFileInfo.Spec.AdditionalPorts {
+			log.Infof("The remote port %v is forwarded to the local port : %v", pair.RemotePort, pair.LocalPort)
 		}
 	} else {
-		return fmt.Errorf("debug is not running for the component %v", o.componentName)
+		log.Infof("Debug is not running for the component %v", o.componentName)
+	}
+	for _, session := range portForwards {
+		var pairs []string
+		for _, pair := range session.PortPairs {
+			pairs = append(pairs, fmt.Sprintf("%d:%d", pair.LocalPort, pair.RemotePort))
+		}
+		log.Infof("Ports %s are forwarded to the %s by process %d", strings.Join(pairs, " "), forwardTarget(session.Target), session.ProcessID)
 	}
 	return nil
 }
 
+// forwardTarget describes the target of a port forwarding session
+func forwardTarget(target string) string {
+	if target == debug.ComponentTarget {
+		return "component"
+	}
+	return "service " + target
+}
+
 // NewCmdInfo implements the debug info odo command
 func NewCmdInfo(name, fullName string) *cobra.Command {
 
@@ -9,8 +9,12 @@ import (
 	"strconv"
 	"syscall"
 
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilelib "github.com/devfile/library/pkg/devfile"
+	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/cli/component"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
@@ -19,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/klog"
 	"k8s.io/kubectl/pkg/util/templates"
 )
 
@@ -30,6 +35,13 @@ type PortForwardOptions struct {
 
 	// PortPair is the combination of local and remote port in the format "local:remote"
 	PortPair string
+	// AdditionalPortPairs are the other debug ports of the component in the format "local:remote"
+	AdditionalPortPairs []string
+
+	// runtime is the debug runtime of the devfile component
+	runtime debug.Runtime
+	// launchConfigFlag writes a VS Code launch configuration attaching the debugger to the forwarded port
+	launchConfigFlag bool
 
 	localPort  int
 	contextDir string
@@ -45,7 +57,11 @@ type PortForwardOptions struct {
 }
 
 var (
-	portforwardLong = templates.LongDesc(`Forward a local port to a remote port on the pod where the application is listening for a debugger. By default the local port and the remote port will be same. To change the local port you can use --local-port argument and to change the remote port use "odo env set DebugPort <port>"   		  
+	portforwardLong = templates.LongDesc(`Forward a local port to a remote port on the pod where the application is listening for a debugger. By default the local port and the remote port will be same. To change the local port you can use --local-port argument and to change the remote port use "odo env set DebugPort <port>"
+
+	For devfile components, the endpoints whose name starts with "debug" of the container running the debug command are forwarded too.
+	The debug runtime (nodejs, java, go or python) is read from the "odo.dev/debug-runtime" attribute of the debug command or of the devfile metadata, or guessed from the language of the devfile.
+	With --launch-config, a configuration attaching the debugger of the runtime to the forwarded port is added to the .vscode/launch.json file of the component, the project sources being mapped to the PROJECT_SOURCE directory of the component containers.
 	`)
 
 	portforwardExample = templates.Examples(`
@@ -54,6 +70,9 @@ var (
 
 		# Listen on the 5000 port locally, forwarding to default port in the pod
 		odo debug port-forward --local-port 5000
+
+		# Listen on default port and add a launch configuration attaching VS Code to it
+		odo debug port-forward --launch-config
 		
 		`)
 )
@@ -86,6 +105,10 @@ func (o *PortForwardOptions) Complete(name string, cmd *cobra.Command, args []st
 		o.componentName = env.GetName()
 		o.Namespace = env.GetNamespace()
 
+		err = o.completeDebugRuntime(remotePort)
+		if err != nil {
+			return err
+		}
 	} else {
 		// this populates the LocalConfigInfo
 		o.Context, err = genericclioptions.NewContext(cmd)
@@ -135,12 +158,66 @@ func (o *PortForwardOptions) Complete(name string, cmd *cobra.Command, args []st
 	return err
 }
 
+// completeDebugRuntime reads the debug runtime and the additional debug ports of the devfile component
+func (o *PortForwardOptions) completeDebugRuntime(debugPort int) error {
+	devObj, err := devfilelib.ParseDevfileAndValidate(parser.ParserArgs{Path: o.devfilePath})
+	if err != nil {
+		return err
+	}
+	debugCommand, err := common.GetDebugCommand(devObj.Data, "")
+	if err != nil {
+		// the ports can still be forwarded without a debug command, the runtime is then guessed from the devfile metadata
+		klog.V(4).Infof("no debug command found in the devfile: %v", err)
+		debugCommand = devfilev1.Command{}
+	}
+
+	o.runtime, err = debug.GetRuntime(devObj, debugCommand)
+	if err != nil {
+		return err
+	}
+
+	ports, err := debug.GetDebugEndpointPorts(devObj, debugCommand, debugPort)
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		localPort := port
+		if !isLocalPortFree(localPort) {
+			localPort, err = util.HTTPGetFreePort()
+			if err != nil {
+				return err
+			}
+			log.Infof("The local port %v is auto selected for the debug port %v", localPort, port)
+		}
+		o.AdditionalPortPairs = append(o.AdditionalPortPairs, fmt.Sprintf("%d:%d", localPort, port))
+	}
+	return nil
+}
+
+// isLocalPortFree returns true if nothing listens on the local port
+func isLocalPortFree(port int) bool {
+	listener, err := net.Listen("tcp", "localhost:"+strconv.Itoa(port))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
 // Validate validates all the required options for port-forward cmd.
 func (o PortForwardOptions) Validate() error {
 
 	if len(o.PortPair) < 1 {
 		return fmt.Errorf("ports cannot be empty")
 	}
+	if o.launchConfigFlag {
+		if !util.CheckPathExists(o.devfilePath) {
+			return fmt.Errorf("launch configurations can only be generated for devfile components")
+		}
+		if o.runtime == "" {
+			return fmt.Errorf("unable to guess the debug runtime of component %s, set the %s attribute of the devfile to one of %v", o.componentName, debug.RuntimeAttribute, debug.Runtimes())
+		}
+	}
 	return nil
 }
 
@@ -163,12 +240,38 @@ func (o PortForwardOptions) Run(cmd *cobra.Command) error {
 		}
 	}()
 
-	err := debug.CreateDebugInfoFile(o.PortForwarder, o.PortPair)
+	portPairs := append([]string{o.PortPair}, o.AdditionalPortPairs...)
+	err := debug.CreateDebugInfoFile(o.PortForwarder, o.runtime, portPairs...)
 	if err != nil {
 		return err
 	}
 
-	return o.PortForwarder.ForwardPorts(o.PortPair, o.StopChannel, o.ReadyChannel, util.CheckPathExists(o.devfilePath))
+	if o.launchConfigFlag {
+		err = o.writeLaunchConfiguration()
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.PortForwarder.ForwardPortPairs(portPairs, o.StopChannel, o.ReadyChannel, util.CheckPathExists(o.devfilePath))
+}
+
+// writeLaunchConfiguration adds the launch configuration attaching the debugger to the forwarded port to the launch file of the component
+func (o PortForwardOptions) writeLaunchConfiguration() error {
+	remoteRoot, err := o.PortForwarder.GetProjectSource()
+	if err != nil {
+		return err
+	}
+	configuration, err := debug.NewLaunchConfiguration(o.runtime, o.componentName, o.localPort, remoteRoot)
+	if err != nil {
+		return err
+	}
+	err = debug.UpdateLaunchFile(o.contextDir, configuration)
+	if err != nil {
+		return err
+	}
+	log.Infof("Added the launch configuration %q to %s", configuration["name"], debug.GetLaunchFilePath(o.contextDir))
+	return nil
 }
 
 // NewCmdPortForward implements the port-forward odo command
@@ -187,6 +290,7 @@ func NewCmdPortForward(name, fullName string) *cobra.Command {
 
 	genericclioptions.AddContextFlag(cmd, &opts.contextDir)
 	cmd.Flags().IntVarP(&opts.localPort, "local-port", "l", config.DefaultDebugPort, "Set the local port")
+	cmd.Flags().BoolVar(&opts.launchConfigFlag, "launch-config", false, "Add a configuration attaching the debugger to the forwarded port to the .vscode/launch.json file of the component")
 
 	return cmd
 }
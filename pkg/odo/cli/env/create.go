@@ -0,0 +1,115 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const createCommandName = "create"
+
+var (
+	createLongDesc = ktemplates.LongDesc(`
+	Create an environment of the component
+
+	The environment gets the name, the application and the source volume settings of the current environment.
+	The URLs, the links and the debug settings are kept per environment, the new environment has none.
+	`)
+
+	createExample = ktemplates.Examples(`
+	# Create an environment deploying the component to the team-shared project
+	%[1]s team --project team-shared
+
+	# Push the component to the team environment
+	odo push --environment team
+	`)
+)
+
+// CreateOptions encapsulates the options for the command
+type CreateOptions struct {
+	context         string
+	cfg             *envinfo.EnvSpecificInfo
+	environmentName string
+	projectFlag     string
+	appFlag         string
+}
+
+// NewCreateOptions creates a new CreateOptions instance
+func NewCreateOptions() *CreateOptions {
+	return &CreateOptions{}
+}
+
+// Complete completes CreateOptions after they've been created
+func (o *CreateOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.cfg, err = envinfo.NewEnvSpecificInfo(o.context)
+	if err != nil {
+		return errors.Wrap(err, "failed to load environment file")
+	}
+	o.environmentName = args[0]
+
+	return nil
+}
+
+// Validate validates the CreateOptions based on completed values
+func (o *CreateOptions) Validate() (err error) {
+	if !o.cfg.Exists() {
+		return errors.Errorf("the context directory doesn't contain a component, please refer `odo create --help` on how to create a component")
+	}
+
+	return nil
+}
+
+// Run contains the logic for the command
+func (o *CreateOptions) Run(cmd *cobra.Command) (err error) {
+	current := o.cfg.GetComponentSettings()
+	cs := envinfo.ComponentSettings{
+		Name:               current.Name,
+		Project:            current.Project,
+		AppName:            current.AppName,
+		UserCreatedDevfile: current.UserCreatedDevfile,
+		Provenance:         current.Provenance,
+		SourceVolumeMode:   current.SourceVolumeMode,
+		SourceVolumeSize:   current.SourceVolumeSize,
+	}
+	if o.projectFlag != "" {
+		cs.Project = o.projectFlag
+	}
+	if o.appFlag != "" {
+		cs.AppName = o.appFlag
+	}
+
+	err = o.cfg.CreateEnvironment(o.environmentName, cs)
+	if err != nil {
+		return err
+	}
+
+	log.Successf("Created the environment %q", o.environmentName)
+	log.Italicf("Run `odo env use %s` to make it the active environment, or use the --environment %s flag", o.environmentName, o.environmentName)
+	return nil
+}
+
+// NewCmdCreate implements the env create odo command
+func NewCmdCreate(name, fullName string) *cobra.Command {
+	o := NewCreateOptions()
+	envCreateCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s NAME", name),
+		Short:   "Create an environment of the component",
+		Long:    createLongDesc,
+		Example: fmt.Sprintf(fmt.Sprint(createExample), fullName),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	envCreateCmd.Flags().StringVar(&o.projectFlag, "project", "", "Project of the component in the environment (Default: the project of the current environment)")
+	envCreateCmd.Flags().StringVar(&o.appFlag, "app", "", "Application of the component in the environment (Default: the application of the current environment)")
+	envCreateCmd.Flags().StringVar(&o.context, "context", "", "Use given context directory as a source for component settings")
+
+	return envCreateCmd
+}
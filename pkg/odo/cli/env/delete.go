@@ -0,0 +1,98 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/ui"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const deleteCommandName = "delete"
+
+var (
+	deleteLongDesc = ktemplates.LongDesc(`
+	Delete an environment of the component
+
+	The settings of the environment are deleted from the environment file, the component
+	deployed in the environment is not deleted from the cluster.
+	`)
+
+	deleteExample = ktemplates.Examples(`
+	# Delete the demo environment
+	%[1]s demo
+	`)
+)
+
+// DeleteOptions encapsulates the options for the command
+type DeleteOptions struct {
+	context         string
+	cfg             *envinfo.EnvSpecificInfo
+	environmentName string
+	forceFlag       bool
+}
+
+// NewDeleteOptions creates a new DeleteOptions instance
+func NewDeleteOptions() *DeleteOptions {
+	return &DeleteOptions{}
+}
+
+// Complete completes DeleteOptions after they've been created
+func (o *DeleteOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.cfg, err = envinfo.NewEnvSpecificInfo(o.context)
+	if err != nil {
+		return errors.Wrap(err, "failed to load environment file")
+	}
+	o.environmentName = args[0]
+
+	return nil
+}
+
+// Validate validates the DeleteOptions based on completed values
+func (o *DeleteOptions) Validate() (err error) {
+	if !o.cfg.Exists() {
+		return errors.Errorf("the context directory doesn't contain a component, please refer `odo create --help` on how to create a component")
+	}
+
+	return nil
+}
+
+// Run contains the logic for the command
+func (o *DeleteOptions) Run(cmd *cobra.Command) (err error) {
+	if !o.forceFlag && !ui.Proceed(fmt.Sprintf("Are you sure you want to delete the environment %q", o.environmentName)) {
+		log.Info("Aborted by the user")
+		return nil
+	}
+
+	err = o.cfg.DeleteEnvironment(o.environmentName)
+	if err != nil {
+		return err
+	}
+
+	log.Successf("Deleted the environment %q", o.environmentName)
+	return nil
+}
+
+// NewCmdDelete implements the env delete odo command
+func NewCmdDelete(name, fullName string) *cobra.Command {
+	o := NewDeleteOptions()
+	envDeleteCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s NAME", name),
+		Short:   "Delete an environment of the component",
+		Long:    deleteLongDesc,
+		Example: fmt.Sprintf(fmt.Sprint(deleteExample), fullName),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	envDeleteCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Don't ask for confirmation, delete the environment directly")
+	envDeleteCmd.Flags().StringVar(&o.context, "context", "", "Use given context directory as a source for component settings")
+
+	return envDeleteCmd
+}
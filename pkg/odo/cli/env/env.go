@@ -23,25 +23,35 @@ const (
 	debugportParameterDescription = "Use this value to set component debug port"
 )
 
-var envLongDesc = ktemplates.LongDesc(`Modifies odo specific configuration settings within environment file`)
+var envLongDesc = ktemplates.LongDesc(`Modifies odo specific configuration settings within environment file
+
+The environment file can hold several named environments, to deploy the component to several projects or clusters.
+The commands use the active environment, or the environment selected with the --environment flag or the ODO_ENV environment variable.
+The --environment flag can be shortened to --env, except on the commands using --env for the environment variables of the component, such as odo create.`)
 
 // NewCmdEnv implements the environment configuration command
 func NewCmdEnv(name, fullName string) *cobra.Command {
 	envViewCmd := NewCmdView(viewCommandName, util.GetFullName(fullName, viewCommandName))
 	envSetCmd := NewCmdSet(setCommandName, util.GetFullName(fullName, setCommandName))
 	envUnsetCmd := NewCmdUnset(unsetCommandName, util.GetFullName(fullName, unsetCommandName))
+	envListCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
+	envUseCmd := NewCmdUse(useCommandName, util.GetFullName(fullName, useCommandName))
+	envCreateCmd := NewCmdCreate(createCommandName, util.GetFullName(fullName, createCommandName))
+	envDeleteCmd := NewCmdDelete(deleteCommandName, util.GetFullName(fullName, deleteCommandName))
 	envCmd := &cobra.Command{
 		Use:   name,
 		Short: "Change or view environment configuration",
 		Long:  envLongDesc,
-		Example: fmt.Sprintf("%s\n\n%s\n\n%s",
+		Example: fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s",
 			envViewCmd.Example,
 			envSetCmd.Example,
 			envUnsetCmd.Example,
+			envCreateCmd.Example,
+			envUseCmd.Example,
 		),
 	}
 
-	envCmd.AddCommand(envViewCmd, envSetCmd, envUnsetCmd)
+	envCmd.AddCommand(envViewCmd, envSetCmd, envUnsetCmd, envListCmd, envUseCmd, envCreateCmd, envDeleteCmd)
 	envCmd.SetUsageTemplate(util.CmdUsageTemplate)
 	envCmd.Annotations = map[string]string{"command": "main"}
 
@@ -0,0 +1,100 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const listCommandName = "list"
+
+var (
+	listLongDesc = ktemplates.LongDesc(`
+	List the environments of the component, the active environment is marked with *
+	`)
+
+	listExample = ktemplates.Examples(`
+	# List the environments of the component
+	%[1]s
+	`)
+)
+
+// ListOptions encapsulates the options for the command
+type ListOptions struct {
+	context string
+	cfg     *envinfo.EnvSpecificInfo
+}
+
+// NewListOptions creates a new ListOptions instance
+func NewListOptions() *ListOptions {
+	return &ListOptions{}
+}
+
+// Complete completes ListOptions after they've been created
+func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.cfg, err = envinfo.NewEnvSpecificInfo(o.context)
+	if err != nil {
+		return errors.Wrap(err, "failed to load environment file")
+	}
+
+	return nil
+}
+
+// Validate validates the ListOptions based on completed values
+func (o *ListOptions) Validate() (err error) {
+	if !o.cfg.Exists() {
+		return errors.Errorf("the context directory doesn't contain a component, please refer `odo create --help` on how to create a component")
+	}
+
+	return nil
+}
+
+// Run contains the logic for the command
+func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(envinfo.NewEnvironmentList(&o.cfg.EnvInfo))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "ACTIVE", "\t", "NAME", "\t", "PROJECT", "\t", "APPLICATION")
+	for _, environment := range o.cfg.ListEnvironments() {
+		active := ""
+		if environment.Name == o.cfg.GetActiveEnvironment() {
+			active = "*"
+		}
+		cs := environment.ComponentSettings
+		fmt.Fprintln(w, active, "\t", environment.Name, "\t", cs.Project, "\t", cs.AppName)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// NewCmdList implements the env list odo command
+func NewCmdList(name, fullName string) *cobra.Command {
+	o := NewListOptions()
+	envListCmd := &cobra.Command{
+		Use:         name,
+		Short:       "List the environments of the component",
+		Long:        listLongDesc,
+		Example:     fmt.Sprintf(fmt.Sprint(listExample), fullName),
+		Annotations: map[string]string{"machineoutput": "json"},
+
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	envListCmd.Flags().StringVar(&o.context, "context", "", "Use given context directory as a source for component settings")
+
+	return envListCmd
+}
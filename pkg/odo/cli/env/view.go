@@ -67,6 +67,7 @@ func (o *ViewOptions) Run(cmd *cobra.Command) (err error) {
 	}
 	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
 	fmt.Fprintln(w, "PARAMETER NAME", "\t", "PARAMETER VALUE")
+	fmt.Fprintln(w, "Environment", "\t", o.cfg.GetEnvironment())
 	fmt.Fprintln(w, "Name", "\t", cs.Name)
 	fmt.Fprintln(w, "Project", "\t", cs.Project)
 	fmt.Fprintln(w, "Application", "\t", cs.AppName)
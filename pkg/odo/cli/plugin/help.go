@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift/odo/pkg/odo/cli/plugins"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const helpCommandName = "help"
+
+var (
+	helpLongDesc = ktemplates.LongDesc(`Print the help of an odo plugin.
+
+The plugin is run with the --help flag, followed by the subcommand if any.`)
+
+	helpExample = ktemplates.Examples(`  # Print the help of the plugin run for 'odo deploy'
+  %[1]s deploy
+
+  # Print the help of the start subcommand of the plugin
+  %[1]s deploy start
+`)
+)
+
+// HelpOptions encapsulates the options for the odo plugin help command
+type HelpOptions struct {
+	args []string
+}
+
+// NewHelpOptions creates a new HelpOptions instance
+func NewHelpOptions() *HelpOptions {
+	return &HelpOptions{}
+}
+
+// Complete completes HelpOptions after they've been created
+func (o *HelpOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.args = args
+	// the built-in commands are run instead of the plugins with the same name
+	if found, _, err := cmd.Root().Find(args); err == nil && found != cmd.Root() {
+		return fmt.Errorf("%q is a built-in command, see `%s --help`", strings.Join(args, " "), found.CommandPath())
+	}
+	return
+}
+
+// Validate validates the HelpOptions based on completed values
+func (o *HelpOptions) Validate() (err error) {
+	return
+}
+
+// Run contains the logic for the odo plugin help command
+func (o *HelpOptions) Run(cmd *cobra.Command) (err error) {
+	return plugins.HandleHelp(plugins.NewExecHandler(plugins.Prefix), o.args)
+}
+
+// NewCmdHelp implements the odo plugin help command
+func NewCmdHelp(name, fullName string) *cobra.Command {
+	o := NewHelpOptions()
+	pluginHelpCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s NAME [SUBCOMMAND]...", name),
+		Short:   "Print the help of an odo plugin",
+		Long:    helpLongDesc,
+		Example: fmt.Sprintf(helpExample, fullName),
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	pluginHelpCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	return pluginHelpCmd
+}
@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/plugins"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const listCommandName = "list"
+
+var (
+	listLongDesc = ktemplates.LongDesc(`List the odo plugins found on the PATH.
+
+The plugins which are never run are reported: a plugin is shadowed by a built-in command with the same name, or by a plugin with the same name found earlier on the PATH.`)
+
+	listExample = ktemplates.Examples(`  # List the odo plugins
+  %[1]s
+`)
+)
+
+// ListOptions encapsulates the options for the odo plugin list command
+type ListOptions struct {
+	plugins []plugins.Plugin
+}
+
+// NewListOptions creates a new ListOptions instance
+func NewListOptions() *ListOptions {
+	return &ListOptions{}
+}
+
+// Complete completes ListOptions after they've been created
+func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.plugins = plugins.NewExecHandler(plugins.Prefix).List(cmd.Root())
+	return
+}
+
+// Validate validates the ListOptions based on completed values
+func (o *ListOptions) Validate() (err error) {
+	return
+}
+
+// Run contains the logic for the odo plugin list command
+func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(plugins.NewPluginList(o.plugins))
+		return
+	}
+
+	if len(o.plugins) == 0 {
+		log.Info("No odo plugins found on the PATH")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME", "\t", "DESCRIPTION", "\t", "PATH")
+	for _, plugin := range o.plugins {
+		fmt.Fprintln(w, plugin.Name, "\t", plugin.Description, "\t", plugin.Path)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	for _, plugin := range o.plugins {
+		if plugin.ShadowedBy != "" {
+			log.Warningf("The plugin %s is never run, it is shadowed by %s", plugin.Path, plugin.ShadowedBy)
+		}
+	}
+	return
+}
+
+// NewCmdList implements the odo plugin list command
+func NewCmdList(name, fullName string) *cobra.Command {
+	o := NewListOptions()
+	pluginListCmd := &cobra.Command{
+		Use:         name,
+		Short:       "List the odo plugins",
+		Long:        listLongDesc,
+		Example:     fmt.Sprintf(listExample, fullName),
+		Annotations: map[string]string{"machineoutput": "all"},
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	pluginListCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	return pluginListCmd
+}
@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/odo/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended plugin command name
+const RecommendedCommandName = "plugin"
+
+var pluginLongDesc = ktemplates.LongDesc(`Manage the odo plugins.
+
+A plugin is an executable named odo-<command> found on the PATH, which odo runs for 'odo <command>' when <command> is not a built-in command.
+The dashes of the executable name separate the words of the command: odo-foo-bar is run for 'odo foo bar', and odo-foo_bar for 'odo foo-bar'.
+
+A plugin can be described by a YAML file next to its executable, odo-<command>.yaml, with the description of the plugin and the subcommands completed by the shell:
+
+  description: Deploy the component with the team pipeline
+  commands:
+  - name: start
+    description: Start the pipeline
+
+The help of a plugin is printed by 'odo plugin help', which runs the plugin with the --help flag.
+
+The plugins are run with the arguments following the command, in the environment of odo extended with:
+
+  ODO_BINARY: the path of the odo executable
+  ODO_CONTEXT: the absolute path of the component directory, given by the --context flag or the current directory
+  ODO_COMPONENT, ODO_APP and ODO_PROJECT: the component, application and project of the component directory, empty when the directory has no component
+  ODO_OUTPUT: the output format requested with the -o flag, empty for the human readable output`)
+
+// NewCmdPlugin implements the plugin odo command
+func NewCmdPlugin(name, fullName string) *cobra.Command {
+	pluginListCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
+	pluginHelpCmd := NewCmdHelp(helpCommandName, util.GetFullName(fullName, helpCommandName))
+
+	pluginCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Manage the odo plugins",
+		Long:    pluginLongDesc,
+		Example: fmt.Sprintf("%s\n\n%s\n", pluginListCmd.Example, pluginHelpCmd.Example),
+	}
+
+	pluginCmd.AddCommand(pluginListCmd, pluginHelpCmd)
+	pluginCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	pluginCmd.Annotations = map[string]string{"command": "utility"}
+
+	return pluginCmd
+}
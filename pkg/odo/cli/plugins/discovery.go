@@ -0,0 +1,152 @@
+package plugins
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// metadataExtension is the extension of the metadata file of a plugin, next to its executable:
+// the metadata of /usr/local/bin/odo-foo is in /usr/local/bin/odo-foo.yaml
+const metadataExtension = ".yaml"
+
+// Plugin is an executable found on the PATH which odo runs for one of its commands
+type Plugin struct {
+	// Name is the odo command running the plugin, e.g. "foo bar" for the odo-foo-bar executable
+	Name string `json:"name"`
+	// Path is the path of the executable
+	Path string `json:"path"`
+	// Metadata is read from the metadata file of the plugin, if any
+	Metadata `json:",inline"`
+	// ShadowedBy is the built-in command, or the path of the plugin, run instead of this plugin
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+}
+
+// Metadata describes a plugin and its subcommands
+type Metadata struct {
+	Description string            `yaml:"description" json:"description,omitempty"`
+	Commands    []MetadataCommand `yaml:"commands" json:"commands,omitempty"`
+}
+
+// MetadataCommand is a subcommand of a plugin, used for the shell completion
+type MetadataCommand struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
+// List returns the plugins found on the PATH, in the order of the PATH; the plugins which are never run, as a
+// built-in command of root or a plugin earlier in the PATH has the same name, are marked as shadowed
+func (h *ExecHandler) List(root *cobra.Command) []Plugin {
+	plugins := findPlugins(h.Prefix, filepath.SplitList(os.Getenv("PATH")))
+	for i := range plugins {
+		if plugins[i].ShadowedBy != "" {
+			continue
+		}
+		// the plugins are only looked up when the command line doesn't match a built-in command
+		cmd, _, err := root.Find(strings.Split(plugins[i].Name, " "))
+		if err == nil && cmd != root {
+			plugins[i].ShadowedBy = cmd.CommandPath()
+		}
+	}
+	return plugins
+}
+
+// findPlugins returns the plugins with the prefix found in the directories, the plugins with the same name as a
+// plugin of a previous directory being marked as shadowed
+func findPlugins(prefix string, dirs []string) []Plugin {
+	var plugins []Plugin
+	found := map[string]string{}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			klog.V(4).Infof("unable to read the directory %s of the PATH: %v", dir, err)
+			continue
+		}
+		for _, file := range files {
+			name, ok := pluginName(prefix, file)
+			if !ok {
+				continue
+			}
+			plugin := Plugin{Name: name, Path: filepath.Join(dir, file.Name())}
+			plugin.Metadata = readMetadata(plugin.Path)
+			if path, ok := found[name]; ok {
+				plugin.ShadowedBy = path
+			} else {
+				found[name] = plugin.Path
+			}
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// pluginName returns the odo command running the executable file, or false if the file is not a plugin: the
+// dashes of the executable name separate the words of the command, the underscores being dashes in the command
+func pluginName(prefix string, file os.FileInfo) (string, bool) {
+	name := file.Name()
+	if file.IsDir() || !strings.HasPrefix(name, prefix+"-") || strings.HasSuffix(name, metadataExtension) {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		if !strings.HasSuffix(name, ".exe") {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ".exe")
+	} else if file.Mode()&0111 == 0 {
+		return "", false
+	}
+
+	var words []string
+	for _, word := range strings.Split(strings.TrimPrefix(name, prefix+"-"), "-") {
+		if word == "" {
+			return "", false
+		}
+		words = append(words, strings.Replace(word, "_", "-", -1))
+	}
+	return strings.Join(words, " "), true
+}
+
+// readMetadata returns the metadata of the plugin, empty if the plugin has no valid metadata file
+func readMetadata(path string) Metadata {
+	var metadata Metadata
+	data, err := ioutil.ReadFile(strings.TrimSuffix(path, ".exe") + metadataExtension)
+	if err != nil {
+		return metadata
+	}
+	if err = yaml.Unmarshal(data, &metadata); err != nil {
+		klog.V(4).Infof("unable to read the metadata of the plugin %s: %v", path, err)
+		return Metadata{}
+	}
+	return metadata
+}
+
+// PluginList is the machine readable output of the plugins
+type PluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []Plugin `json:"items"`
+}
+
+// NewPluginList returns the machine readable output of the plugins
+func NewPluginList(plugins []Plugin) PluginList {
+	if plugins == nil {
+		plugins = []Plugin{}
+	}
+	return PluginList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: machineoutput.APIVersion,
+		},
+		Items: plugins,
+	}
+}
@@ -0,0 +1,65 @@
+package plugins
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestFindPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are .exe files on Windows")
+	}
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	first, second := filepath.Join(dir, "first"), filepath.Join(dir, "second")
+
+	files := []struct {
+		path string
+		data string
+		mode os.FileMode
+	}{
+		{path: filepath.Join(first, "odo-hello"), mode: 0755},
+		{path: filepath.Join(first, "odo-hello.yaml"), data: "description: Say hello\ncommands:\n- name: world\n", mode: 0644},
+		{path: filepath.Join(first, "odo-deploy-team_x"), mode: 0755},
+		{path: filepath.Join(first, "odo-notexecutable"), mode: 0644},
+		{path: filepath.Join(first, "kubectl-hello"), mode: 0755},
+		{path: filepath.Join(second, "odo-hello"), mode: 0755},
+	}
+	for _, file := range files {
+		if err = os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(file.path, []byte(file.data), file.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := findPlugins("odo", []string{first, "", filepath.Join(dir, "missing"), second})
+
+	want := []Plugin{
+		{
+			Name: "deploy team-x",
+			Path: filepath.Join(first, "odo-deploy-team_x"),
+		},
+		{
+			Name:     "hello",
+			Path:     filepath.Join(first, "odo-hello"),
+			Metadata: Metadata{Description: "Say hello", Commands: []MetadataCommand{{Name: "world"}}},
+		},
+		{
+			Name:       "hello",
+			Path:       filepath.Join(second, "odo-hello"),
+			ShadowedBy: filepath.Join(first, "odo-hello"),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got the plugins %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift/odo/pkg/config"
+	"github.com/openshift/odo/pkg/envinfo"
+	"k8s.io/klog"
+)
+
+// The environment variables set by odo when it runs a plugin, in addition to its own environment
+const (
+	// BinaryEnv is the path of the odo executable running the plugin
+	BinaryEnv = "ODO_BINARY"
+	// ContextEnv is the absolute path of the component directory, given by the --context flag or the current directory
+	ContextEnv = "ODO_CONTEXT"
+	// ComponentEnv is the name of the component of the context directory, empty if there is no component
+	ComponentEnv = "ODO_COMPONENT"
+	// ApplicationEnv is the application of the component of the context directory
+	ApplicationEnv = "ODO_APP"
+	// ProjectEnv is the project of the component of the context directory
+	ProjectEnv = "ODO_PROJECT"
+	// OutputEnv is the output format requested with the -o flag, empty for the human readable output
+	OutputEnv = "ODO_OUTPUT"
+)
+
+// pluginEnvironment returns the variables of the environment contract of the plugins, for the plugin arguments
+func pluginEnvironment(args []string) map[string]string {
+	env := map[string]string{
+		ContextEnv:     flagValue(args, "context"),
+		OutputEnv:      flagValue(args, "o"),
+		ComponentEnv:   "",
+		ApplicationEnv: "",
+		ProjectEnv:     "",
+	}
+	if binary, err := os.Executable(); err == nil {
+		env[BinaryEnv] = binary
+	}
+
+	contextDir, err := filepath.Abs(env[ContextEnv])
+	if err != nil {
+		klog.V(4).Infof("unable to get the context directory of the plugin: %v", err)
+		return env
+	}
+	env[ContextEnv] = contextDir
+
+	// the devfile components have an env.yaml file, the s2i components a config.yaml file
+	if envInfo, err := envinfo.NewEnvSpecificInfo(contextDir); err == nil && envInfo.Exists() {
+		env[ComponentEnv] = envInfo.GetName()
+		env[ApplicationEnv] = envInfo.GetApplication()
+		env[ProjectEnv] = envInfo.GetNamespace()
+	} else if localConfig, err := config.NewLocalConfigInfo(contextDir); err == nil && localConfig.Exists() {
+		env[ComponentEnv] = localConfig.GetName()
+		env[ApplicationEnv] = localConfig.GetApplication()
+		env[ProjectEnv] = localConfig.GetProject()
+	}
+	return env
+}
+
+// flagValue returns the value of the flag in the arguments, given as -name value, -name=value, --name value or
+// --name=value, empty if the flag is not given
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+		if trimmed == name && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(trimmed, name+"=") {
+			return strings.TrimPrefix(trimmed, name+"=")
+		}
+	}
+	return ""
+}
+
+// mergeEnvironment returns the environment with the variables set to the values, replacing their current values:
+// with the exec syscall, the first value of a variable would be used
+func mergeEnvironment(environ []string, values map[string]string) []string {
+	var merged []string
+	for _, variable := range environ {
+		name := strings.SplitN(variable, "=", 2)[0]
+		if _, ok := values[name]; !ok {
+			merged = append(merged, variable)
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		merged = append(merged, name+"="+values[name])
+	}
+	return merged
+}
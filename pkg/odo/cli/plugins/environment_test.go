@@ -0,0 +1,93 @@
+package plugins
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFlagValue(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		flag string
+		want string
+	}{
+		{
+			name: "Case 1: Flag with a separate value",
+			args: []string{"deploy", "--context", "frontend"},
+			flag: "context",
+			want: "frontend",
+		},
+		{
+			name: "Case 2: Short flag with an inline value",
+			args: []string{"-o=json", "deploy"},
+			flag: "o",
+			want: "json",
+		},
+		{
+			name: "Case 3: Missing flag",
+			args: []string{"deploy", "--output", "json"},
+			flag: "o",
+			want: "",
+		},
+		{
+			name: "Case 4: Flag after the end of the flags",
+			args: []string{"deploy", "--", "-o", "json"},
+			flag: "o",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flagValue(tt.args, tt.flag); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluginEnvironment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "component")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	envFile := filepath.Join(dir, ".odo", "env", "env.yaml")
+	if err = os.MkdirAll(filepath.Dir(envFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	envInfo := "ComponentSettings:\n  Name: frontend\n  Project: team\n  AppName: shop\n"
+	if err = ioutil.WriteFile(envFile, []byte(envInfo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := pluginEnvironment([]string{"deploy", "--context", dir, "-o", "json"})
+
+	want := map[string]string{
+		ContextEnv:     dir,
+		ComponentEnv:   "frontend",
+		ApplicationEnv: "shop",
+		ProjectEnv:     "team",
+		OutputEnv:      "json",
+	}
+	for name, value := range want {
+		if env[name] != value {
+			t.Errorf("got %s=%q, want %q", name, env[name], value)
+		}
+	}
+	if env[BinaryEnv] == "" {
+		t.Errorf("%s is not set", BinaryEnv)
+	}
+
+	merged := mergeEnvironment([]string{"HOME=/home/user", ComponentEnv + "=stale"}, env)
+	for _, variable := range merged {
+		if variable == ComponentEnv+"=stale" {
+			t.Errorf("the inherited %s is not replaced", ComponentEnv)
+		}
+	}
+	if merged[0] != "HOME=/home/user" || len(merged) != len(env)+1 {
+		t.Errorf("unexpected environment %v", merged)
+	}
+}
@@ -11,18 +11,33 @@ import (
 
 // HandleCommand receives a PluginHandler and command-line arguments and attempts to find
 // a plugin executable on the PATH that satisfies the given arguments.
+// The plugin is run with the variables of the environment contract of the plugins, see pluginEnvironment.
 func HandleCommand(handler PluginHandler, args []string) error {
 	foundBinary, remaining := findBinary(handler, args)
 	if foundBinary == "" {
 		return nil
 	}
 
-	if err := handler.Execute(foundBinary, args[len(remaining):], os.Environ()); err != nil {
+	pluginArgs := args[len(remaining):]
+	env := mergeEnvironment(os.Environ(), pluginEnvironment(pluginArgs))
+	if err := handler.Execute(foundBinary, pluginArgs, env); err != nil {
 		return err
 	}
 	return nil
 }
 
+// HandleHelp runs the plugin implementing the command given by args with the --help flag,
+// it returns an error if no plugin executable on the PATH implements the command
+func HandleHelp(handler PluginHandler, args []string) error {
+	if foundBinary, _ := findBinary(handler, args); foundBinary == "" {
+		return fmt.Errorf("no plugin found for the command %q, see `odo plugin list` for the list of the plugins", strings.Join(args, " "))
+	}
+	return HandleCommand(handler, append(append([]string{}, args...), "--help"))
+}
+
+// Prefix is the prefix of the names of the odo plugin executables, odo-<command>
+const Prefix = "odo"
+
 type execFunc func(string, []string, []string) (err error)
 
 // NewExecHandler creates and returns a new ExecHandler configured with
@@ -0,0 +1,72 @@
+package plugins
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeHandler is a PluginHandler knowing the plugins with the given paths, it records the executed plugin
+type fakeHandler struct {
+	plugins  map[string]string
+	executed string
+	args     []string
+}
+
+func (h *fakeHandler) Lookup(command string) string {
+	return h.plugins[command]
+}
+
+func (h *fakeHandler) Execute(filename string, args, env []string) error {
+	h.executed = filename
+	h.args = args
+	return nil
+}
+
+func TestHandleHelp(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantExecuted string
+		wantArgs     []string
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: Plugin command",
+			args:         []string{"deploy"},
+			wantExecuted: "/usr/bin/odo-deploy",
+			wantArgs:     []string{"--help"},
+		},
+		{
+			name:         "Case 2: Subcommand of a plugin",
+			args:         []string{"deploy", "start"},
+			wantExecuted: "/usr/bin/odo-deploy",
+			wantArgs:     []string{"start", "--help"},
+		},
+		{
+			name:         "Case 3: Plugin of a nested command",
+			args:         []string{"team", "deploy"},
+			wantExecuted: "/usr/bin/odo-team-deploy",
+			wantArgs:     []string{"--help"},
+		},
+		{
+			name:    "Case 4: Unknown plugin",
+			args:    []string{"unknown"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeHandler{plugins: map[string]string{
+				"deploy":      "/usr/bin/odo-deploy",
+				"team-deploy": "/usr/bin/odo-team-deploy",
+			}}
+			err := HandleHelp(handler, tt.args)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got the error %v, want an error: %t", err, tt.wantErr)
+			}
+			if handler.executed != tt.wantExecuted || !reflect.DeepEqual(handler.args, tt.wantArgs) {
+				t.Errorf("got %s run with %v, want %s run with %v", handler.executed, handler.args, tt.wantExecuted, tt.wantArgs)
+			}
+		})
+	}
+}
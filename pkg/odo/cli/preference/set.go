@@ -74,6 +74,7 @@ func (o *SetOptions) Run(cmd *cobra.Command) (err error) {
 	}
 
 	log.Info("Global preference was successfully updated")
+	cfg.WarnIfOverridden(o.paramName)
 	return nil
 }
 
@@ -85,7 +86,10 @@ func NewCmdSet(name, fullName string) *cobra.Command {
 		Short: "Set a value in odo config file",
 		Long:  fmt.Sprintf(setLongDesc, preference.FormatSupportedParameters()),
 		Example: func(exampleString, fullName string) string {
-			cfg, _ := preference.New()
+			cfg, err := preference.New()
+			if err != nil {
+				cfg = &preference.PreferenceInfo{}
+			}
 			properties := preference.NewPreferenceList(*cfg)
 			for _, property := range properties.Items {
 				value := property.Default
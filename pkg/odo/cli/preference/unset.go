@@ -74,6 +74,7 @@ func (o *UnsetOptions) Run(cmd *cobra.Command) (err error) {
 	}
 
 	log.Info("Global preference was successfully updated")
+	cfg.WarnIfOverridden(o.paramName)
 	return nil
 
 }
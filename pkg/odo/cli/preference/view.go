@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/openshift/odo/pkg/log"
@@ -21,6 +22,14 @@ var viewExample = ktemplates.Examples(`# For viewing the current preference valu
    %[1]s
   `)
 
+var viewLongDesc = ktemplates.LongDesc(`View current preference values
+
+The preferences are read, by increasing precedence, from the defaults, the global preference file,
+the .odo/preference.yaml file of the repository and the ODO_PREF_<PARAMETER> environment variables,
+e.g. ODO_PREF_PUSHTIMEOUT. The SOURCE column shows where each value comes from.
+The registries of the .odo/preference.yaml file are added to the global registries, they can't change the URL
+of a global registry or stop requiring its signatures.`)
+
 // ViewOptions encapsulates the options for the command
 type ViewOptions struct {
 }
@@ -49,41 +58,43 @@ func (o *ViewOptions) Run(cmd *cobra.Command) (err error) {
 		util.LogErrorAndExit(err, "")
 	}
 
+	prefList := preference.NewPreferenceList(*cfg)
 	if log.IsJSON() {
-		prefDef := preference.NewPreferenceList(*cfg)
-		machineoutput.OutputSuccess(prefDef)
+		machineoutput.OutputSuccess(prefList)
 
 		return
 	}
 	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
-	fmt.Fprintln(w, "PARAMETER", "\t", "CURRENT_VALUE")
-	fmt.Fprintln(w, "UpdateNotification", "\t", showBlankIfNil(cfg.OdoSettings.UpdateNotification))
-	fmt.Fprintln(w, "NamePrefix", "\t", showBlankIfNil(cfg.OdoSettings.NamePrefix))
-	fmt.Fprintln(w, "Timeout", "\t", showBlankIfNil(cfg.OdoSettings.Timeout))
-	fmt.Fprintln(w, "BuildTimeout", "\t", showBlankIfNil(cfg.OdoSettings.BuildTimeout))
-	fmt.Fprintln(w, "PushTimeout", "\t", showBlankIfNil(cfg.OdoSettings.PushTimeout))
-	fmt.Fprintln(w, "Experimental", "\t", showBlankIfNil(cfg.OdoSettings.Experimental))
-	fmt.Fprintln(w, "Ephemeral", "\t", showBlankIfNil(cfg.OdoSettings.Ephemeral))
-	fmt.Fprintln(w, "ConsentTelemetry", "\t", showBlankIfNil(cfg.OdoSettings.ConsentTelemetry))
-
+	fmt.Fprintln(w, "PARAMETER", "\t", "CURRENT_VALUE", "\t", "SOURCE")
+	for _, item := range prefList.Items {
+		value := item.Default
+		if !reflect.ValueOf(item.Value).IsNil() {
+			value = item.Value
+		}
+		fmt.Fprintln(w, item.Name, "\t", showValue(value), "\t", item.Source)
+	}
 	w.Flush()
+
+	if cfg.ProjectFilename != "" {
+		log.Infof("\nThe project preferences are read from %s", cfg.ProjectFilename)
+	}
 	return
 }
 
-func showBlankIfNil(intf interface{}) interface{} {
+// showValue returns the value to display, the pointers being de-referenced and the lists joined with commas
+func showValue(intf interface{}) interface{} {
 	imm := reflect.ValueOf(intf)
 
-	// if the value is nil then we should return a blank string
-	if imm.IsNil() {
-		return ""
-	}
-
 	// if its a pointer then we should de-ref it because we cant de-ref an interface{}
 	if imm.Kind() == reflect.Ptr {
-		return imm.Elem().Interface()
+		imm = imm.Elem()
+	}
+
+	if list, ok := imm.Interface().([]string); ok {
+		return strings.Join(list, ",")
 	}
 
-	return intf
+	return imm.Interface()
 }
 
 // NewCmdView implements the config view odo command
@@ -92,7 +103,7 @@ func NewCmdView(name, fullName string) *cobra.Command {
 	preferenceViewCmd := &cobra.Command{
 		Use:         name,
 		Short:       "View current preference values",
-		Long:        "View current preference values",
+		Long:        viewLongDesc,
 		Example:     fmt.Sprintf(fmt.Sprint("\n", viewExample), fullName),
 		Annotations: map[string]string{"machineoutput": "json"},
 
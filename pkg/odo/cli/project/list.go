@@ -82,7 +82,7 @@ func NewCmdProjectList(name, fullName string) *cobra.Command {
 		Long:        listLongDesc,
 		Example:     fmt.Sprintf(listExample, fullName),
 		Args:        cobra.ExactArgs(0),
-		Annotations: map[string]string{"machineoutput": "json"},
+		Annotations: map[string]string{"machineoutput": "all"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
 		},
@@ -12,6 +12,7 @@ import (
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	// odo packages
+	"github.com/openshift/odo/pkg/catalog"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/preference"
@@ -28,6 +29,16 @@ var (
 	%[1]s CheRegistry https://che-devfile-registry.openshift.io
 
 	%[1]s RegistryFromGitHub https://github.com/elsony/devfile-registry
+
+	# Add devfile registry from a local directory or tarball following the devfile registry layout
+	%[1]s LocalRegistry file:///opt/devfile-registry
+	%[1]s TarballRegistry file:///opt/devfile-registry.tar.gz
+
+	# Add devfile registry whose stacks are preferred over the stacks with the same name from other registries
+	%[1]s InternalRegistry https://registry.example.com --priority 10
+
+	# Add devfile registry whose devfiles must be signed by a key trusted with "odo preference set TrustedKeys"
+	%[1]s SignedRegistry https://registry.example.com --require-signature
 	`)
 )
 
@@ -39,6 +50,12 @@ type AddOptions struct {
 	user         string
 	token        string
 	forceFlag    bool
+	priority     int
+	// setPriority is true if the priority was specified by the user
+	setPriority      bool
+	requireSignature bool
+	// setRequireSignature is true if the signature requirement was specified by the user
+	setRequireSignature bool
 }
 
 // NewAddOptions creates a new AddOptions instance
@@ -52,11 +69,19 @@ func (o *AddOptions) Complete(name string, cmd *cobra.Command, args []string) (e
 	o.registryName = args[0]
 	o.registryURL = args[1]
 	o.user = "default"
+	o.setPriority = cmd.Flags().Changed("priority")
+	o.setRequireSignature = cmd.Flags().Changed("require-signature")
+	if util2.IsLocalRegistry(o.registryURL) {
+		o.registryURL, err = util2.GetAbsoluteLocalRegistryURL(o.registryURL)
+	}
 	return
 }
 
 // Validate validates the AddOptions based on completed values
 func (o *AddOptions) Validate() (err error) {
+	if util2.IsFileBasedRegistry(o.registryURL) {
+		return catalog.ValidateFileBasedRegistry(o.registryName, o.registryURL)
+	}
 	err = util.ValidateURL(o.registryURL)
 	if err != nil {
 		return err
@@ -82,6 +107,19 @@ func (o *AddOptions) Run(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return err
 	}
+	cfg.WarnIfOverridden(preference.RegistryListSetting)
+	if o.setPriority {
+		err = cfg.SetRegistryPriority(o.registryName, o.priority)
+		if err != nil {
+			return err
+		}
+	}
+	if o.setRequireSignature {
+		err = cfg.SetRegistryRequireSignature(o.registryName, o.requireSignature)
+		if err != nil {
+			return err
+		}
+	}
 
 	if o.token != "" {
 		err = keyring.Set(util.CredentialPrefix+o.registryName, o.user, o.token)
@@ -109,6 +147,8 @@ func NewCmdAdd(name, fullName string) *cobra.Command {
 	}
 
 	registryAddCmd.Flags().StringVar(&o.token, "token", "", "Token to be used to access secure registry")
+	registryAddCmd.Flags().BoolVar(&o.requireSignature, "require-signature", false, "Refuse the devfiles and zip starter projects of the registry that aren't signed by a key trusted with the TrustedKeys preference")
+	registryAddCmd.Flags().IntVar(&o.priority, "priority", 0, "Priority of the registry, stacks from registries with a higher priority are preferred when several registries publish the same stack")
 
 	return registryAddCmd
 }
@@ -68,6 +68,7 @@ func (o *DeleteOptions) Run(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return err
 	}
+	cfg.WarnIfOverridden(preference.RegistryListSetting)
 
 	if isSecure {
 		err = keyring.Delete(util.CredentialPrefix+o.registryName, o.user)
@@ -60,8 +60,9 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 		util.LogErrorAndExit(err, "")
 	}
 
-	registryList := cfg.OdoSettings.RegistryList
-	if registryList == nil || len(*registryList) == 0 {
+	registries := cfg.GetRegistryList()
+	registryList := &registries
+	if len(registries) == 0 {
 		return fmt.Errorf("No devfile registries added to the configuration. Refer `odo registry add -h` to add one")
 	}
 
@@ -71,7 +72,7 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
-	fmt.Fprintln(w, "NAME", "\t", "URL", "\t", "SECURE")
+	fmt.Fprintln(w, "NAME", "\t", "URL", "\t", "SECURE", "\t", "PRIORITY")
 	o.printRegistryList(w, registryList)
 	w.Flush()
 	if o.printGitRegistryDeprecationWarning {
@@ -85,15 +86,13 @@ func (o *ListOptions) printRegistryList(w io.Writer, registryList *[]preference.
 		return
 	}
 
-	regList := *registryList
-	// Loop backwards here to ensure the registry display order is correct (display latest newly added registry firstly)
-	for i := len(regList) - 1; i >= 0; i-- {
-		registry := regList[i]
+	// Display the registries in the order they are used to look for stacks
+	for _, registry := range preference.SortRegistriesByPriority(*registryList) {
 		secure := "No"
 		if registry.Secure {
 			secure = "Yes"
 		}
-		fmt.Fprintln(w, registry.Name, "\t", registry.URL, "\t", secure)
+		fmt.Fprintln(w, registry.Name, "\t", registry.URL, "\t", secure, "\t", registry.Priority)
 		if util2.IsGitBasedRegistry(registry.URL) {
 			o.printGitRegistryDeprecationWarning = true
 		}
@@ -108,7 +107,7 @@ func NewCmdList(name, fullName string) *cobra.Command {
 		Short:       listDesc,
 		Long:        listDesc,
 		Example:     fmt.Sprintf(fmt.Sprint(listExample), fullName),
-		Annotations: map[string]string{"machineoutput": "json"},
+		Annotations: map[string]string{"machineoutput": "all"},
 		Args:        cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
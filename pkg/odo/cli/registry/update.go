@@ -11,6 +11,7 @@ import (
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	// odo packages
+	"github.com/openshift/odo/pkg/catalog"
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/preference"
@@ -36,6 +37,12 @@ type UpdateOptions struct {
 	user         string
 	token        string
 	forceFlag    bool
+	priority     int
+	// setPriority is true if the priority was specified by the user
+	setPriority      bool
+	requireSignature bool
+	// setRequireSignature is true if the signature requirement was specified by the user
+	setRequireSignature bool
 }
 
 // NewUpdateOptions creates a new UpdateOptions instance
@@ -49,11 +56,19 @@ func (o *UpdateOptions) Complete(name string, cmd *cobra.Command, args []string)
 	o.registryName = args[0]
 	o.registryURL = args[1]
 	o.user = "default"
+	o.setPriority = cmd.Flags().Changed("priority")
+	o.setRequireSignature = cmd.Flags().Changed("require-signature")
+	if registryUtil.IsLocalRegistry(o.registryURL) {
+		o.registryURL, err = registryUtil.GetAbsoluteLocalRegistryURL(o.registryURL)
+	}
 	return
 }
 
 // Validate validates the UpdateOptions based on completed values
 func (o *UpdateOptions) Validate() (err error) {
+	if registryUtil.IsFileBasedRegistry(o.registryURL) {
+		return catalog.ValidateFileBasedRegistry(o.registryName, o.registryURL)
+	}
 	err = util.ValidateURL(o.registryURL)
 	if err != nil {
 		return err
@@ -83,6 +98,19 @@ func (o *UpdateOptions) Run(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return err
 	}
+	cfg.WarnIfOverridden(preference.RegistryListSetting)
+	if o.setPriority {
+		err = cfg.SetRegistryPriority(o.registryName, o.priority)
+		if err != nil {
+			return err
+		}
+	}
+	if o.setRequireSignature {
+		err = cfg.SetRegistryRequireSignature(o.registryName, o.requireSignature)
+		if err != nil {
+			return err
+		}
+	}
 
 	if secureAfterUpdate {
 		err = keyring.Set(util.CredentialPrefix+o.registryName, o.user, o.token)
@@ -114,6 +142,8 @@ func NewCmdUpdate(name, fullName string) *cobra.Command {
 	}
 
 	registryUpdateCmd.Flags().StringVar(&o.token, "token", "", "Token to be used to access secure registry")
+	registryUpdateCmd.Flags().BoolVar(&o.requireSignature, "require-signature", false, "Refuse the devfiles and zip starter projects of the registry that aren't signed by a key trusted with the TrustedKeys preference")
+	registryUpdateCmd.Flags().IntVar(&o.priority, "priority", 0, "Priority of the registry, stacks from registries with a higher priority are preferred when several registries publish the same stack")
 	registryUpdateCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Don't ask for confirmation, update the registry directly")
 
 	return registryUpdateCmd
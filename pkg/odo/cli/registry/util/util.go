@@ -4,6 +4,7 @@ import (
 	// odo packages
 
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/openshift/odo/pkg/log"
@@ -24,18 +25,41 @@ func IsSecure(registryName string) bool {
 	}
 
 	isSecure := false
-	if cfg.OdoSettings.RegistryList != nil {
-		for _, registry := range *cfg.OdoSettings.RegistryList {
-			if registry.Name == registryName && registry.Secure {
-				isSecure = true
-				break
-			}
+	for _, registry := range cfg.GetRegistryList() {
+		if registry.Name == registryName && registry.Secure {
+			isSecure = true
+			break
 		}
 	}
 
 	return isSecure
 }
 
+// IsLocalRegistry checks if the registry is stored in the local filesystem
+func IsLocalRegistry(url string) bool {
+	return strings.HasPrefix(url, "file://")
+}
+
+// GetAbsoluteLocalRegistryURL makes the path of a local registry absolute so that the registry can be used from any directory
+func GetAbsoluteLocalRegistryURL(url string) (string, error) {
+	path, err := filepath.Abs(filepath.FromSlash(strings.TrimPrefix(url, "file://")))
+	if err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(path), nil
+}
+
+// IsTarballRegistry checks if the registry is distributed as a tarball
+func IsTarballRegistry(url string) bool {
+	return strings.HasSuffix(url, ".tar.gz") || strings.HasSuffix(url, ".tgz")
+}
+
+// IsFileBasedRegistry checks if the registry is a directory tree or a tarball following the devfile registry layout
+// instead of a registry served by a registry server
+func IsFileBasedRegistry(url string) bool {
+	return IsLocalRegistry(url) || IsTarballRegistry(url)
+}
+
 func IsGitBasedRegistry(url string) bool {
 	return strings.Contains(url, "github.com") || strings.Contains(url, "raw.githubusercontent.com")
 }
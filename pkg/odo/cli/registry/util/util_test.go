@@ -101,3 +101,46 @@ func TestIsGitBasedRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFileBasedRegistry(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{
+			name: "Case 1: OCI-based registry",
+			url:  "https://registry.devfile.io",
+			want: false,
+		},
+		{
+			name: "Case 2: Git-based registry",
+			url:  "https://github.com/odo-devfiles/registry",
+			want: false,
+		},
+		{
+			name: "Case 3: Local directory registry",
+			url:  "file:///opt/registry",
+			want: true,
+		},
+		{
+			name: "Case 4: Local tarball registry",
+			url:  "file:///opt/registry.tar.gz",
+			want: true,
+		},
+		{
+			name: "Case 5: Remote tarball registry",
+			url:  "https://example.com/registry.tgz",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsFileBasedRegistry(tt.url)
+			if got != tt.want {
+				t.Errorf("Got: %t, want: %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	"k8s.io/klog"
+)
+
+// eventBufferSize is the number of events buffered for each subscriber, the events are dropped for the subscribers
+// which don't read them fast enough
+const eventBufferSize = 256
+
+// eventBroker dispatches the machine events of the component to the connections streaming them
+type eventBroker struct {
+	lock        sync.Mutex
+	subscribers map[chan machineoutput.MachineEventWrapper]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: map[chan machineoutput.MachineEventWrapper]struct{}{}}
+}
+
+// publish sends the event to every subscriber
+func (b *eventBroker) publish(event machineoutput.MachineEventWrapper) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			klog.V(4).Infof("dropping a machine event for a slow subscriber")
+		}
+	}
+}
+
+func (b *eventBroker) subscribe() chan machineoutput.MachineEventWrapper {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	subscriber := make(chan machineoutput.MachineEventWrapper, eventBufferSize)
+	b.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+func (b *eventBroker) unsubscribe(subscriber chan machineoutput.MachineEventWrapper) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.subscribers, subscriber)
+}
+
+// eventStream writes the machine events to a streaming response, one JSON event per line
+type eventStream struct {
+	encoder *json.Encoder
+	flusher http.Flusher
+}
+
+func newEventStream(w http.ResponseWriter) *eventStream {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	return &eventStream{encoder: json.NewEncoder(w), flusher: flusher}
+}
+
+func (s *eventStream) write(event machineoutput.MachineEventWrapper) error {
+	if err := s.encoder.Encode(event); err != nil {
+		return err
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+// streamUntil writes the events of the subscriber to the stream until done is closed, or the client disconnects;
+// the events published before done is closed are all written
+func (s *eventStream) streamUntil(subscriber chan machineoutput.MachineEventWrapper, done <-chan struct{}, disconnected <-chan struct{}) {
+	for {
+		select {
+		case event := <-subscriber:
+			if err := s.write(event); err != nil {
+				return
+			}
+		case <-disconnected:
+			return
+		case <-done:
+			for {
+				select {
+				case event := <-subscriber:
+					if err := s.write(event); err != nil {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
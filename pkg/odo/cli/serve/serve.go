@@ -0,0 +1,168 @@
+package serve
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/component"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	odoutil "github.com/openshift/odo/pkg/odo/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended serve command name
+const RecommendedCommandName = "serve"
+
+// socketFile is the name of the default socket file, in the .odo directory of the component
+const socketFile = "odo.sock"
+
+var (
+	serveLongDesc = ktemplates.LongDesc(`Serve the operations of the devfile component over a local API.
+
+	The API is served over HTTP on a Unix socket, which is only accessible by the current user.
+	The clients, preferences and cluster capabilities are loaded once, when the server starts, instead of on every odo command.
+	The endpoints are prefixed with the version of the API, /v1alpha1:
+
+	- GET /version: the versions of odo and of the API
+	- POST /push: push the component, streaming the machine events of the push
+	- POST /watch: watch the component for changes and push them, streaming the machine events until the client disconnects
+	- GET /events: stream the machine events of the component, including the status of its pods and supervisord programs
+	- GET /log: stream the log of the run command, or of the debug command with the debug=true query parameter, following it with follow=true
+	- GET /urls, /storage and /services: the JSON output of 'odo url list', 'odo storage list' and 'odo service list'
+
+	The push and watch requests accept a JSON body with the forceBuild, debug, show, buildCommand, runCommand, debugCommand and delay fields.
+	The machine events are streamed as one JSON event per line, as the events of 'odo push -o json'; a failed push is reported by a final reportError event.`)
+
+	serveExample = ktemplates.Examples(`  # Serve the component of the current directory on .odo/odo.sock
+  %[1]s
+
+  # Push the component through the API
+  curl --unix-socket .odo/odo.sock -X POST -d '{"forceBuild": true}' http://localhost/v1alpha1/push
+	`)
+)
+
+// ServeOptions encapsulates the options for the odo serve command
+type ServeOptions struct {
+	componentContext string
+	socketFlag       string
+
+	devfilePath string
+	socketPath  string
+
+	*genericclioptions.Context
+}
+
+// NewServeOptions returns new instance of ServeOptions
+func NewServeOptions() *ServeOptions {
+	return &ServeOptions{}
+}
+
+// Complete completes ServeOptions after they've been created
+func (o *ServeOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.devfilePath = filepath.Join(o.componentContext, component.DevfilePath)
+	o.Context, err = genericclioptions.New(genericclioptions.CreateParameters{
+		Cmd:              cmd,
+		DevfilePath:      component.DevfilePath,
+		ComponentContext: o.componentContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	o.socketPath = o.socketFlag
+	if o.socketPath == "" {
+		o.socketPath = filepath.Join(o.componentContext, ".odo", socketFile)
+	}
+	o.socketPath, err = filepath.Abs(o.socketPath)
+	return err
+}
+
+// Validate validates the ServeOptions based on completed values
+func (o *ServeOptions) Validate() (err error) {
+	if _, ok := o.LocalConfigProvider.(*envinfo.EnvSpecificInfo); !ok {
+		return fmt.Errorf("odo serve is only supported by devfile components")
+	}
+	// a socket file left by a server which didn't stop cleanly is removed, but not a socket in use
+	if _, err := os.Stat(o.socketPath); err == nil {
+		if conn, err := net.Dial("unix", o.socketPath); err == nil {
+			conn.Close()
+			return fmt.Errorf("the socket %s is already served by another odo serve", o.socketPath)
+		}
+		if err = os.Remove(o.socketPath); err != nil {
+			return errors.Wrapf(err, "unable to remove the socket %s", o.socketPath)
+		}
+	}
+	return nil
+}
+
+// Run contains the logic for the odo serve command
+func (o *ServeOptions) Run(cmd *cobra.Command) (err error) {
+	server, err := NewServer(o.Context, o.devfilePath)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(o.socketPath), 0750); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", o.socketPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to listen on %s", o.socketPath)
+	}
+	defer os.Remove(o.socketPath)
+	if err = os.Chmod(o.socketPath, 0600); err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{Handler: server.Handler()}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		klog.V(4).Infof("stopping the server")
+		// the streaming connections are closed too, rather than waiting for them to end
+		_ = httpServer.Close()
+	}()
+
+	log.Infof("Serving the API %s of component %s on %s", APIVersion, o.EnvSpecificInfo.GetName(), o.socketPath)
+	err = httpServer.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// NewCmdServe implements the odo serve command
+func NewCmdServe(name, fullName string) *cobra.Command {
+	o := NewServeOptions()
+
+	serveCmd := &cobra.Command{
+		Use:         name,
+		Short:       "Serve the operations of the component over a local API",
+		Long:        serveLongDesc,
+		Example:     fmt.Sprintf(serveExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"command": "component"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	serveCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
+	serveCmd.Flags().StringVar(&o.socketFlag, "socket", "", "Path of the Unix socket to serve the API on (default .odo/odo.sock in the component directory)")
+	genericclioptions.AddContextFlag(serveCmd, &o.componentContext)
+
+	return serveCmd
+}
@@ -0,0 +1,473 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes/component"
+	"github.com/openshift/odo/pkg/devfile/validate"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	svc "github.com/openshift/odo/pkg/service"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/openshift/odo/pkg/url"
+	"github.com/openshift/odo/pkg/util"
+	odoversion "github.com/openshift/odo/pkg/version"
+	"github.com/openshift/odo/pkg/watch"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Server exposes the operations of a devfile component over HTTP, reusing the same clients and cluster
+// capabilities for all the requests
+type Server struct {
+	context     *genericclioptions.Context
+	devfilePath string
+	sourcePath  string
+
+	// the cluster capabilities are discovered once, when the server starts
+	routeSupported bool
+	csvSupported   bool
+
+	events        *eventBroker
+	loggingClient machineoutput.MachineEventLoggingClient
+
+	// pushLock prevents concurrent pushes of the component, by push requests or by the watch
+	pushLock sync.Mutex
+	// statusLock protects statusStarted, the status watches of the component being started the first time the
+	// events are requested
+	statusLock    sync.Mutex
+	statusStarted bool
+	// watchLock protects watchStop, which is non nil while the component is watched
+	watchLock sync.Mutex
+	watchStop chan bool
+}
+
+// NewServer returns a server for the devfile component of the context
+func NewServer(context *genericclioptions.Context, devfilePath string) (*Server, error) {
+	sourcePath, err := util.GetAbsPath(context.ComponentContext)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get source path")
+	}
+
+	routeSupported, err := context.Client.IsRouteSupported()
+	if err != nil {
+		return nil, err
+	}
+	csvSupported, err := context.KClient.IsCSVSupported()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		context:        context,
+		devfilePath:    devfilePath,
+		sourcePath:     sourcePath,
+		routeSupported: routeSupported,
+		csvSupported:   csvSupported,
+		events:         newEventBroker(),
+	}
+	s.loggingClient = machineoutput.NewConsoleMachineEventLoggingClientWithFunction(s.events.publish)
+	return s, nil
+}
+
+// Handler returns the HTTP handler of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	prefix := "/" + APIVersion
+	mux.HandleFunc(prefix+"/version", s.handle(http.MethodGet, s.version))
+	mux.HandleFunc(prefix+"/push", s.handle(http.MethodPost, s.push))
+	mux.HandleFunc(prefix+"/watch", s.handle(http.MethodPost, s.watch))
+	mux.HandleFunc(prefix+"/events", s.handle(http.MethodGet, s.status))
+	mux.HandleFunc(prefix+"/log", s.handle(http.MethodGet, s.log))
+	mux.HandleFunc(prefix+"/urls", s.handle(http.MethodGet, s.urls))
+	mux.HandleFunc(prefix+"/storage", s.handle(http.MethodGet, s.storage))
+	mux.HandleFunc(prefix+"/services", s.handle(http.MethodGet, s.services))
+	return mux
+}
+
+// handle checks the method of the request and writes the error returned by the handler, if any, as a JSON error
+func (s *Server) handle(method string, handler func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed, use %s", r.Method, method))
+			return
+		}
+		if err := handler(w, r); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// writeJSON writes the document as the machine readable output of the commands, carrying the version of its schema
+func writeJSON(w http.ResponseWriter, status int, obj interface{}) {
+	data, err := machineoutput.MarshalDocument(obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, machineoutput.GenericError{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       machineoutput.Kind,
+			APIVersion: machineoutput.APIVersion,
+		},
+		Message: err.Error(),
+	})
+}
+
+func (s *Server) version(w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, VersionResponse{
+		APIVersion: APIVersion,
+		OdoVersion: odoversion.VERSION,
+		GitCommit:  odoversion.GITCOMMIT,
+	})
+	return nil
+}
+
+// newAdapter returns a component adapter for the current devfile, reporting its events to the connections
+func (s *Server) newAdapter() (*component.Adapter, error) {
+	devObj, err := devfile.ParseDevfileAndValidate(parser.ParserArgs{Path: s.devfilePath})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse and validate '%s'", s.devfilePath)
+	}
+	err = validate.ValidateDevfileData(devObj.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := component.New(common.AdapterContext{
+		ComponentName: s.context.EnvSpecificInfo.GetName(),
+		Context:       s.context.ComponentContext,
+		AppName:       s.context.Application,
+		Devfile:       devObj,
+	}, *s.context.Client)
+	adapter.SetLogger(s.loggingClient)
+	return &adapter, nil
+}
+
+func decodePushRequest(r *http.Request) (PushRequest, error) {
+	var request PushRequest
+	if r.ContentLength == 0 {
+		return request, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return request, errors.Wrap(err, "unable to decode the request")
+	}
+	return request, nil
+}
+
+func (s *Server) pushParameters(request PushRequest) (common.PushParameters, error) {
+	var ignores []string
+	err := genericclioptions.ApplyIgnore(&ignores, s.sourcePath)
+	if err != nil {
+		return common.PushParameters{}, errors.Wrap(err, "unable to apply ignore information")
+	}
+	return common.PushParameters{
+		Path:            s.sourcePath,
+		IgnoredFiles:    ignores,
+		ForceBuild:      request.ForceBuild,
+		Show:            request.Show,
+		EnvSpecificInfo: *s.context.EnvSpecificInfo,
+		DevfileBuildCmd: strings.ToLower(request.BuildCommand),
+		DevfileRunCmd:   strings.ToLower(request.RunCommand),
+		DevfileDebugCmd: strings.ToLower(request.DebugCommand),
+		Debug:           request.Debug,
+		DebugPort:       s.context.EnvSpecificInfo.GetDebugPort(),
+	}, nil
+}
+
+// pushComponent pushes the component, one push at a time
+func (s *Server) pushComponent(parameters common.PushParameters) error {
+	s.pushLock.Lock()
+	defer s.pushLock.Unlock()
+
+	adapter, err := s.newAdapter()
+	if err != nil {
+		return err
+	}
+	err = adapter.Push(parameters)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to start component with name %q", adapter.ComponentName)
+	}
+
+	// push is successful, save the run mode used
+	runMode := envinfo.Run
+	if parameters.Debug {
+		runMode = envinfo.Debug
+	}
+	return s.context.EnvSpecificInfo.SetRunMode(runMode)
+}
+
+// push pushes the component and streams the machine events until the push completes, a failed push
+// being reported by a final error event
+func (s *Server) push(w http.ResponseWriter, r *http.Request) error {
+	request, err := decodePushRequest(r)
+	if err != nil {
+		return err
+	}
+	parameters, err := s.pushParameters(request)
+	if err != nil {
+		return err
+	}
+
+	subscriber := s.events.subscribe()
+	defer s.events.unsubscribe(subscriber)
+
+	done := make(chan struct{})
+	var pushErr error
+	go func() {
+		defer close(done)
+		pushErr = s.pushComponent(parameters)
+	}()
+
+	stream := newEventStream(w)
+	stream.streamUntil(subscriber, done, r.Context().Done())
+	<-done
+	if pushErr != nil {
+		_ = stream.write(machineoutput.MachineEventWrapper{
+			ReportError: &machineoutput.ReportError{
+				Error:            pushErr.Error(),
+				AbstractLogEvent: machineoutput.AbstractLogEvent{Timestamp: machineoutput.TimestampNow()},
+			},
+		})
+	}
+	return nil
+}
+
+// watch watches the component for changes, pushing them, and streams the machine events until the client disconnects
+func (s *Server) watch(w http.ResponseWriter, r *http.Request) error {
+	request, err := decodePushRequest(r)
+	if err != nil {
+		return err
+	}
+	parameters, err := s.pushParameters(request)
+	if err != nil {
+		return err
+	}
+	if request.Delay <= 0 {
+		request.Delay = 1
+	}
+
+	s.watchLock.Lock()
+	if s.watchStop != nil {
+		s.watchLock.Unlock()
+		writeError(w, http.StatusConflict, fmt.Errorf("the component %s is already watched", s.context.EnvSpecificInfo.GetName()))
+		return nil
+	}
+	stop := make(chan bool)
+	s.watchStop = stop
+	s.watchLock.Unlock()
+
+	subscriber := s.events.subscribe()
+	defer s.events.unsubscribe(subscriber)
+
+	done := make(chan struct{})
+	var watchErr error
+	go func() {
+		defer close(done)
+		watchErr = watch.DevfileWatchAndPush(ioutil.Discard, watch.WatchParameters{
+			ComponentName:   s.context.EnvSpecificInfo.GetName(),
+			ApplicationName: s.context.Application,
+			Path:            s.sourcePath,
+			FileIgnores:     util.GetAbsGlobExps(s.sourcePath, parameters.IgnoredFiles),
+			PushDiffDelay:   request.Delay,
+			ExtChan:         stop,
+			DevfileWatchHandler: func(pushParams common.PushParameters, _ watch.WatchParameters) error {
+				pushParams.Debug = request.Debug
+				pushParams.DebugPort = parameters.DebugPort
+				return s.pushComponent(pushParams)
+			},
+			Show:            request.Show,
+			DevfileBuildCmd: parameters.DevfileBuildCmd,
+			DevfileRunCmd:   parameters.DevfileRunCmd,
+			DevfileDebugCmd: parameters.DevfileDebugCmd,
+			EnvSpecificInfo: s.context.EnvSpecificInfo,
+		})
+	}()
+
+	stream := newEventStream(w)
+	stream.streamUntil(subscriber, done, r.Context().Done())
+
+	select {
+	case <-done:
+	default:
+		// the client disconnected, stop the watch
+		stopWatch(stop, done)
+		<-done
+	}
+	s.watchLock.Lock()
+	s.watchStop = nil
+	s.watchLock.Unlock()
+
+	if watchErr != nil && watchErr != watch.ErrUserRequestedWatchExit {
+		_ = stream.write(machineoutput.MachineEventWrapper{
+			ReportError: &machineoutput.ReportError{
+				Error:            watchErr.Error(),
+				AbstractLogEvent: machineoutput.AbstractLogEvent{Timestamp: machineoutput.TimestampNow()},
+			},
+		})
+	}
+	return nil
+}
+
+// stopWatch asks the watch to stop, unless it already returned, failing to set up or on an error, as done is then closed
+func stopWatch(stop chan<- bool, done <-chan struct{}) {
+	select {
+	case stop <- true:
+	case <-done:
+	}
+}
+
+// status streams the machine events of the component, including the status of its pods and supervisord programs,
+// until the client disconnects
+func (s *Server) status(w http.ResponseWriter, r *http.Request) error {
+	err := s.startStatusWatches()
+	if err != nil {
+		return err
+	}
+
+	subscriber := s.events.subscribe()
+	defer s.events.unsubscribe(subscriber)
+	newEventStream(w).streamUntil(subscriber, nil, r.Context().Done())
+	return nil
+}
+
+// startStatusWatches starts the watches reporting the status of the pods and supervisord programs of the component,
+// if they are not running yet
+func (s *Server) startStatusWatches() error {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+	if s.statusStarted {
+		return nil
+	}
+
+	adapter, err := s.newAdapter()
+	if err != nil {
+		return err
+	}
+	adapter.StartContainerStatusWatch()
+	adapter.StartSupervisordCtlStatusWatch()
+	s.statusStarted = true
+	return nil
+}
+
+// log streams the log of the run command, or of the debug command with debug=true, following it with follow=true
+func (s *Server) log(w http.ResponseWriter, r *http.Request) error {
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+	debug, _ := strconv.ParseBool(r.URL.Query().Get("debug"))
+
+	adapter, err := s.newAdapter()
+	if err != nil {
+		return err
+	}
+
+	var command devfilev1.Command
+	if debug {
+		command, err = common.GetDebugCommand(adapter.Devfile.Data, "")
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(devfilev1.Command{}, command) {
+			return errors.New("no debug command found in devfile")
+		}
+	} else {
+		command, err = common.GetRunCommand(adapter.Devfile.Data, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	rd, err := adapter.Log(follow, command)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 4096)
+	for {
+		n, err := rd.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return nil
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// the response has started, the error can only be logged in it
+			fmt.Fprintf(w, "\nunable to read the log: %v\n", err)
+			return nil
+		}
+	}
+}
+
+func (s *Server) urls(w http.ResponseWriter, r *http.Request) error {
+	client := url.NewClient(url.ClientOptions{
+		LocalConfigProvider: s.context.LocalConfigProvider,
+		OCClient:            *s.context.Client,
+		IsRouteSupported:    s.routeSupported,
+	})
+	urls, err := client.List()
+	if err != nil {
+		return err
+	}
+	url.ProbeURLs(&urls, s.context.LocalConfigProvider.GetName(), url.ProbeTimeout)
+	writeJSON(w, http.StatusOK, urls)
+	return nil
+}
+
+func (s *Server) storage(w http.ResponseWriter, r *http.Request) error {
+	client := storage.NewClient(storage.ClientOptions{
+		LocalConfigProvider: s.context.LocalConfigProvider,
+		OCClient:            *s.context.Client,
+	})
+	storageList, err := client.List()
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, storageList)
+	return nil
+}
+
+func (s *Server) services(w http.ResponseWriter, r *http.Request) error {
+	if s.csvSupported {
+		list, _, err := svc.ListOperatorServices(s.context.KClient)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, list)
+		return nil
+	}
+
+	services, err := svc.ListWithDetailedStatus(s.context.Client, s.context.Application)
+	if err != nil {
+		return fmt.Errorf("Service catalog is not enabled within your cluster: %v", err)
+	}
+	writeJSON(w, http.StatusOK, services)
+	return nil
+}
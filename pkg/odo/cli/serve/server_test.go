@@ -0,0 +1,137 @@
+package serve
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+)
+
+func TestServerHandler(t *testing.T) {
+	s := &Server{events: newEventBroker()}
+	handler := s.Handler()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantKind   string
+		// versioned checks that the document carries the version of its schema
+		versioned bool
+	}{
+		{
+			name:       "Case 1: Version of the API",
+			method:     http.MethodGet,
+			path:       "/v1alpha1/version",
+			wantStatus: http.StatusOK,
+			versioned:  true,
+		},
+		{
+			name:       "Case 2: Method not allowed",
+			method:     http.MethodGet,
+			path:       "/v1alpha1/push",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantKind:   machineoutput.Kind,
+			versioned:  true,
+		},
+		{
+			name:       "Case 3: Unknown version of the API",
+			method:     http.MethodGet,
+			path:       "/v1/version",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantKind != "" {
+				var genericError machineoutput.GenericError
+				if err := json.Unmarshal(recorder.Body.Bytes(), &genericError); err != nil {
+					t.Fatal(err)
+				}
+				if genericError.Kind != tt.wantKind || genericError.Message == "" {
+					t.Errorf("unexpected error %+v", genericError)
+				}
+			}
+			if tt.versioned {
+				var document map[string]interface{}
+				if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+					t.Fatal(err)
+				}
+				if document[machineoutput.SchemaVersionField] != machineoutput.SchemaVersion {
+					t.Errorf("got the schema version %v, want %s", document[machineoutput.SchemaVersionField], machineoutput.SchemaVersion)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	broker := newEventBroker()
+	loggingClient := machineoutput.NewConsoleMachineEventLoggingClientWithFunction(broker.publish)
+
+	subscriber := broker.subscribe()
+	loggingClient.DevFileCommandExecutionBegin("build", "runtime", "npm install", "build", machineoutput.TimestampNow())
+	loggingClient.DevFileCommandExecutionComplete("build", "runtime", "npm install", "build", machineoutput.TimestampNow(), nil)
+	broker.unsubscribe(subscriber)
+	// the events published after unsubscribing are not streamed
+	loggingClient.ReportError(errors.New("unexpected"), machineoutput.TimestampNow())
+
+	done := make(chan struct{})
+	close(done)
+	recorder := httptest.NewRecorder()
+	newEventStream(recorder).streamUntil(subscriber, done, nil)
+
+	var types []machineoutput.MachineEventLogEntryType
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		var event machineoutput.MachineEventWrapper
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := event.GetEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, entry.GetType())
+	}
+	if len(types) != 2 || types[0] != machineoutput.TypeDevFileCommandExecutionBegin || types[1] != machineoutput.TypeDevFileCommandExecutionComplete {
+		t.Errorf("got the events %v", types)
+	}
+}
+
+func TestStopWatch(t *testing.T) {
+	// the watch returned without reading the stop channel
+	stop := make(chan bool)
+	done := make(chan struct{})
+	close(done)
+	returned := make(chan struct{})
+	go func() {
+		stopWatch(stop, done)
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stopWatch blocked although the watch returned")
+	}
+
+	// the watch reads the stop channel
+	stop = make(chan bool)
+	go stopWatch(stop, make(chan struct{}))
+	select {
+	case <-stop:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the watch was not asked to stop")
+	}
+}
@@ -0,0 +1,23 @@
+package serve
+
+// APIVersion is the version of the odo serve API, the paths of its endpoints are prefixed with /<APIVersion>
+const APIVersion = "v1alpha1"
+
+// VersionResponse is the response of the version endpoint
+type VersionResponse struct {
+	APIVersion string `json:"apiVersion"`
+	OdoVersion string `json:"odoVersion"`
+	GitCommit  string `json:"gitCommit"`
+}
+
+// PushRequest is the body of the push and watch requests, its fields match the flags of odo push and odo watch
+type PushRequest struct {
+	ForceBuild   bool   `json:"forceBuild,omitempty"`
+	Debug        bool   `json:"debug,omitempty"`
+	Show         bool   `json:"show,omitempty"`
+	BuildCommand string `json:"buildCommand,omitempty"`
+	RunCommand   string `json:"runCommand,omitempty"`
+	DebugCommand string `json:"debugCommand,omitempty"`
+	// Delay is the time in seconds between a change being detected and the push, for the watch requests
+	Delay int `json:"delay,omitempty"`
+}
@@ -140,7 +140,7 @@ func NewCmdServiceList(name, fullName string) *cobra.Command {
 		Long:        listLongDesc,
 		Example:     fmt.Sprintf(listExample, fullName),
 		Args:        cobra.NoArgs,
-		Annotations: map[string]string{"machineoutput": "json"},
+		Annotations: map[string]string{"machineoutput": "all"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
 		},
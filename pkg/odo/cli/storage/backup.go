@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/component"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const backupRecommendedCommandName = "backup"
+
+var (
+	storageBackupShortDesc = `Backup the data of a storage of the component`
+	storageBackupLongDesc  = ktemplates.LongDesc(`Backup the data of a storage of the component.
+
+	When the cluster can take CSI volume snapshots of the storage, a VolumeSnapshot is created.
+	Otherwise, or when --archive is given, the content of the storage is streamed through the component
+	container, in which the tar command must be available, into a local gzipped tar archive.`)
+	storageBackupExample = ktemplates.Examples(`
+	# Backup the storage mystorage of the current component
+  %[1]s mystorage
+
+  # Backup the storage mystorage of the current component into a local archive
+  %[1]s mystorage --archive mystorage.tar.gz
+	`)
+)
+
+// BackupOptions encapsulates the options for the odo storage backup command
+type BackupOptions struct {
+	storageName      string
+	archivePath      string
+	componentContext string
+
+	*genericclioptions.Context
+}
+
+// NewStorageBackupOptions creates a new BackupOptions instance
+func NewStorageBackupOptions() *BackupOptions {
+	return &BackupOptions{}
+}
+
+// Complete completes BackupOptions after they've been created
+func (o *BackupOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = genericclioptions.New(genericclioptions.CreateParameters{
+		Cmd:              cmd,
+		DevfilePath:      component.DevfilePath,
+		ComponentContext: o.componentContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	o.storageName = args[0]
+	return
+}
+
+// Validate validates the BackupOptions based on completed values
+func (o *BackupOptions) Validate() (err error) {
+	return validateStorageExists(o.Context, o.storageName)
+}
+
+// Run contains the logic for the odo storage backup command
+func (o *BackupOptions) Run(cmd *cobra.Command) (err error) {
+	componentName := o.LocalConfigProvider.GetName()
+
+	method := storage.ArchiveBackup
+	if o.archivePath == "" {
+		method, err = storage.GetBackupMethod(o.KClient, componentName, o.storageName)
+		if err != nil {
+			return err
+		}
+	}
+
+	var successMessage string
+	if method == storage.SnapshotBackup {
+		snapshotName, err := storage.BackupToSnapshot(o.KClient, componentName, o.LocalConfigProvider.GetApplication(), o.storageName)
+		if err != nil {
+			return err
+		}
+		successMessage = fmt.Sprintf("Saved storage %s in snapshot %s", o.storageName, snapshotName)
+	} else {
+		if o.archivePath == "" {
+			o.archivePath = fmt.Sprintf("%s-%s.tar.gz", o.storageName, time.Now().Format("20060102150405"))
+		}
+		s := log.Spinnerf("Saving storage %s in archive %s", o.storageName, o.archivePath)
+		err = storage.BackupToArchive(o.KClient, componentName, o.storageName, o.archivePath)
+		s.End(err == nil)
+		if err != nil {
+			return err
+		}
+		archivePath, err := filepath.Abs(o.archivePath)
+		if err != nil {
+			return err
+		}
+		successMessage = fmt.Sprintf("Saved storage %s in archive %s", o.storageName, archivePath)
+	}
+
+	if log.IsJSON() {
+		storage.MachineReadableSuccessOutput(o.storageName, successMessage)
+	} else {
+		log.Successf(successMessage)
+	}
+	return nil
+}
+
+// validateStorageExists returns an error if the storage is not a storage of the component
+func validateStorageExists(context *genericclioptions.Context, storageName string) error {
+	gotStorage, err := context.LocalConfigProvider.GetStorage(storageName)
+	if err != nil {
+		return err
+	}
+	if gotStorage == nil {
+		return fmt.Errorf("the storage %v does not exist in component %v", storageName, context.LocalConfigProvider.GetName())
+	}
+	return nil
+}
+
+// NewCmdStorageBackup implements the odo storage backup command.
+func NewCmdStorageBackup(name, fullName string) *cobra.Command {
+	o := NewStorageBackupOptions()
+	storageBackupCmd := &cobra.Command{
+		Use:         name + " STORAGE_NAME",
+		Short:       storageBackupShortDesc,
+		Long:        storageBackupLongDesc,
+		Example:     fmt.Sprintf(storageBackupExample, fullName),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	storageBackupCmd.Flags().StringVar(&o.archivePath, "archive", "", "Save the content of the storage in this local gzipped tar archive, instead of a volume snapshot")
+	completion.RegisterCommandHandler(storageBackupCmd, completion.StorageDeleteCompletionHandler)
+
+	genericclioptions.AddContextFlag(storageBackupCmd, &o.componentContext)
+	completion.RegisterCommandFlagHandler(storageBackupCmd, "context", completion.FileCompletionHandler)
+	completion.RegisterCommandFlagHandler(storageBackupCmd, "archive", completion.FileCompletionHandler)
+
+	return storageBackupCmd
+}
@@ -19,10 +19,18 @@ const createRecommendedCommandName = "create"
 
 var (
 	storageCreateShortDesc = `Create storage and mount to a component`
-	storageCreateLongDesc  = ktemplates.LongDesc(`Create storage and mount to a component`)
-	storageCreateExample   = ktemplates.Examples(`
+	storageCreateLongDesc  = ktemplates.LongDesc(`Create storage and mount to a component.
+
+	For devfile components, the StorageClass, access mode and volume mode of the storage can be set; they are saved
+	in the odo.dev/storage-class, odo.dev/access-mode and odo.dev/volume-mode attributes of the volume component.
+	They can't be changed once the storage is pushed. The size of a pushed storage can only be increased,
+	when its StorageClass allows volume expansion.`)
+	storageCreateExample = ktemplates.Examples(`
 	# Create storage of size 1Gb to a component
   %[1]s mystorage --path=/opt/app-root/src/storage/ --size=1Gi
+
+  # Create storage shared between pods, using the StorageClass nfs
+  %[1]s mystorage --path=/data --size=5Gi --storage-class=nfs --access-mode=RWX
 	`)
 )
 
@@ -30,6 +38,9 @@ type CreateOptions struct {
 	storageName      string
 	storageSize      string
 	storagePath      string
+	storageClass     string
+	accessMode       string
+	volumeMode       string
 	componentContext string
 
 	container string // container to which this storage belongs
@@ -61,10 +72,13 @@ func (o *CreateOptions) Complete(name string, cmd *cobra.Command, args []string)
 	}
 
 	o.storage = localConfigProvider.LocalStorage{
-		Name:      o.storageName,
-		Size:      o.storageSize,
-		Path:      o.storagePath,
-		Container: o.container,
+		Name:         o.storageName,
+		Size:         o.storageSize,
+		Path:         o.storagePath,
+		Container:    o.container,
+		StorageClass: o.storageClass,
+		AccessMode:   o.accessMode,
+		VolumeMode:   o.volumeMode,
 	}
 
 	o.Context.LocalConfigProvider.CompleteStorage(&o.storage)
@@ -87,6 +101,9 @@ func (o *CreateOptions) Run(cmd *cobra.Command) (err error) {
 
 	if log.IsJSON() {
 		storageResultMachineReadable := storage.GetMachineReadableFormat(o.storage.Name, o.storage.Size, o.storage.Path)
+		storageResultMachineReadable.Spec.StorageClass = o.storage.StorageClass
+		storageResultMachineReadable.Spec.AccessMode = o.storage.AccessMode
+		storageResultMachineReadable.Spec.VolumeMode = o.storage.VolumeMode
 		machineoutput.OutputSuccess(storageResultMachineReadable)
 	} else {
 		log.Successf("Added storage %v to %v", o.storageName, o.Context.LocalConfigProvider.GetName())
@@ -114,6 +131,9 @@ func NewCmdStorageCreate(name, fullName string) *cobra.Command {
 	storageCreateCmd.Flags().StringVar(&o.storageSize, "size", "", "Size of storage to add")
 	storageCreateCmd.Flags().StringVar(&o.storagePath, "path", "", "Path to mount the storage on")
 	storageCreateCmd.Flags().StringVar(&o.container, "container", "", "Name of container to attach the storage to in devfile")
+	storageCreateCmd.Flags().StringVar(&o.storageClass, "storage-class", "", "StorageClass of the storage, the default StorageClass of the cluster is used if not set")
+	storageCreateCmd.Flags().StringVar(&o.accessMode, "access-mode", "", "Access mode of the storage, ReadWriteOnce (RWO), ReadWriteMany (RWX) or ReadOnlyMany (ROX)")
+	storageCreateCmd.Flags().StringVar(&o.volumeMode, "volume-mode", "", "Volume mode of the storage, Filesystem or Block; a Block storage is attached as a device at its path")
 
 	genericclioptions.AddContextFlag(storageCreateCmd, &o.componentContext)
 	completion.RegisterCommandFlagHandler(storageCreateCmd, "context", completion.FileCompletionHandler)
@@ -204,7 +204,7 @@ func NewCmdStorageList(name, fullName string) *cobra.Command {
 		Long:        storageListLongDesc,
 		Example:     fmt.Sprintf(storageListExample, fullName),
 		Args:        cobra.MaximumNArgs(1),
-		Annotations: map[string]string{"machineoutput": "json"},
+		Annotations: map[string]string{"machineoutput": "all"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
 		},
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/cli/component"
+	"github.com/openshift/odo/pkg/odo/cli/ui"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const restoreRecommendedCommandName = "restore"
+
+var (
+	storageRestoreShortDesc = `Restore the data of a storage of the component`
+	storageRestoreLongDesc  = ktemplates.LongDesc(`Restore the data of a storage of the component from a backup.
+
+	With --archive, the local archive created by 'odo storage backup' is extracted into the storage through the
+	component container; files of the archive overwrite the existing ones.
+	Otherwise, the storage is replaced by a volume provisioned from the given snapshot, or the latest snapshot
+	of the storage; the component is stopped while the storage is replaced.`)
+	storageRestoreExample = ktemplates.Examples(`
+	# Restore the storage mystorage of the current component from its latest snapshot
+  %[1]s mystorage
+
+  # Restore the storage mystorage of the current component from a snapshot
+  %[1]s mystorage --snapshot mystorage-nodejs-20210101120000
+
+  # Restore the storage mystorage of the current component from a local archive
+  %[1]s mystorage --archive mystorage.tar.gz
+	`)
+)
+
+// RestoreOptions encapsulates the options for the odo storage restore command
+type RestoreOptions struct {
+	storageName      string
+	archivePath      string
+	snapshotName     string
+	forceFlag        bool
+	componentContext string
+
+	*genericclioptions.Context
+}
+
+// NewStorageRestoreOptions creates a new RestoreOptions instance
+func NewStorageRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{}
+}
+
+// Complete completes RestoreOptions after they've been created
+func (o *RestoreOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = genericclioptions.New(genericclioptions.CreateParameters{
+		Cmd:              cmd,
+		DevfilePath:      component.DevfilePath,
+		ComponentContext: o.componentContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	o.storageName = args[0]
+	return
+}
+
+// Validate validates the RestoreOptions based on completed values
+func (o *RestoreOptions) Validate() (err error) {
+	if o.archivePath != "" && o.snapshotName != "" {
+		return errors.New("--archive and --snapshot can't be used together")
+	}
+	return validateStorageExists(o.Context, o.storageName)
+}
+
+// Run contains the logic for the odo storage restore command
+func (o *RestoreOptions) Run(cmd *cobra.Command) (err error) {
+	componentName := o.LocalConfigProvider.GetName()
+
+	var successMessage string
+	if o.archivePath != "" {
+		s := log.Spinnerf("Restoring storage %s from archive %s", o.storageName, o.archivePath)
+		err = storage.RestoreFromArchive(o.KClient, componentName, o.storageName, o.archivePath)
+		s.End(err == nil)
+		if err != nil {
+			return err
+		}
+		successMessage = fmt.Sprintf("Restored storage %s from archive %s", o.storageName, o.archivePath)
+	} else {
+		if o.snapshotName == "" {
+			o.snapshotName, err = storage.GetLatestSnapshot(o.KClient, componentName, o.storageName)
+			if err != nil {
+				return err
+			}
+		}
+
+		restoreMsg := fmt.Sprintf("Are you sure you want to replace the data of storage %v with snapshot %v? Component %v will be restarted", o.storageName, o.snapshotName, componentName)
+		if !log.IsJSON() && !o.forceFlag && !ui.Proceed(restoreMsg) {
+			return fmt.Errorf("aborting restore of storage: %v", o.storageName)
+		}
+		if err = storage.RestoreFromSnapshot(o.KClient, componentName, o.storageName, o.snapshotName); err != nil {
+			return err
+		}
+		successMessage = fmt.Sprintf("Restored storage %s from snapshot %s", o.storageName, o.snapshotName)
+	}
+
+	if log.IsJSON() {
+		storage.MachineReadableSuccessOutput(o.storageName, successMessage)
+	} else {
+		log.Successf(successMessage)
+	}
+	return nil
+}
+
+// NewCmdStorageRestore implements the odo storage restore command.
+func NewCmdStorageRestore(name, fullName string) *cobra.Command {
+	o := NewStorageRestoreOptions()
+	storageRestoreCmd := &cobra.Command{
+		Use:         name + " STORAGE_NAME",
+		Short:       storageRestoreShortDesc,
+		Long:        storageRestoreLongDesc,
+		Example:     fmt.Sprintf(storageRestoreExample, fullName),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	storageRestoreCmd.Flags().StringVar(&o.archivePath, "archive", "", "Restore the storage from this local archive created by 'odo storage backup'")
+	storageRestoreCmd.Flags().StringVar(&o.snapshotName, "snapshot", "", "Restore the storage from this volume snapshot, the latest snapshot of the storage is used by default")
+	storageRestoreCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Restore the storage from a snapshot without prompting")
+	completion.RegisterCommandHandler(storageRestoreCmd, completion.StorageDeleteCompletionHandler)
+
+	genericclioptions.AddContextFlag(storageRestoreCmd, &o.componentContext)
+	completion.RegisterCommandFlagHandler(storageRestoreCmd, "context", completion.FileCompletionHandler)
+	completion.RegisterCommandFlagHandler(storageRestoreCmd, "archive", completion.FileCompletionHandler)
+
+	return storageRestoreCmd
+}
@@ -41,18 +41,18 @@ func (o *TelemetryOptions) Run(cmd *cobra.Command) (err error) {
 		return nil
 	}
 
-	segmentClient, err := segment.NewClient(cfg)
+	sink, err := segment.NewSink(cfg)
 	if err != nil {
-		klog.V(4).Infof("Cannot create a segment client. Will not send any data: %q", err)
+		klog.V(4).Infof("Cannot create the telemetry sink. Will not send any data: %q", err)
+		return nil
 	}
-	defer segmentClient.Close()
 
-	err = segmentClient.Upload(o.telemetryData)
+	err = sink.Upload(o.telemetryData)
 	if err != nil {
 		klog.V(4).Infof("Cannot send data to telemetry: %q", err)
 	}
 
-	return segmentClient.Close()
+	return sink.Close()
 }
 
 func NewCmdTelemetry(name string) *cobra.Command {
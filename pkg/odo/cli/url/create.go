@@ -42,6 +42,15 @@ var (
 	# Create a URL of ingress kind for the current component with a host
 	%[1]s --port 8080 --host example.com --ingress
 
+	# Create a URL of ingress kind served by a specific ingress controller
+	%[1]s --port 8080 --host example.com --ingress --ingress-class nginx
+
+	# Create a URL of HTTPRoute kind attached to the Gateway 'external' of the namespace 'infra'
+	%[1]s --port 8080 --host example.com --httproute --gateway infra/external
+
+	# Create a URL reached on localhost:8080 by forwarding the port with 'odo url forward'
+	%[1]s --port 8080 --portforward --local-port 8080
+
 	# Create a secure URL for the current component
 	%[1]s --port 8080 --secure
 
@@ -66,7 +75,14 @@ type CreateOptions struct {
 	protocol    string // protocol of the URL
 	container   string // container to which the URL belongs
 	wantIngress bool
-	url         localConfigProvider.LocalURL
+	// wantHTTPRoute is true to create a Gateway API HTTPRoute
+	wantHTTPRoute bool
+	ingressClass  string // ingressClass is the class of the ingress controller serving the URL
+	gateway       string // gateway is the Gateway the HTTPRoute is attached to
+	// wantPortForward is true to reach the URL by forwarding its port to localhost
+	wantPortForward bool
+	localPort       int // localPort is the local port of the URL of portforward kind
+	url             localConfigProvider.LocalURL
 }
 
 // NewURLCreateOptions creates a new CreateOptions instance
@@ -91,6 +107,10 @@ func (o *CreateOptions) Complete(_ string, cmd *cobra.Command, args []string) (e
 	var urlType localConfigProvider.URLKind
 	if o.wantIngress {
 		urlType = localConfigProvider.INGRESS
+	} else if o.wantHTTPRoute {
+		urlType = localConfigProvider.HTTPROUTE
+	} else if o.wantPortForward {
+		urlType = localConfigProvider.PORTFORWARD
 	}
 
 	// get the name
@@ -109,6 +129,9 @@ func (o *CreateOptions) Complete(_ string, cmd *cobra.Command, args []string) (e
 		Container: o.container,
 		Protocol:  o.protocol,
 		Path:      o.path,
+
+		IngressClass: o.ingressClass,
+		Gateway:      o.gateway,
 	}
 
 	// complete the URL
@@ -117,6 +140,14 @@ func (o *CreateOptions) Complete(_ string, cmd *cobra.Command, args []string) (e
 		return err
 	}
 
+	if o.url.Kind == localConfigProvider.PORTFORWARD {
+		// the local port is the same as the remote one by default
+		if o.localPort == -1 {
+			o.localPort = o.url.Port
+		}
+		o.url.ExposedPort = o.localPort
+	}
+
 	if o.now {
 		prjName := o.Context.LocalConfigProvider.GetNamespace()
 		o.ResolveSrcAndConfigFlags()
@@ -140,6 +171,18 @@ func (o *CreateOptions) Validate() (err error) {
 	if len(o.urlName) > 63 {
 		errorList = append(errorList, "URL name must be shorter than 63 characters")
 	}
+	kinds := 0
+	for _, want := range []bool{o.wantIngress, o.wantHTTPRoute, o.wantPortForward} {
+		if want {
+			kinds++
+		}
+	}
+	if kinds > 1 {
+		errorList = append(errorList, "only one of --ingress, --httproute and --portforward can be used")
+	}
+	if o.localPort != -1 && !o.wantPortForward {
+		errorList = append(errorList, "--local-port can only be used with --portforward")
+	}
 
 	// validate the URL
 	err = o.LocalConfigProvider.ValidateURL(o.url)
@@ -212,7 +255,12 @@ func NewCmdURLCreate(name, fullName string) *cobra.Command {
 	urlCreateCmd.Flags().StringVar(&o.tlsSecret, "tls-secret", "", "TLS secret name for the url of the component if the user bring their own TLS secret")
 	urlCreateCmd.Flags().StringVarP(&o.host, "host", "", "", "Cluster IP for this URL")
 	urlCreateCmd.Flags().BoolVar(&o.wantIngress, "ingress", false, "Create an Ingress instead of Route on OpenShift clusters")
-	urlCreateCmd.Flags().BoolVarP(&o.secureURL, "secure", "", false, "Create a secure HTTPS URL")
+	urlCreateCmd.Flags().StringVar(&o.ingressClass, "ingress-class", "", "Class of the ingress controller serving the Ingress")
+	urlCreateCmd.Flags().BoolVar(&o.wantHTTPRoute, "httproute", false, "Create a Gateway API HTTPRoute instead of an Ingress or a Route")
+	urlCreateCmd.Flags().StringVar(&o.gateway, "gateway", "", "Gateway, as [namespace/]name, the HTTPRoute is attached to")
+	urlCreateCmd.Flags().BoolVar(&o.wantPortForward, "portforward", false, "Reach the URL on localhost by forwarding its port with 'odo url forward', for clusters without ingress or routes")
+	urlCreateCmd.Flags().IntVar(&o.localPort, "local-port", -1, "Local port of the URL of portforward kind, the port of the URL by default")
+	urlCreateCmd.Flags().BoolVarP(&o.secureURL, "secure", "", false, "Create a secure HTTPS URL, using a certificate signed by the local development CA of odo when no TLS secret is provided")
 	urlCreateCmd.Flags().StringVarP(&o.path, "path", "", "", "path for this URL")
 	urlCreateCmd.Flags().StringVarP(&o.protocol, "protocol", "", string(devfilev1.HTTPEndpointProtocol), "protocol for this URL")
 	urlCreateCmd.Flags().StringVarP(&o.container, "container", "", "", "container of the endpoint in devfile")
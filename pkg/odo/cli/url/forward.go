@@ -0,0 +1,142 @@
+package url
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/envinfo"
+	clicomponent "github.com/openshift/odo/pkg/odo/cli/component"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/url"
+	"github.com/spf13/cobra"
+
+	k8sgenclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const forwardRecommendedCommandName = "forward"
+
+var (
+	urlForwardShortDesc = `Forward the ports of the URLs of portforward kind`
+	urlForwardLongDesc  = ktemplates.LongDesc(`Forward the ports of the URLs of portforward kind to the pod of the component.
+
+	The URLs are reachable on localhost until the command is interrupted. The ports are forwarded again when the pod
+	of the component is replaced, for example after a push.`)
+	urlForwardExample = ktemplates.Examples(`  # Forward the ports of the URLs of portforward kind of the component
+ %[1]s
+	`)
+)
+
+// ForwardOptions encapsulates the options for the odo url forward command
+type ForwardOptions struct {
+	componentContext string
+	*genericclioptions.Context
+
+	portPairs  []string
+	localPorts []int
+
+	portForwarder *debug.DefaultPortForwarder
+	// stopChannel is used to stop port forwarding
+	stopChannel chan struct{}
+}
+
+// NewURLForwardOptions creates a new ForwardOptions instance
+func NewURLForwardOptions() *ForwardOptions {
+	return &ForwardOptions{}
+}
+
+// Complete completes ForwardOptions after they've been created
+func (o *ForwardOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = genericclioptions.New(genericclioptions.CreateParameters{
+		Cmd:              cmd,
+		DevfilePath:      clicomponent.DevfilePath,
+		ComponentContext: o.componentContext,
+	})
+	if err != nil {
+		return err
+	}
+
+	urls, err := o.LocalConfigProvider.ListURLs()
+	if err != nil {
+		return err
+	}
+	o.portPairs, o.localPorts = url.GetPortForwardPairs(urls)
+
+	// Using Discard streams because nothing important is logged
+	o.portForwarder = debug.NewDefaultPortForwarder(o.LocalConfigProvider.GetName(), o.LocalConfigProvider.GetApplication(), o.LocalConfigProvider.GetNamespace(), o.Client, o.KClient, k8sgenclioptions.NewTestIOStreamsDiscard())
+	o.stopChannel = make(chan struct{}, 1)
+	return nil
+}
+
+// Validate validates the ForwardOptions based on completed values
+func (o *ForwardOptions) Validate() (err error) {
+	if _, ok := o.LocalConfigProvider.(*envinfo.EnvSpecificInfo); !ok {
+		return fmt.Errorf("URLs of portforward kind are only supported by devfile components")
+	}
+	if len(o.portPairs) == 0 {
+		return fmt.Errorf("no URL of portforward kind found for component %v. Refer `odo url create -h` to add one", o.LocalConfigProvider.GetName())
+	}
+
+	// the local ports must be free
+	for _, port := range o.localPorts {
+		listener, err := net.Listen("tcp", "localhost:"+strconv.Itoa(port))
+		if err != nil {
+			return fmt.Errorf("the local port %d is not free, cause: %v", port, err)
+		}
+		if err = listener.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run contains the logic for the odo url forward command
+func (o *ForwardOptions) Run(cmd *cobra.Command) (err error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	defer signal.Stop(signals)
+
+	componentName := o.LocalConfigProvider.GetName()
+	appName := o.LocalConfigProvider.GetApplication()
+	projectName := o.LocalConfigProvider.GetNamespace()
+	defer os.RemoveAll(url.GetPortForwardInfoFilePath(componentName, appName, projectName))
+
+	go func() {
+		<-signals
+		close(o.stopChannel)
+	}()
+
+	err = url.WritePortForwardInfo(componentName, appName, projectName, o.localPorts)
+	if err != nil {
+		return err
+	}
+
+	return o.portForwarder.KeepForwarding(o.portPairs, o.stopChannel, true)
+}
+
+// NewCmdURLForward implements the odo url forward command.
+func NewCmdURLForward(name, fullName string) *cobra.Command {
+	o := NewURLForwardOptions()
+	urlForwardCmd := &cobra.Command{
+		Use:     name,
+		Short:   urlForwardShortDesc,
+		Long:    urlForwardLongDesc,
+		Example: fmt.Sprintf(urlForwardExample, fullName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	genericclioptions.AddContextFlag(urlForwardCmd, &o.componentContext)
+
+	return urlForwardCmd
+}
@@ -23,8 +23,13 @@ const listRecommendedCommandName = "list"
 
 var (
 	urlListShortDesc = `List URLs`
-	urlListLongDesc  = ktemplates.LongDesc(`Lists all the available URLs which can be used to access the components.`)
-	urlListExample   = ktemplates.Examples(` # List the available URLs
+	urlListLongDesc  = ktemplates.LongDesc(`Lists all the available URLs which can be used to access the components.
+
+	The reachability of the pushed and forwarded URLs is checked with a probe depending on the protocol of their devfile endpoint:
+	an HTTP request for http(s) and ws(s) endpoints, a TCP connection for tcp endpoints, and the standard gRPC health check
+	when the endpoint has the "odo.dev/probe-type: grpc" attribute. The "odo.dev/probe-path" and "odo.dev/probe-status" attributes
+	set the path requested by HTTP probes and the accepted status codes, e.g. "200,3xx".`)
+	urlListExample = ktemplates.Examples(` # List the available URLs
   %[1]s
 	`)
 )
@@ -78,6 +83,7 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return err
 	}
+	url.ProbeURLs(&urls, componentName, url.ProbeTimeout)
 	if log.IsJSON() {
 		machineoutput.OutputSuccess(urls)
 	} else {
@@ -87,15 +93,23 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 
 		log.Infof("Found the following URLs for component %v", componentName)
 		tabWriterURL := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
-		fmt.Fprintln(tabWriterURL, "NAME", "\t", "STATE", "\t", "URL", "\t", "PORT", "\t", "SECURE", "\t", "KIND")
+		fmt.Fprintln(tabWriterURL, "NAME", "\t", "STATE", "\t", "URL", "\t", "PORT", "\t", "SECURE", "\t", "KIND", "\t", "REACHABLE", "\t", "LATENCY")
 
 		// are there changes between local and cluster states?
 		outOfSync := false
+		notForwarded := false
 		for _, u := range urls.Items {
+			if u.Spec.Kind == localConfigProvider.PORTFORWARD {
+				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetPortForwardURLString(u), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind, "\t", reachability(u), "\t", latency(u))
+				if u.Status.State != url.StateTypeForwarded {
+					notForwarded = true
+				}
+				continue
+			}
 			if u.Spec.Kind == localConfigProvider.ROUTE {
-				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetURLString(u.Spec.Protocol, u.Spec.Host, "", o.Context.LocalConfigInfo.Exists()), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind)
+				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetURLString(u.Spec.Protocol, u.Spec.Host, "", o.Context.LocalConfigInfo.Exists()), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind, "\t", reachability(u), "\t", latency(u))
 			} else {
-				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetURLString(url.GetProtocol(routev1.Route{}, url.ConvertIngressURLToIngress(u, o.EnvSpecificInfo.GetName())), "", u.Spec.Host, false), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind)
+				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetURLString(url.GetProtocol(routev1.Route{}, url.ConvertIngressURLToIngress(u, o.EnvSpecificInfo.GetName())), "", u.Spec.Host, false), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind, "\t", reachability(u), "\t", latency(u))
 			}
 			if u.Status.State != url.StateTypePushed {
 				outOfSync = true
@@ -105,6 +119,9 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 		if outOfSync {
 			log.Info("There are local changes. Please run 'odo push'.")
 		}
+		if notForwarded {
+			log.Info("Some ports are not forwarded. Please run 'odo url forward'.")
+		}
 	}
 
 	return
@@ -119,7 +136,7 @@ func NewCmdURLList(name, fullName string) *cobra.Command {
 		Long:        urlListLongDesc,
 		Example:     fmt.Sprintf(urlListExample, fullName),
 		Args:        cobra.NoArgs,
-		Annotations: map[string]string{"machineoutput": "json"},
+		Annotations: map[string]string{"machineoutput": "all"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
 		},
@@ -129,3 +146,22 @@ func NewCmdURLList(name, fullName string) *cobra.Command {
 
 	return urlListCmd
 }
+
+// reachability returns the reachability of the URL to display
+func reachability(u url.URL) string {
+	if u.Status.Reachability == nil || u.Status.Reachability.Probe == localConfigProvider.NoProbe {
+		return "-"
+	}
+	if u.Status.Reachability.Reachable {
+		return "Yes"
+	}
+	return "No"
+}
+
+// latency returns the latency of the URL to display
+func latency(u url.URL) string {
+	if u.Status.Reachability == nil || !u.Status.Reachability.Reachable {
+		return "-"
+	}
+	return fmt.Sprintf("%dms", u.Status.Reachability.LatencyMillis)
+}
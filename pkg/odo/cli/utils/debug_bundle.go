@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/occlient"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/segment"
+	"github.com/openshift/odo/pkg/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	debugBundleCommandName = "debug-bundle"
+
+	// maxBundleTelemetryRecords is the number of the most recent telemetry records included in the bundle
+	maxBundleTelemetryRecords = 100
+)
+
+var (
+	debugBundleExample = ktemplates.Examples(`  # Create a debug bundle for the component in the current directory
+  %[1]s
+
+  # Create a debug bundle for the component in the frontend directory, in the bundle.tar.gz file
+  %[1]s --context frontend --output bundle.tar.gz
+`)
+	debugBundleLongDesc = ktemplates.LongDesc(`Create an archive holding the information needed to report a bug.
+
+The archive contains the versions of odo and of the cluster, the preferences, the env.yaml and the devfile of the component,
+and the most recent telemetry records of the file telemetry sink.
+The name prefix, the trusted keys, the telemetry endpoint and the credentials of the registries are removed from the preferences,
+and the home directory of the user is replaced by $HOME in all the files.
+Review the content of the archive before attaching it to a bug report.`)
+)
+
+// bundleFile is a file of the debug bundle
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// DebugBundleOptions encapsulates the options for the utils debug-bundle command
+type DebugBundleOptions struct {
+	contextFlag string
+	outputFlag  string
+}
+
+// NewDebugBundleOptions creates a new DebugBundleOptions instance
+func NewDebugBundleOptions() *DebugBundleOptions {
+	return &DebugBundleOptions{}
+}
+
+// Complete completes DebugBundleOptions after they've been created
+func (o *DebugBundleOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if o.outputFlag == "" {
+		o.outputFlag = fmt.Sprintf("odo-debug-bundle-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+	return
+}
+
+// Validate validates the DebugBundleOptions based on completed values
+func (o *DebugBundleOptions) Validate() (err error) {
+	if _, err := os.Stat(o.outputFlag); err == nil {
+		return fmt.Errorf("the file %s already exists", o.outputFlag)
+	}
+	return
+}
+
+// Run contains the logic for the command
+func (o *DebugBundleOptions) Run(cmd *cobra.Command) (err error) {
+	files := []bundleFile{
+		{name: "version.txt", data: []byte(getVersionInfo())},
+		{name: "cluster.txt", data: []byte(getClusterInfo())},
+	}
+
+	cfg, err := preference.New()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the preferences")
+	}
+	pref, err := yaml.Marshal(sanitizePreference(cfg.Preference))
+	if err != nil {
+		return err
+	}
+	files = append(files, bundleFile{name: "preference.yaml", data: pref})
+
+	envInfo, err := envinfo.NewEnvSpecificInfo(o.contextFlag)
+	if err != nil {
+		return err
+	}
+	componentFiles := []struct{ name, path string }{
+		{name: "env.yaml", path: envInfo.Filename},
+		{name: "devfile.yaml", path: envInfo.GetDevfilePath()},
+	}
+	for _, componentFile := range componentFiles {
+		data, err := ioutil.ReadFile(componentFile.path)
+		if err != nil {
+			log.Warningf("The %s file is not included: %v", componentFile.name, err)
+			continue
+		}
+		files = append(files, bundleFile{name: componentFile.name, data: data})
+	}
+
+	records, err := getTelemetryRecords(cfg)
+	if err != nil {
+		log.Warningf("The telemetry records are not included: %v", err)
+	} else {
+		var lines []string
+		for _, record := range records {
+			line, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, string(line))
+		}
+		files = append(files, bundleFile{name: "telemetry.jsonl", data: []byte(strings.Join(lines, "\n"))})
+	}
+
+	if err = writeBundle(o.outputFlag, files); err != nil {
+		return err
+	}
+	log.Successf("The debug bundle was written to %s", o.outputFlag)
+	return nil
+}
+
+// getVersionInfo returns the versions of odo and of its platform
+func getVersionInfo() string {
+	return fmt.Sprintf("odo %s (%s)\ngo %s %s/%s\n", version.VERSION, version.GITCOMMIT, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// getClusterInfo returns the versions of the cluster, or the reason why they are unknown
+func getClusterInfo() string {
+	client, err := occlient.New()
+	if err != nil {
+		return fmt.Sprintf("unable to connect to the cluster: %s\n", segment.SetError(err))
+	}
+	serverInfo, err := client.GetServerVersion()
+	if err != nil {
+		return fmt.Sprintf("unable to get the versions of the cluster: %s\n", segment.SetError(err))
+	}
+	info := fmt.Sprintf("Server: %s\nKubernetes: %s\n", serverInfo.Address, serverInfo.KubernetesVersion)
+	if serverInfo.OpenShiftVersion != "" {
+		info += fmt.Sprintf("OpenShift: %s\n", serverInfo.OpenShiftVersion)
+	}
+	return info
+}
+
+// getTelemetryRecords returns the most recent records of the file telemetry sink
+func getTelemetryRecords(cfg *preference.PreferenceInfo) ([]segment.TelemetryRecord, error) {
+	path := cfg.GetTelemetryEndpoint()
+	if cfg.GetTelemetrySink() != preference.TelemetrySinkFile || path == "" {
+		var err error
+		if path, err = segment.DefaultTelemetryFile(); err != nil {
+			return nil, err
+		}
+	}
+	return segment.ReadTelemetryRecords(path, maxBundleTelemetryRecords)
+}
+
+// sanitizePreference returns a copy of the preferences without the personal information
+func sanitizePreference(pref preference.Preference) preference.Preference {
+	sanitizer := segment.Sanitizer
+	settings := &pref.OdoSettings
+	if settings.NamePrefix != nil {
+		settings.NamePrefix = &sanitizer
+	}
+	if settings.TelemetryEndpoint != nil {
+		settings.TelemetryEndpoint = &sanitizer
+	}
+	if settings.TrustedKeys != nil {
+		keys := make([]string, len(*settings.TrustedKeys))
+		for i := range keys {
+			keys[i] = sanitizer
+		}
+		settings.TrustedKeys = &keys
+	}
+	if settings.RegistryList != nil {
+		registries := make([]preference.Registry, len(*settings.RegistryList))
+		for i, registry := range *settings.RegistryList {
+			if u, err := url.Parse(registry.URL); err == nil && u.User != nil {
+				u.User = url.User(sanitizer)
+				registry.URL = u.String()
+			}
+			registries[i] = registry
+		}
+		settings.RegistryList = &registries
+	}
+	return pref
+}
+
+// writeBundle writes the files to a gzipped tar archive, the paths of the home directory of the user being sanitized
+func writeBundle(path string, files []bundleFile) (err error) {
+	archive, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create the debug bundle %s", path)
+	}
+	defer func() {
+		if cerr := archive.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	var username, home string
+	if currentUser, err := user.Current(); err == nil {
+		username, home = currentUser.Username, currentUser.HomeDir
+	}
+
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		data := []byte(sanitizeHomePaths(string(file.data), username, home))
+		header := &tar.Header{Name: file.name, Mode: 0600, Size: int64(len(data)), ModTime: time.Now()}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err = tarWriter.Write(data); err != nil {
+			return err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// sanitizeHomePaths replaces the home directory of the user by $HOME, and the user name in the paths of home
+// directories, such as /home/<user>, by segment.Sanitizer. The user name is not replaced elsewhere, as a short
+// or common name would corrupt unrelated content.
+func sanitizeHomePaths(data, username, home string) string {
+	// the paths are only replaced when followed by a separator, /home/dev must not match /home/developer
+	boundary := `([/\\\s"':,;]|$)`
+	if home != "" && home != "/" {
+		homePattern := regexp.MustCompile(`(?m)` + regexp.QuoteMeta(home) + boundary)
+		data = homePattern.ReplaceAllString(data, "$$HOME$1")
+	}
+	if username != "" {
+		// the name of a Windows user is qualified by its domain, e.g. DOMAIN\user
+		name := username[strings.LastIndex(username, `\`)+1:]
+		userPattern := regexp.MustCompile(`(?m)(/home/|/Users/|\\Users\\)` + regexp.QuoteMeta(name) + boundary)
+		data = userPattern.ReplaceAllString(data, "${1}"+segment.Sanitizer+"$2")
+	}
+	return data
+}
+
+// NewCmdDebugBundle implements the utils debug-bundle odo command
+func NewCmdDebugBundle(name, fullName string) *cobra.Command {
+	o := NewDebugBundleOptions()
+	debugBundleCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Create an archive holding the information needed to report a bug",
+		Long:    debugBundleLongDesc,
+		Example: fmt.Sprintf(debugBundleExample, fullName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	debugBundleCmd.Flags().StringVar(&o.outputFlag, "output", "", "Path of the archive (default odo-debug-bundle-<date>.tar.gz)")
+	genericclioptions.AddContextFlag(debugBundleCmd, &o.contextFlag)
+	return debugBundleCmd
+}
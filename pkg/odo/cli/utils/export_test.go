@@ -0,0 +1,12 @@
+package utils
+
+// SchemaCommands returns the commands whose machine readable output is described by a published schema
+func SchemaCommands() map[string]bool {
+	commands := map[string]bool{}
+	for _, schema := range schemas {
+		for _, command := range schema.commands {
+			commands[command] = true
+		}
+	}
+	return commands
+}
@@ -0,0 +1,376 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/application"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	catalogdescribe "github.com/openshift/odo/pkg/odo/cli/catalog/describe"
+	cataloglist "github.com/openshift/odo/pkg/odo/cli/catalog/list"
+	"github.com/openshift/odo/pkg/odo/cli/plugins"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/project"
+	"github.com/openshift/odo/pkg/service"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/openshift/odo/pkg/url"
+	"github.com/openshift/odo/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const schemaCommandName = "schema"
+
+var (
+	schemaExample = ktemplates.Examples(`  # List the schemas of the machine readable output
+  %[1]s
+
+  # Print the JSON Schema of the 'odo url list -o json' output
+  %[1]s url-list
+
+  # Write every JSON Schema to the schemas directory
+  %[1]s --dir schemas
+`)
+	schemaLongDesc = ktemplates.LongDesc(`Print the JSON Schemas of the machine readable output of odo.
+
+The schemas are generated from the structures of the documents printed with -o json, and of the events of 'odo push -o json'.
+Every document and event carries the version of its schema in the schemaVersion field; the major version of the schemas is increased when a change is not backward compatible.`)
+)
+
+// schemaDefinition is a schema of a machine readable document or event
+type schemaDefinition struct {
+	name string
+	// commands are the paths of the commands printing the document or event
+	commands []string
+	// description replaces the list of the commands when it doesn't describe the output well enough
+	description string
+	generate    func() *machineoutput.JSONSchema
+}
+
+// outputOf returns the description of the output described by the schema
+func (s schemaDefinition) outputOf() string {
+	if s.description != "" {
+		return s.description
+	}
+	return strings.Join(s.commands, ", ")
+}
+
+// schemas are the published schemas, sorted by name
+var schemas = []schemaDefinition{
+	{
+		name:     "application-description",
+		commands: []string{"odo app describe"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("application-description", application.App{})
+		},
+	},
+	{
+		name:     "application-list",
+		commands: []string{"odo app list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("application-list", application.AppList{})
+		},
+	},
+	{
+		name:     "catalog-component-description",
+		commands: []string{"odo catalog describe component"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("catalog-component-description", []catalogdescribe.DevfileComponentDescription{})
+		},
+	},
+	{
+		name:     "catalog-component-list",
+		commands: []string{"odo catalog list components"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("catalog-component-list", cataloglist.CombinedCatalogList{})
+		},
+	},
+	{
+		name:     "catalog-service-description",
+		commands: []string{"odo catalog describe service"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("catalog-service-description", service.JSONCRDDescriptionRepr{})
+		},
+	},
+	{
+		name:     "catalog-service-list",
+		commands: []string{"odo catalog list services"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("catalog-service-list", cataloglist.CatalogListOutput{})
+		},
+	},
+	{
+		name:     "component",
+		commands: []string{"odo create", "odo component create"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("component", component.Component{})
+		},
+	},
+	{
+		name:     "component-description",
+		commands: []string{"odo describe", "odo component describe"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("component-description", component.ComponentFullDescription{})
+		},
+	},
+	{
+		name:     "component-list",
+		commands: []string{"odo list", "odo component list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("component-list", component.CombinedComponentList{})
+		},
+	},
+	{
+		name:     "config",
+		commands: []string{"odo config view"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("config", component.JSONConfigRepr{})
+		},
+	},
+	{
+		name:     "debug-info",
+		commands: []string{"odo debug info"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("debug-info", debug.OdoDebugFile{})
+		},
+	},
+	{
+		name:     "environment",
+		commands: []string{"odo env view"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("environment", envinfo.JSONEnvInfoRepr{})
+		},
+	},
+	{
+		name:     "environment-list",
+		commands: []string{"odo env list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("environment-list", envinfo.EnvironmentList{})
+		},
+	},
+	{
+		name:        "error",
+		description: "errors of the commands run with -o json",
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("error", machineoutput.GenericError{})
+		},
+	},
+	{
+		name:        "events",
+		commands:    []string{"odo push", "odo component push", "odo status", "odo component status"},
+		description: "events of odo push -o json and odo status -o json, one per line",
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("events", machineoutput.MachineEventWrapper{})
+		},
+	},
+	{
+		name:        "operator-service-list",
+		commands:    []string{"odo service list"},
+		description: "odo service list, on the clusters supporting Operators",
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("operator-service-list", []unstructured.Unstructured{})
+		},
+	},
+	{
+		name:     "plugin-list",
+		commands: []string{"odo plugin list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("plugin-list", plugins.PluginList{})
+		},
+	},
+	{
+		name:     "preference-list",
+		commands: []string{"odo preference view"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("preference-list", preference.PreferenceList{})
+		},
+	},
+	{
+		name:     "project",
+		commands: []string{"odo project get"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("project", project.Project{})
+		},
+	},
+	{
+		name:     "project-list",
+		commands: []string{"odo project list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("project-list", project.ProjectList{})
+		},
+	},
+	{
+		name:     "registry-list",
+		commands: []string{"odo registry list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("registry-list", machineoutput.RegistryListOutput{})
+		},
+	},
+	{
+		name:        "service-list",
+		commands:    []string{"odo service list"},
+		description: "odo service list, on the clusters without Operators",
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("service-list", service.ServiceList{})
+		},
+	},
+	{
+		name:     "storage",
+		commands: []string{"odo storage create"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("storage", storage.Storage{})
+		},
+	},
+	{
+		name:     "storage-list",
+		commands: []string{"odo storage list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("storage-list", storage.StorageList{})
+		},
+	},
+	{
+		name:     "success",
+		commands: []string{"odo project create", "odo project delete", "odo storage backup", "odo storage delete", "odo storage restore"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("success", machineoutput.GenericSuccess{})
+		},
+	},
+	{
+		name:     "url-list",
+		commands: []string{"odo url list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("url-list", url.URLList{})
+		},
+	},
+	{
+		name:     "workspace-component-list",
+		commands: []string{"odo workspace list"},
+		generate: func() *machineoutput.JSONSchema {
+			return machineoutput.GenerateDocumentSchema("workspace-component-list", workspace.ComponentList{})
+		},
+	},
+}
+
+// SchemaOptions encapsulates the options for the utils schema command
+type SchemaOptions struct {
+	dirFlag string
+
+	schema *schemaDefinition
+}
+
+// NewSchemaOptions creates a new SchemaOptions instance
+func NewSchemaOptions() *SchemaOptions {
+	return &SchemaOptions{}
+}
+
+// Complete completes SchemaOptions after they've been created
+func (o *SchemaOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 1 {
+		o.schema = getSchema(args[0])
+		if o.schema == nil {
+			return fmt.Errorf("unknown schema %s, see '%s' for the list of the schemas", args[0], cmd.CommandPath())
+		}
+	}
+	return
+}
+
+// Validate validates the SchemaOptions based on completed values
+func (o *SchemaOptions) Validate() (err error) {
+	if o.dirFlag != "" && o.schema != nil {
+		return fmt.Errorf("the --dir flag writes every schema, it can't be used with a schema name")
+	}
+	return
+}
+
+// Run contains the logic for the command
+func (o *SchemaOptions) Run(cmd *cobra.Command) (err error) {
+	if o.dirFlag != "" {
+		if err = writeSchemas(o.dirFlag); err != nil {
+			return err
+		}
+		log.Successf("The schemas were written to %s", o.dirFlag)
+		return nil
+	}
+
+	if o.schema != nil {
+		data, err := marshalSchema(o.schema.generate())
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME", "\t", "OUTPUT OF")
+	for _, schema := range schemas {
+		fmt.Fprintln(w, schema.name, "\t", schema.outputOf())
+	}
+	return w.Flush()
+}
+
+// getSchema returns the schema with the given name, nil if there is none
+func getSchema(name string) *schemaDefinition {
+	for i := range schemas {
+		if schemas[i].name == name {
+			return &schemas[i]
+		}
+	}
+	return nil
+}
+
+// marshalSchema returns the indented JSON representation of the schema
+func marshalSchema(schema *machineoutput.JSONSchema) ([]byte, error) {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// writeSchemas writes every schema to <dir>/<name>.json
+func writeSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, schema := range schemas {
+		data, err := marshalSchema(schema.generate())
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, schema.name+".json")
+		if err = ioutil.WriteFile(path, data, 0644); err != nil { // #nosec G306
+			return errors.Wrapf(err, "unable to write the schema %s", path)
+		}
+	}
+	return nil
+}
+
+// NewCmdSchema implements the utils schema odo command
+func NewCmdSchema(name, fullName string) *cobra.Command {
+	o := NewSchemaOptions()
+	schemaCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s [NAME]", name),
+		Short:   "Print the JSON Schemas of the machine readable output",
+		Long:    schemaLongDesc,
+		Example: fmt.Sprintf(schemaExample, fullName),
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	schemaCmd.Flags().StringVar(&o.dirFlag, "dir", "", "Write every schema to <name>.json files in the directory")
+	return schemaCmd
+}
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/openshift/odo/pkg/odo/cli"
+	"github.com/openshift/odo/pkg/odo/cli/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/klog"
+)
+
+// machineReadableCommands returns the paths of the commands supporting the machine readable output
+func machineReadableCommands(cmd *cobra.Command) []string {
+	var commands []string
+	if _, ok := cmd.Annotations["machineoutput"]; ok {
+		commands = append(commands, cmd.CommandPath())
+	}
+	for _, subCmd := range cmd.Commands() {
+		commands = append(commands, machineReadableCommands(subCmd)...)
+	}
+	return commands
+}
+
+// TestSchemaCommands fails when the output of a command supporting -o json has no published schema
+func TestSchemaCommands(t *testing.T) {
+	klog.InitFlags(nil)
+	commands := machineReadableCommands(cli.NewCmdOdo("odo", "odo"))
+	if len(commands) == 0 {
+		t.Fatal("no command supports the machine readable output")
+	}
+
+	documented := utils.SchemaCommands()
+	annotated := map[string]bool{}
+	for _, command := range commands {
+		annotated[command] = true
+		if !documented[command] {
+			t.Errorf("the machine readable output of %q has no schema, register it in the schemas of odo utils schema", command)
+		}
+	}
+	for command := range documented {
+		if !annotated[command] {
+			t.Errorf("the schemas document %q, which is not a command supporting the machine readable output", command)
+		}
+	}
+}
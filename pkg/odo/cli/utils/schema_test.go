@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+)
+
+// publishedSchemasDir is the directory of the published schemas, relative to this package
+var publishedSchemasDir = filepath.Join("..", "..", "..", "..", "docs", "schemas")
+
+// majorVersion returns the major version of a semantic version
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
+// TestPublishedSchemas is the contract test of the machine readable output: it fails when a structure changes
+// incompatibly without a new major SchemaVersion, or when the published schemas are outdated
+func TestPublishedSchemas(t *testing.T) {
+	for _, schema := range schemas {
+		t.Run(schema.name, func(t *testing.T) {
+			path := filepath.Join(publishedSchemasDir, schema.name+".json")
+			published, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("the schema is not published, run 'odo utils schema --dir docs/schemas': %v", err)
+			}
+			var publishedSchema machineoutput.JSONSchema
+			if err = json.Unmarshal(published, &publishedSchema); err != nil {
+				t.Fatal(err)
+			}
+
+			generatedSchema := schema.generate()
+			if majorVersion(publishedSchema.Version) == majorVersion(generatedSchema.Version) {
+				for _, incompatibility := range machineoutput.CheckSchemaCompatibility(&publishedSchema, generatedSchema) {
+					t.Errorf("incompatible change of the version %s, increase the major version of machineoutput.SchemaVersion: %s", publishedSchema.Version, incompatibility)
+				}
+			}
+
+			generated, err := marshalSchema(generatedSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(published, generated) {
+				t.Errorf("the published schema %s is outdated, run 'odo utils schema --dir docs/schemas'", path)
+			}
+		})
+	}
+}
+
+func TestSchemaOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		dir     string
+		wantErr bool
+	}{
+		{
+			name: "Case 1: List of the schemas",
+		},
+		{
+			name: "Case 2: Known schema",
+			args: []string{"url-list"},
+		},
+		{
+			name:    "Case 3: Unknown schema",
+			args:    []string{"url"},
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: Directory with a schema name",
+			args:    []string{"url-list"},
+			dir:     "schemas",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewSchemaOptions()
+			o.dirFlag = tt.dir
+			cmd := NewCmdSchema(schemaCommandName, schemaCommandName)
+			err := o.Complete(schemaCommandName, cmd, tt.args)
+			if err == nil {
+				err = o.Validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ const RecommendedCommandName = "utils"
 func NewCmdUtils(name, fullName string) *cobra.Command {
 	terminalCmd := NewCmdTerminal(terminalCommandName, odoutil.GetFullName(fullName, terminalCommandName))
 	convertCmd := NewCmdConvert(convertCommandName, odoutil.GetFullName(fullName, convertCommandName))
+	debugBundleCmd := NewCmdDebugBundle(debugBundleCommandName, odoutil.GetFullName(fullName, debugBundleCommandName))
+	schemaCmd := NewCmdSchema(schemaCommandName, odoutil.GetFullName(fullName, schemaCommandName))
 	utilsCmd := &cobra.Command{
 		Use:   name,
 		Short: "Utilities for terminal commands and modifying odo configurations",
@@ -27,5 +29,7 @@ func NewCmdUtils(name, fullName string) *cobra.Command {
 
 	utilsCmd.AddCommand(terminalCmd)
 	utilsCmd.AddCommand(convertCmd)
+	utilsCmd.AddCommand(schemaCmd)
+	utilsCmd.AddCommand(debugBundleCmd)
 	return utilsCmd
 }
@@ -0,0 +1,103 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const initCommandName = "init"
+
+var (
+	initLongDesc = ktemplates.LongDesc(`
+	Create the workspace file listing the components in the directory and its subdirectories
+
+	The components are found the same way as with 'odo list --path', edit the DependsOn field of the components in the
+	workspace file to push them in order.
+	`)
+
+	initExample = ktemplates.Examples(`
+	# Create the workspace of the components of the current directory
+	%[1]s
+	`)
+)
+
+// InitOptions encapsulates the options for the command
+type InitOptions struct {
+	context string
+	force   bool
+	root    string
+}
+
+// NewInitOptions creates a new InitOptions instance
+func NewInitOptions() *InitOptions {
+	return &InitOptions{}
+}
+
+// Complete completes InitOptions after they've been created
+func (o *InitOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.root = genericclioptions.GetContextFlagValue(cmd)
+	return nil
+}
+
+// Validate validates the InitOptions based on completed values
+func (o *InitOptions) Validate() (err error) {
+	if filename := workspace.GetFilename(o.root); util.CheckPathExists(filename) && !o.force {
+		return errors.Errorf("the workspace file %s already exists, use --force to overwrite it", filename)
+	}
+	return nil
+}
+
+// Run contains the logic for the command
+func (o *InitOptions) Run(cmd *cobra.Command) (err error) {
+	components, err := component.ListDevfileComponentsInPath(nil, []string{o.root})
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return errors.Errorf("no component found in %s, run `odo create` in the directories of the components first", o.root)
+	}
+
+	var references []workspace.ComponentReference
+	for _, comp := range components {
+		context, err := filepath.Rel(o.root, comp.Status.Context)
+		if err != nil {
+			return err
+		}
+		references = append(references, workspace.ComponentReference{Context: filepath.ToSlash(context)})
+		log.Successf("Added the component %s in %s", comp.Name, context)
+	}
+	if err = workspace.Write(o.root, workspace.NewWorkspace(references)); err != nil {
+		return err
+	}
+	log.Infof("\nThe workspace was written to %s", workspace.GetFilename(o.root))
+	return nil
+}
+
+// NewCmdInit implements the workspace init odo command
+func NewCmdInit(name, fullName string) *cobra.Command {
+	o := NewInitOptions()
+	initCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Create the workspace of the components of a directory",
+		Long:    initLongDesc,
+		Example: fmt.Sprintf(initExample, fullName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	genericclioptions.AddContextFlag(initCmd, &o.context)
+	initCmd.Flags().BoolVarP(&o.force, "force", "f", false, "Overwrite the workspace file if it exists")
+
+	return initCmd
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/workspace"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const listCommandName = "list"
+
+var (
+	listLongDesc = ktemplates.LongDesc(`
+	List the components of the workspace, in the order they are pushed
+	`)
+
+	listExample = ktemplates.Examples(`
+	# List the components of the workspace of the current directory
+	%[1]s
+	`)
+)
+
+// ListOptions encapsulates the options for the command
+type ListOptions struct {
+	context    string
+	wi         *workspace.WorkspaceInfo
+	components []workspace.Component
+}
+
+// NewListOptions creates a new ListOptions instance
+func NewListOptions() *ListOptions {
+	return &ListOptions{}
+}
+
+// Complete completes ListOptions after they've been created
+func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.wi, err = workspace.Find(genericclioptions.GetContextFlagValue(cmd))
+	return err
+}
+
+// Validate validates the ListOptions based on completed values
+func (o *ListOptions) Validate() (err error) {
+	o.components, err = o.wi.GetComponents()
+	return err
+}
+
+// Run contains the logic for the command
+func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(workspace.NewComponentList(o.components))
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME", "\t", "CONTEXT", "\t", "PROJECT", "\t", "APPLICATION", "\t", "DEPENDS ON")
+	for _, component := range o.components {
+		context, err := filepath.Rel(o.wi.Root, component.Context)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, component.Name, "\t", context, "\t", component.Project, "\t", component.App, "\t", strings.Join(component.DependsOn, ","))
+	}
+	w.Flush()
+	return nil
+}
+
+// NewCmdList implements the workspace list odo command
+func NewCmdList(name, fullName string) *cobra.Command {
+	o := NewListOptions()
+	listCmd := &cobra.Command{
+		Use:         name,
+		Short:       "List the components of the workspace",
+		Long:        listLongDesc,
+		Example:     fmt.Sprintf(listExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	genericclioptions.AddContextFlag(listCmd, &o.context)
+
+	return listCmd
+}
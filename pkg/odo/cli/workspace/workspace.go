@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/odo/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended workspace command name
+const RecommendedCommandName = "workspace"
+
+var workspaceLongDesc = ktemplates.LongDesc(`Manage the workspace of several components
+
+A workspace lists the component contexts developed together, e.g. the microservices of an application in a monorepo,
+in the .odo/workspace.yaml file of its root directory. The DependsOn field of a component lists the names of the
+components it depends on.
+
+Use the --all flag of 'odo push' and 'odo watch' to work on all the components of the workspace, and the --workspace
+flag of 'odo app delete' to delete them.`)
+
+// NewCmdWorkspace implements the workspace odo command
+func NewCmdWorkspace(name, fullName string) *cobra.Command {
+	initCmd := NewCmdInit(initCommandName, util.GetFullName(fullName, initCommandName))
+	listCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
+	workspaceCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Manage the workspace of several components",
+		Long:    workspaceLongDesc,
+		Example: fmt.Sprintf("%s\n\n%s", initCmd.Example, listCmd.Example),
+	}
+
+	workspaceCmd.AddCommand(initCmd, listCmd)
+	workspaceCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	workspaceCmd.Annotations = map[string]string{"command": "main"}
+
+	return workspaceCmd
+}
@@ -0,0 +1,75 @@
+package genericclioptions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+)
+
+// IgnoreClusterBindingFlagName is the name of the flag allowing a user to run a command against another cluster than
+// the one the component is bound to
+const IgnoreClusterBindingFlagName = "ignore-cluster-binding"
+
+// AddIgnoreClusterBindingFlag adds the --ignore-cluster-binding flag to the commands working on the component of a
+// context directory
+func AddIgnoreClusterBindingFlag(cmd *cobra.Command) {
+	if cmd.Flags().Lookup(ContextFlagName) == nil || cmd.Flags().Lookup(IgnoreClusterBindingFlagName) != nil {
+		return
+	}
+	cmd.Flags().Bool(IgnoreClusterBindingFlagName, false, "Run the command even if the current kube context points to another cluster than the one the component is bound to")
+}
+
+// GetClusterBinding returns the current context of the kubeconfig and the server of its cluster, the default
+// kubeconfig is used if config is nil
+func GetClusterBinding(config clientcmd.ClientConfig) (envinfo.ClusterBinding, error) {
+	if config == nil {
+		config = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	}
+	rawConfig, err := config.RawConfig()
+	if err != nil {
+		return envinfo.ClusterBinding{}, errors.Wrap(err, "unable to read the kubeconfig")
+	}
+	context, ok := rawConfig.Contexts[rawConfig.CurrentContext]
+	if !ok {
+		return envinfo.ClusterBinding{}, errors.New("the kubeconfig has no current context")
+	}
+	binding := envinfo.ClusterBinding{Context: rawConfig.CurrentContext}
+	if cluster, ok := rawConfig.Clusters[context.Cluster]; ok {
+		binding.Server = cluster.Server
+	}
+	return binding, nil
+}
+
+// ClusterBindingError is returned when the component is bound to another cluster than the one of the current context
+type ClusterBindingError struct {
+	Bound   envinfo.ClusterBinding
+	Current envinfo.ClusterBinding
+}
+
+func (e *ClusterBindingError) Error() string {
+	return fmt.Sprintf("the component is bound to the cluster %s (context %s) but the current context %s points to %s, run `kubectl config use-context %s` to switch back, or use --%s to run against the current cluster", e.Bound.Server, e.Bound.Context, e.Current.Context, e.Current.Server, e.Bound.Context, IgnoreClusterBindingFlagName)
+}
+
+// checkClusterBinding returns a ClusterBindingError if the component is bound to another cluster than the current one,
+// only a warning is shown if ignore is true
+func checkClusterBinding(bound *envinfo.ClusterBinding, current envinfo.ClusterBinding, ignore bool) error {
+	if bound == nil || bound.Server == "" || sameServer(bound.Server, current.Server) {
+		return nil
+	}
+	if ignore {
+		log.Warningf("The component is bound to the cluster %s (context %s), running against %s (context %s)", bound.Server, bound.Context, current.Server, current.Context)
+		return nil
+	}
+	return &ClusterBindingError{Bound: *bound, Current: current}
+}
+
+// sameServer returns true if both URLs are the URL of the same API server
+func sameServer(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/"))
+}
@@ -0,0 +1,85 @@
+package genericclioptions
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/openshift/odo/pkg/envinfo"
+)
+
+func TestGetClusterBinding(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["dev-cluster"] = &clientcmdapi.Cluster{Server: "https://dev.example.com:6443"}
+	config.Contexts["dev"] = &clientcmdapi.Context{Cluster: "dev-cluster", Namespace: "myproject"}
+
+	tests := []struct {
+		name           string
+		currentContext string
+		want           envinfo.ClusterBinding
+		wantErr        bool
+	}{
+		{
+			name:           "Case 1: the current context is recorded with the server of its cluster",
+			currentContext: "dev",
+			want:           envinfo.ClusterBinding{Context: "dev", Server: "https://dev.example.com:6443"},
+		},
+		{
+			name:           "Case 2: no current context",
+			currentContext: "",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.CurrentContext = tt.currentContext
+			got, err := GetClusterBinding(clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got the binding %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClusterBinding(t *testing.T) {
+	current := envinfo.ClusterBinding{Context: "dev", Server: "https://dev.example.com:6443"}
+
+	tests := []struct {
+		name    string
+		bound   *envinfo.ClusterBinding
+		ignore  bool
+		wantErr bool
+	}{
+		{
+			name:  "Case 1: the component is not bound",
+			bound: nil,
+		},
+		{
+			name:  "Case 2: the component is bound to the current cluster with another context",
+			bound: &envinfo.ClusterBinding{Context: "dev-admin", Server: "https://dev.example.com:6443/"},
+		},
+		{
+			name:    "Case 3: the component is bound to another cluster",
+			bound:   &envinfo.ClusterBinding{Context: "prod", Server: "https://prod.example.com:6443"},
+			wantErr: true,
+		},
+		{
+			name:   "Case 4: the component is bound to another cluster and the binding is ignored",
+			bound:  &envinfo.ClusterBinding{Context: "prod", Server: "https://prod.example.com:6443"},
+			ignore: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkClusterBinding(tt.bound, current, tt.ignore)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -302,6 +302,17 @@ func newDevfileContext(command *cobra.Command, createAppIfNeeded bool) (*Context
 		return nil, err
 	}
 
+	// Refuse to work on the component in another cluster than the one it is bound to
+	if ignoreFlag := command.Flags().Lookup(IgnoreClusterBindingFlagName); ignoreFlag != nil && envInfo.Exists() && envInfo.GetClusterBinding() != nil {
+		current, err := GetClusterBinding(internalCxt.KClient.KubeConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkClusterBinding(envInfo.GetClusterBinding(), current, ignoreFlag.Value.String() == "true"); err != nil {
+			return nil, err
+		}
+	}
+
 	// Gather the environment information
 	internalCxt.EnvSpecificInfo = envInfo
 
@@ -21,6 +21,7 @@ import (
 	"k8s.io/klog"
 
 	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/util"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -35,14 +36,17 @@ type Runnable interface {
 func GenericRun(o Runnable, cmd *cobra.Command, args []string) {
 	var err error
 	var startTime time.Time
-	cfg, _ := preference.New()
+	// the preference file of the repository is the one of the context directory of the component
+	preference.SetProjectDir(FlagValueIfSet(cmd, ContextFlagName))
+	cfg, err := preference.New()
+	util.LogErrorAndExit(err, "")
 	disableTelemetry, _ := strconv.ParseBool(os.Getenv(segment.DisableTelemetryEnv))
 
 	// Prompt the user to consent for telemetry if a value is not set already
 	// Skip prompting if the preference command is called
 	// This prompt has been placed here so that it does not prompt the user when they call --help
 
-	if !cfg.IsSet(preference.ConsentTelemetrySetting) && cmd.Parent().Name() != "preference" {
+	if cfg.GetSource(preference.ConsentTelemetrySetting) == preference.SourceDefault && cmd.Parent().Name() != "preference" {
 		if !segment.RunningInTerminal() {
 			klog.V(4).Infof("Skipping telemetry question because there is no terminal (tty)\n")
 		} else if disableTelemetry {
@@ -86,7 +90,7 @@ func GenericRun(o Runnable, cmd *cobra.Command, args []string) {
 	util.LogErrorAndExit(err, "")
 }
 
-// startTelemetry uploads the data to segment if user has consented to usage data collection and the command is not telemetry
+// startTelemetry uploads the data to the telemetry sink if user has consented to usage data collection and the command is not telemetry
 // TODO: move this function to a more suitable place, preferably pkg/segment
 func startTelemetry(cfg *preference.PreferenceInfo, cmd *cobra.Command, err error, startTime time.Time) {
 	if segment.IsTelemetryEnabled(cfg) && !strings.Contains(cmd.CommandPath(), "telemetry") {
@@ -162,13 +166,16 @@ func CheckMachineReadableOutputCommand(cmd *cobra.Command) {
 	machineOutput := cmd.Annotations["machineoutput"]
 
 	// Check the valid output
-	if hasFlagChanged && outputFlag.Value.String() != "json" {
-		log.Error("Please input a valid output format for -o, available format: json")
-		os.Exit(1)
+	if hasFlagChanged {
+		if _, err := machineoutput.NewPrinter(outputFlag.Value.String()); err != nil {
+			_ = flag.Set("o", "")
+			log.Errorf("Please input a valid output format for -o: %v", err)
+			os.Exit(1)
+		}
 	}
 
-	// Check that if -o json has been passed, that the command actually USES json.. if not, error out.
-	if hasFlagChanged && outputFlag.Value.String() == "json" && machineOutput == "" {
+	// Check that if -o has been passed, that the command actually USES machine readable output.. if not, error out.
+	if hasFlagChanged && machineOutput == "" {
 
 		// By default we "disable" logging, so activate it so that the below error can be shown.
 		_ = flag.Set("o", "")
@@ -178,6 +185,14 @@ func CheckMachineReadableOutputCommand(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 
+	// Commands which only output json, such as the commands streaming events, don't support the other formats
+	if hasFlagChanged && machineOutput == "json" && outputFlag.Value.String() != machineoutput.JSONFormat {
+		format := outputFlag.Value.String()
+		_ = flag.Set("o", "")
+		log.Errorf("The output format %s is not supported by this command, available format: json", format)
+		os.Exit(1)
+	}
+
 	// Before running anything, we will make sure that no verbose output is made
 	// This is a HACK to manually override `-v 4` to `-v 0` (in which we have no klog.V(0) in our code...
 	// in order to have NO verbose output when combining both `-o json` and `-v 4` so json output
@@ -252,8 +252,11 @@ func ModifyAdditionalFlags(cmd *cobra.Command) string {
 
 	f.VisitAll(func(f *pflag.Flag) {
 		// Remove json flag if machineoutput has not been passed in
-		if f.Name == "o" && machineOutput == "json" {
+		if f.Name == "o" && machineOutput != "" {
 			f.Hidden = false
+			if machineOutput != "json" {
+				f.Usage = fmt.Sprintf("Specify output format, supported formats: %s", machineoutput.OutputFormats)
+			}
 		}
 	})
 
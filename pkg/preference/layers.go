@@ -0,0 +1,214 @@
+package preference
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/util"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables overriding the preferences,
+	// e.g. ODO_PREF_PUSHTIMEOUT overrides the PushTimeout preference
+	EnvPrefix = "ODO_PREF_"
+
+	// RegistryListSetting is the name of the setting holding the devfile registries
+	RegistryListSetting = "RegistryList"
+)
+
+// Source is where the effective value of a preference comes from
+type Source string
+
+const (
+	// SourceDefault is used when the preference is not set
+	SourceDefault Source = "default"
+	// SourceGlobal is used when the preference is set in the global preference file
+	SourceGlobal Source = "global"
+	// SourceProject is used when the preference is set in the .odo/preference.yaml file of the repository
+	SourceProject Source = "project"
+	// SourceEnv is used when the preference is set with an environment variable
+	SourceEnv Source = "env"
+)
+
+// projectIgnoredSettings can't be set in the preference file of a repository, or cloning a repository would be
+// enough to send the telemetry data elsewhere or to trust other keys
+var projectIgnoredSettings = []string{ConsentTelemetrySetting, TelemetrySinkSetting, TelemetryEndpointSetting, TrustedKeysSetting}
+
+// shownWarnings records the warnings already shown, as the preferences are read several times by a command
+var shownWarnings = map[string]bool{}
+
+// projectDir is the directory the preference file of the repository is searched from, the current directory if empty
+var projectDir string
+
+// SetProjectDir sets the directory the preference file of the repository is searched from, usually the context
+// directory of the component; the current directory is used if dir is empty
+func SetProjectDir(dir string) {
+	projectDir = dir
+}
+
+// GetEnvName returns the environment variable overriding the preference
+func GetEnvName(parameter string) string {
+	return EnvPrefix + strings.ToUpper(parameter)
+}
+
+// loadOverrides reads the preference file of the repository of the project directory, if any, and the preference
+// environment variables; they override the global preferences, in this order
+func (c *PreferenceInfo) loadOverrides() error {
+	if dir, err := filepath.Abs(projectDir); err == nil {
+		c.ProjectFilename = findProjectPreferenceFile(dir, c.Filename)
+	}
+	if c.ProjectFilename != "" {
+		klog.V(4).Infof("The path for project preference file is %+v", c.ProjectFilename)
+		project := NewPreference()
+		if err := util.GetFromFile(&project, c.ProjectFilename); err != nil {
+			return errors.Wrapf(err, "unable to read the project preference file %s", c.ProjectFilename)
+		}
+		c.project = project.OdoSettings
+		for _, setting := range projectIgnoredSettings {
+			if !util.IsSet(c.project, setting) {
+				continue
+			}
+			warning := fmt.Sprintf("%s is ignored in %s, it can only be set in the global preferences or with %s", setting, c.ProjectFilename, GetEnvName(setting))
+			if !shownWarnings[warning] {
+				shownWarnings[warning] = true
+				log.Warning(warning)
+			}
+			if err := util.DeleteConfiguration(&c.project, setting); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, setting := range GetSupportedParameters() {
+		value, ok := os.LookupEnv(GetEnvName(setting))
+		if !ok {
+			continue
+		}
+		if err := setSetting(&c.env, setting, value); err != nil {
+			return errors.Errorf("invalid value of %s: %v", GetEnvName(setting), err)
+		}
+	}
+	return nil
+}
+
+// findProjectPreferenceFile returns the .odo/preference.yaml file of dir or of its closest parent, the search stopping
+// at the root of the git repository and at the home directory; empty if there is none
+func findProjectPreferenceFile(dir string, globalFile string) string {
+	home := getHomeDir()
+	for {
+		if dir == home {
+			return ""
+		}
+		file := filepath.Join(dir, ".odo", configFileName)
+		if file != globalFile && util.CheckPathExists(file) {
+			return file
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || util.CheckPathExists(filepath.Join(dir, ".git")) {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// settings returns the effective settings: the global settings overridden by the project settings, themselves
+// overridden by the environment variables. The registries of the project settings are added to the global ones.
+func (c *PreferenceInfo) settings() OdoSettings {
+	settings := c.OdoSettings
+	project := c.project
+	if project.RegistryList != nil {
+		registries := mergeRegistries(c.OdoSettings.RegistryList, *project.RegistryList)
+		project.RegistryList = &registries
+	}
+	effective := reflect.ValueOf(&settings).Elem()
+	for _, layer := range []OdoSettings{project, c.env} {
+		fields := reflect.ValueOf(layer)
+		for i := 0; i < fields.NumField(); i++ {
+			if !fields.Field(i).IsNil() {
+				effective.Field(i).Set(fields.Field(i))
+			}
+		}
+	}
+	return settings
+}
+
+// mergeRegistries adds the project registries to the global ones. A project registry with the name of a global
+// registry only sets its priority and can require signatures; it can't change its URL or stop requiring signatures,
+// or cloning a repository would be enough to use unverified devfiles
+func mergeRegistries(global *[]Registry, project []Registry) []Registry {
+	var registries []Registry
+	if global != nil {
+		registries = append(registries, *global...)
+	}
+	for _, registry := range project {
+		found := false
+		for i := range registries {
+			if registries[i].Name != registry.Name {
+				continue
+			}
+			found = true
+			if registries[i].URL != registry.URL {
+				warning := fmt.Sprintf("the URL of the registry %s can't be changed by the project preference file, %s is used", registry.Name, registries[i].URL)
+				if !shownWarnings[warning] {
+					shownWarnings[warning] = true
+					log.Warning(warning)
+				}
+			}
+			registries[i].Priority = registry.Priority
+			registries[i].RequireSignature = registries[i].RequireSignature || registry.RequireSignature
+		}
+		if !found {
+			registries = append(registries, registry)
+		}
+	}
+	return registries
+}
+
+// WarnIfOverridden warns that the global value of the preference is not used, when it is overridden
+// by the project preference file or by an environment variable
+func (c *PreferenceInfo) WarnIfOverridden(parameter string) {
+	switch c.GetSource(parameter) {
+	case SourceProject:
+		if strings.EqualFold(parameter, RegistryListSetting) {
+			log.Warningf("The registries of the project preference file %s are added to the global registries and set their priority", c.ProjectFilename)
+			return
+		}
+		log.Warningf("%s is overridden by the project preference file %s", parameter, c.ProjectFilename)
+	case SourceEnv:
+		log.Warningf("%s is overridden by the environment variable %s", parameter, GetEnvName(parameter))
+	}
+}
+
+// GetSource returns where the effective value of the parameter comes from
+func (c *PreferenceInfo) GetSource(parameter string) Source {
+	_, offlineEnv := getOfflineEnv()
+	switch {
+	case util.IsSet(c.env, parameter), strings.EqualFold(parameter, OfflineSetting) && offlineEnv:
+		return SourceEnv
+	case util.IsSet(c.project, parameter):
+		return SourceProject
+	case strings.EqualFold(parameter, RegistryListSetting) && c.defaultRegistryList:
+		return SourceDefault
+	case util.IsSet(c.OdoSettings, parameter):
+		return SourceGlobal
+	}
+	return SourceDefault
+}
+
+// getOfflineEnv returns the value of the ODO_OFFLINE env variable, and false if it is not set to a boolean
+func getOfflineEnv() (bool, bool) {
+	if env, ok := os.LookupEnv(OfflineEnv); ok {
+		if val, err := strconv.ParseBool(env); err == nil {
+			return val, true
+		}
+	}
+	return false, false
+}
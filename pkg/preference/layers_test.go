@@ -0,0 +1,177 @@
+package preference
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/odo/pkg/util"
+)
+
+func TestLoadOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preference")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repo := filepath.Join(dir, "repo")
+	files := map[string]string{
+		filepath.Join(dir, "global.yaml"):                "OdoSettings:\n  PushTimeout: 100\n  BuildTimeout: 10\n  Ephemeral: false\n  RegistryList:\n  - Name: DefaultDevfileRegistry\n    URL: https://registry.devfile.io\n    RequireSignature: true\n",
+		filepath.Join(dir, ".odo", configFileName):       "OdoSettings:\n  Timeout: 5\n",
+		filepath.Join(dir, "other", ".git", "HEAD"):      "ref: refs/heads/main\n",
+		filepath.Join(repo, ".git", "HEAD"):              "ref: refs/heads/main\n",
+		filepath.Join(repo, ".odo", configFileName):      "OdoSettings:\n  PushTimeout: 200\n  ConsentTelemetry: true\n  RegistryList:\n  - Name: team\n    URL: https://registry.example.com\n  - Name: DefaultDevfileRegistry\n    URL: https://registry.example.org\n    Priority: 5\n",
+		filepath.Join(repo, "component", "devfile.yaml"): "schemaVersion: 2.0.0\n",
+	}
+	for path, data := range files {
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd) // #nosec G104
+	if err = os.Chdir(filepath.Join(repo, "component")); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(GlobalConfigEnvName, filepath.Join(dir, "global.yaml"))
+	os.Setenv(GetEnvName(EphemeralSetting), "true")
+	defer os.Unsetenv(GetEnvName(EphemeralSetting))
+
+	cfg, err := NewPreferenceInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the directories are compared once the symbolic links are resolved, the temporary directory may be one
+	projectFile, _ := filepath.EvalSymlinks(cfg.ProjectFilename)
+	wantProjectFile, _ := filepath.EvalSymlinks(filepath.Join(repo, ".odo", configFileName))
+	if projectFile != wantProjectFile {
+		t.Errorf("got the project preference file %q, want %q", cfg.ProjectFilename, wantProjectFile)
+	}
+
+	// the search stops at the root of the repository
+	if file := findProjectPreferenceFile(filepath.Join(dir, "other"), cfg.Filename); file != "" {
+		t.Errorf("got the project preference file %q outside of the repository", file)
+	}
+
+	tests := []struct {
+		name       string
+		parameter  string
+		value      interface{}
+		wantValue  interface{}
+		wantSource Source
+	}{
+		{
+			name:       "Case 1: Default value",
+			parameter:  TimeoutSetting,
+			value:      cfg.GetTimeout(),
+			wantValue:  DefaultTimeout,
+			wantSource: SourceDefault,
+		},
+		{
+			name:       "Case 2: Global value",
+			parameter:  BuildTimeoutSetting,
+			value:      cfg.GetBuildTimeout(),
+			wantValue:  10,
+			wantSource: SourceGlobal,
+		},
+		{
+			name:       "Case 3: Project value overriding the global value",
+			parameter:  PushTimeoutSetting,
+			value:      cfg.GetPushTimeout(),
+			wantValue:  200,
+			wantSource: SourceProject,
+		},
+		{
+			name:       "Case 4: Environment variable overriding the global value",
+			parameter:  EphemeralSetting,
+			value:      cfg.GetEphemeralSourceVolume(),
+			wantValue:  true,
+			wantSource: SourceEnv,
+		},
+		{
+			name:       "Case 5: Setting ignored in the project preference file",
+			parameter:  ConsentTelemetrySetting,
+			value:      cfg.GetConsentTelemetry(),
+			wantValue:  DefaultConsentTelemetrySetting,
+			wantSource: SourceDefault,
+		},
+		{
+			name:       "Case 6: Project registry added to the global registries",
+			parameter:  RegistryListSetting,
+			value:      cfg.GetRegistryList()[1].Name,
+			wantValue:  "team",
+			wantSource: SourceProject,
+		},
+		{
+			name:       "Case 7: Project registry doesn't change the URL of a global registry",
+			parameter:  RegistryListSetting,
+			value:      cfg.GetRegistryList()[0].URL,
+			wantValue:  "https://registry.devfile.io",
+			wantSource: SourceProject,
+		},
+		{
+			name:       "Case 8: Project registry doesn't disable the signatures of a global registry",
+			parameter:  RegistryListSetting,
+			value:      cfg.GetRegistryList()[0].RequireSignature,
+			wantValue:  true,
+			wantSource: SourceProject,
+		},
+		{
+			name:       "Case 9: Project registry sets the priority of a global registry",
+			parameter:  RegistryListSetting,
+			value:      cfg.GetRegistryList()[0].Priority,
+			wantValue:  5,
+			wantSource: SourceProject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.value != tt.wantValue {
+				t.Errorf("got the value %v, want %v", tt.value, tt.wantValue)
+			}
+			if source := cfg.GetSource(tt.parameter); source != tt.wantSource {
+				t.Errorf("got the source %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+
+	// the global preference file is written without the overrides
+	if err = cfg.SetConfiguration(TimeoutSetting, "3"); err != nil {
+		t.Fatal(err)
+	}
+	global := NewPreference()
+	if err = util.GetFromFile(&global, cfg.Filename); err != nil {
+		t.Fatal(err)
+	}
+	if global.OdoSettings.PushTimeout == nil || *global.OdoSettings.PushTimeout != 100 || global.OdoSettings.ConsentTelemetry != nil {
+		t.Errorf("the overrides were written to the global preference file")
+	}
+
+	// the project preference file is searched from the context directory of the component
+	SetProjectDir(filepath.Join(repo, "component"))
+	defer SetProjectDir("")
+	if err = os.Chdir(filepath.Join(dir, "other")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = NewPreferenceInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projectFile, _ = filepath.EvalSymlinks(cfg.ProjectFilename); projectFile != wantProjectFile {
+		t.Errorf("got the project preference file %q for the context directory, want %q", cfg.ProjectFilename, wantProjectFile)
+	}
+
+	os.Setenv(GetEnvName(PushTimeoutSetting), "soon")
+	defer os.Unsetenv(GetEnvName(PushTimeoutSetting))
+	if _, err = NewPreferenceInfo(); err == nil {
+		t.Errorf("expected an error for the invalid value of %s", GetEnvName(PushTimeoutSetting))
+	}
+}
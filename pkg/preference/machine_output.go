@@ -22,6 +22,7 @@ type PreferenceItem struct {
 	Default     interface{} // default value of the preference if the user hasn't set the value
 	Type        string      // the type of the preference, possible values int, string, bool
 	Description string      // The description of the preference
+	Source      Source      // where the value comes from: default, global, project or env
 }
 
 func NewPreferenceList(prefInfo PreferenceInfo) PreferenceList {
@@ -35,8 +36,11 @@ func NewPreferenceList(prefInfo PreferenceInfo) PreferenceList {
 }
 
 func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
-	odoSettings := prefInfo.OdoSettings
-	return []PreferenceItem{
+	odoSettings := prefInfo.settings()
+	if offline, ok := getOfflineEnv(); ok {
+		odoSettings.Offline = &offline
+	}
+	items := []PreferenceItem{
 		{
 			Name:        UpdateNotificationSetting,
 			Value:       odoSettings.UpdateNotification,
@@ -79,6 +83,13 @@ func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
 			Type:        getType(prefInfo.GetExperimental()),
 			Description: ExperimentalDescription,
 		},
+		{
+			Name:        EphemeralSetting,
+			Value:       odoSettings.Ephemeral,
+			Default:     DefaultEphemeralSettings,
+			Type:        getType(prefInfo.GetEphemeralSourceVolume()),
+			Description: EphemeralDescription,
+		},
 		{
 			Name:        ConsentTelemetrySetting,
 			Value:       odoSettings.ConsentTelemetry,
@@ -86,7 +97,39 @@ func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
 			Type:        getType(prefInfo.GetConsentTelemetry()),
 			Description: ConsentTelemetryDescription,
 		},
+		{
+			Name:        OfflineSetting,
+			Value:       odoSettings.Offline,
+			Default:     DefaultOfflineSetting,
+			Type:        getType(prefInfo.GetOffline()),
+			Description: OfflineDescription,
+		},
+		{
+			Name:        TrustedKeysSetting,
+			Value:       odoSettings.TrustedKeys,
+			Default:     []string{},
+			Type:        getType(prefInfo.GetTrustedKeys()),
+			Description: TrustedKeysDescription,
+		},
+		{
+			Name:        TelemetrySinkSetting,
+			Value:       odoSettings.TelemetrySink,
+			Default:     DefaultTelemetrySink,
+			Type:        getType(prefInfo.GetTelemetrySink()),
+			Description: TelemetrySinkDescription,
+		},
+		{
+			Name:        TelemetryEndpointSetting,
+			Value:       odoSettings.TelemetryEndpoint,
+			Default:     "",
+			Type:        getType(prefInfo.GetTelemetryEndpoint()),
+			Description: TelemetryEndpointDescription,
+		},
+	}
+	for i := range items {
+		items[i].Source = prefInfo.GetSource(items[i].Name)
 	}
+	return items
 }
 
 func getType(v interface{}) string {
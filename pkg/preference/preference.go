@@ -2,9 +2,11 @@ package preference
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -85,6 +87,42 @@ const (
 
 	// DefaultConsentTelemetry is a default value for ConsentTelemetry preference
 	DefaultConsentTelemetrySetting = false
+
+	// OfflineSetting specifies if odo should only use cached devfile registry data instead of contacting the registries
+	OfflineSetting = "Offline"
+
+	// DefaultOfflineSetting is a default value for Offline preference
+	DefaultOfflineSetting = false
+
+	// OfflineEnv is the env variable that can be set to true to enable the offline mode
+	OfflineEnv = "ODO_OFFLINE"
+
+	// TrustedKeysSetting is the name of the setting holding the public keys trusted to sign devfile stacks and starter projects
+	TrustedKeysSetting = "TrustedKeys"
+
+	// TrustedKeysDescription is human-readable description for the trusted keys setting
+	TrustedKeysDescription = "Comma separated list of files holding the PEM encoded public keys trusted to sign devfiles and starter projects"
+
+	// TelemetrySinkSetting is the name of the setting controlling where the telemetry data is sent
+	TelemetrySinkSetting = "TelemetrySink"
+
+	// TelemetrySinkSegment sends the telemetry data to Segment
+	TelemetrySinkSegment = "segment"
+
+	// TelemetrySinkFile appends the telemetry data to a local JSON lines file
+	TelemetrySinkFile = "file"
+
+	// TelemetrySinkHTTP posts the telemetry data to an HTTP endpoint
+	TelemetrySinkHTTP = "http"
+
+	// DefaultTelemetrySink is a default value for TelemetrySink preference
+	DefaultTelemetrySink = TelemetrySinkSegment
+
+	// TelemetryEndpointSetting is the name of the setting holding the file or the URL of the telemetry sink
+	TelemetryEndpointSetting = "TelemetryEndpoint"
+
+	// TelemetryEndpointDescription is human-readable description for the telemetry endpoint setting
+	TelemetryEndpointDescription = "Path of the file of the file telemetry sink (Default: telemetry.jsonl in the odo configuration directory), or URL of the http telemetry sink"
 )
 
 // TimeoutSettingDescription is human-readable description for the timeout setting
@@ -100,11 +138,17 @@ var BuildTimeoutSettingDescription = fmt.Sprintf("BuildTimeout (in seconds) for
 var RegistryCacheTimeDescription = fmt.Sprintf("For how long (in minutes) odo will cache information from Devfile registry (Default: %d)", DefaultRegistryCacheTime)
 
 // EphemeralDescription adds a description for EphemeralSourceVolume
-var EphemeralDescription = fmt.Sprintf("If true odo will create a emptyDir volume to store source code (Default: %t)", DefaultEphemeralSettings)
+var EphemeralDescription = fmt.Sprintf("If true odo will create a emptyDir volume to store source code, unless the SourceVolumeMode of the component is set in its env.yaml (Default: %t)", DefaultEphemeralSettings)
 
 //TelemetryConsentDescription adds a description for TelemetryConsentSetting
 var ConsentTelemetryDescription = fmt.Sprintf("If true odo will collect telemetry for the user's odo usage (Default: %t)\n\t\t    For more information: https://developers.redhat.com/article/tool-data-collection", DefaultConsentTelemetrySetting)
 
+// OfflineDescription adds a description for Offline
+var OfflineDescription = fmt.Sprintf("If true odo will only use cached devfile registry indexes, devfiles and starter projects (Default: %t)", DefaultOfflineSetting)
+
+// TelemetrySinkDescription adds a description for TelemetrySink
+var TelemetrySinkDescription = fmt.Sprintf("Where odo sends the telemetry data, if ConsentTelemetry is true: %s, %s or %s (Default: %s)", TelemetrySinkSegment, TelemetrySinkFile, TelemetrySinkHTTP, DefaultTelemetrySink)
+
 // This value can be provided to set a seperate directory for users 'homedir' resolution
 // note for mocking purpose ONLY
 var customHomeDir = os.Getenv("CUSTOM_HOMEDIR")
@@ -121,6 +165,10 @@ var (
 		RegistryCacheTimeSetting:  RegistryCacheTimeDescription,
 		EphemeralSetting:          EphemeralDescription,
 		ConsentTelemetrySetting:   ConsentTelemetryDescription,
+		OfflineSetting:            OfflineDescription,
+		TrustedKeysSetting:        TrustedKeysDescription,
+		TelemetrySinkSetting:      TelemetrySinkDescription,
+		TelemetryEndpointSetting:  TelemetryEndpointDescription,
 	}
 
 	// set-like map to quickly check if a parameter is supported
@@ -132,6 +180,16 @@ var (
 type PreferenceInfo struct {
 	Filename   string `yaml:"FileName,omitempty"`
 	Preference `yaml:",omitempty"`
+
+	// ProjectFilename is the preference file of the repository overriding the global preferences, empty if none
+	ProjectFilename string `yaml:"ProjectFileName,omitempty"`
+
+	// project and env hold the settings of the project preference file and of the environment variables
+	project OdoSettings
+	env     OdoSettings
+
+	// defaultRegistryList is true when the registry list is not set in the preference file
+	defaultRegistryList bool
 }
 
 // OdoSettings holds all odo specific configurations
@@ -165,6 +223,18 @@ type OdoSettings struct {
 
 	// ConsentTelemetry if true collects telemetry for odo
 	ConsentTelemetry *bool `yaml:"ConsentTelemetry,omitempty"`
+
+	// Offline if true makes odo use only the cached devfile registry data
+	Offline *bool `yaml:"Offline,omitempty"`
+
+	// TrustedKeys holds the files of the public keys trusted to sign devfiles and starter projects
+	TrustedKeys *[]string `yaml:"TrustedKeys,omitempty"`
+
+	// TelemetrySink is where the telemetry data is sent: segment, file or http
+	TelemetrySink *string `yaml:"TelemetrySink,omitempty"`
+
+	// TelemetryEndpoint is the file of the file telemetry sink, or the URL of the http telemetry sink
+	TelemetryEndpoint *string `yaml:"TelemetryEndpoint,omitempty"`
 }
 
 // Registry includes the registry metadata
@@ -172,6 +242,11 @@ type Registry struct {
 	Name   string `yaml:"Name,omitempty"`
 	URL    string `yaml:"URL,omitempty"`
 	Secure bool
+	// Priority decides which registry is used when several registries publish a stack with the same name,
+	// registries with a higher priority are preferred
+	Priority int `yaml:"Priority,omitempty"`
+	// RequireSignature makes odo refuse the devfiles and starter projects of the registry that aren't signed by a trusted key
+	RequireSignature bool `yaml:"RequireSignature,omitempty"`
 }
 
 // Preference stores all the preferences related to odo
@@ -187,15 +262,33 @@ func getPreferenceFile() (string, error) {
 		return env, nil
 	}
 
+	home := getHomeDir()
+	if home == "" {
+		return "", errors.New("unable to get the home directory of the current user")
+	}
+	return filepath.Join(home, ".odo", configFileName), nil
+}
+
+// getHomeDir returns the home directory of the current user, empty if it is unknown
+func getHomeDir() string {
 	if len(customHomeDir) != 0 {
-		return filepath.Join(customHomeDir, ".odo", configFileName), nil
+		return customHomeDir
 	}
 
 	currentUser, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return currentUser.HomeDir
+}
+
+// GetConfigDir returns the directory holding the odo preference file
+func GetConfigDir() (string, error) {
+	preferenceFile, err := getPreferenceFile()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(currentUser.HomeDir, ".odo", configFileName), nil
+	return filepath.Dir(preferenceFile), nil
 }
 
 // New returns the PreferenceInfo to retain the expected behavior
@@ -239,6 +332,10 @@ func NewPreferenceInfo() (*PreferenceInfo, error) {
 	// If the preference file doesn't exist then we return with default preference
 	if _, err = os.Stat(preferenceFile); os.IsNotExist(err) {
 		c.OdoSettings.RegistryList = &defaultRegistryList
+		c.defaultRegistryList = true
+		if err = c.loadOverrides(); err != nil {
+			return nil, err
+		}
 		return &c, nil
 	}
 
@@ -250,6 +347,7 @@ func NewPreferenceInfo() (*PreferenceInfo, error) {
 	// Handle user has preference file but doesn't use dynamic registry before
 	if c.OdoSettings.RegistryList == nil {
 		c.OdoSettings.RegistryList = &defaultRegistryList
+		c.defaultRegistryList = true
 	}
 
 	// Handle OCI-based default registry migration
@@ -263,6 +361,9 @@ func NewPreferenceInfo() (*PreferenceInfo, error) {
 		}
 	}
 
+	if err = c.loadOverrides(); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
@@ -365,10 +466,70 @@ func handleWithRegistryExist(index int, registryList []Registry, operation strin
 	return registryList, nil
 }
 
+// SetRegistryPriority sets the priority of the registry and writes it to the preference file
+func (c *PreferenceInfo) SetRegistryPriority(registryName string, priority int) error {
+	return c.updateRegistry(registryName, func(registry *Registry) {
+		registry.Priority = priority
+	})
+}
+
+// SetRegistryRequireSignature sets if the registry requires signed devfiles and starter projects
+// and writes it to the preference file
+func (c *PreferenceInfo) SetRegistryRequireSignature(registryName string, requireSignature bool) error {
+	return c.updateRegistry(registryName, func(registry *Registry) {
+		registry.RequireSignature = requireSignature
+	})
+}
+
+// updateRegistry applies update to the registry and writes it to the preference file
+func (c *PreferenceInfo) updateRegistry(registryName string, update func(registry *Registry)) error {
+	if c.OdoSettings.RegistryList != nil {
+		registryList := *c.OdoSettings.RegistryList
+		for index := range registryList {
+			if registryList[index].Name != registryName {
+				continue
+			}
+			update(&registryList[index])
+			err := util.WriteToFile(&c.Preference, c.Filename)
+			if err != nil {
+				return errors.Errorf("unable to write the settings of registry %q to preference file", registryName)
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("failed to update registry: registry %q doesn't exist", registryName)
+}
+
+// SortRegistriesByPriority returns the registries ordered by decreasing priority, registries with the same priority
+// are ordered from the most recently added one to the oldest one
+func SortRegistriesByPriority(registryList []Registry) []Registry {
+	sorted := make([]Registry, 0, len(registryList))
+	for i := len(registryList) - 1; i >= 0; i-- {
+		sorted = append(sorted, registryList[i])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
+
 // SetConfiguration modifies Odo configurations in the config file
 // as of now being used for nameprefix, timeout, updatenotification
-// TODO: Use reflect to set parameters
 func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error {
+	if err := setSetting(&c.OdoSettings, parameter, value); err != nil {
+		return err
+	}
+
+	err := util.WriteToFile(&c.Preference, c.Filename)
+	if err != nil {
+		return errors.Errorf("unable to set %q, something is wrong with odo, kindly raise an issue at https://github.com/openshift/odo/issues/new?template=Bug.md", parameter)
+	}
+	return nil
+}
+
+// setSetting validates the value of the parameter and sets it in the settings
+// TODO: Use reflect to set parameters
+func setSetting(settings *OdoSettings, parameter string, value string) error {
 	if p, ok := asSupportedParameter(parameter); ok {
 		// processing values according to the parameter names
 		switch p {
@@ -381,7 +542,7 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 			if typedval < 0 {
 				return errors.Errorf("cannot set timeout to less than 0")
 			}
-			c.OdoSettings.Timeout = &typedval
+			settings.Timeout = &typedval
 
 		case "buildtimeout":
 			typedval, err := strconv.Atoi(value)
@@ -391,7 +552,7 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 			if typedval < 0 {
 				return errors.Errorf("cannot set timeout to less than 0")
 			}
-			c.OdoSettings.BuildTimeout = &typedval
+			settings.BuildTimeout = &typedval
 
 		case "pushtimeout":
 			typedval, err := strconv.Atoi(value)
@@ -401,7 +562,7 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 			if typedval < 0 {
 				return errors.Errorf("cannot set timeout to less than 0")
 			}
-			c.OdoSettings.PushTimeout = &typedval
+			settings.PushTimeout = &typedval
 
 		case "registrycachetime":
 			typedval, err := strconv.Atoi(value)
@@ -411,48 +572,89 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 			if typedval < 0 {
 				return errors.Errorf("cannot set timeout to less than 0")
 			}
-			c.OdoSettings.RegistryCacheTime = &typedval
+			settings.RegistryCacheTime = &typedval
 
 		case "updatenotification":
 			val, err := strconv.ParseBool(strings.ToLower(value))
 			if err != nil {
 				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
-			c.OdoSettings.UpdateNotification = &val
+			settings.UpdateNotification = &val
 
 		//	TODO: should we add a validator here? What is the use of nameprefix?
 		case "nameprefix":
-			c.OdoSettings.NamePrefix = &value
+			settings.NamePrefix = &value
 
 		case "experimental":
 			val, err := strconv.ParseBool(strings.ToLower(value))
 			if err != nil {
 				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
-			c.OdoSettings.Experimental = &val
+			settings.Experimental = &val
 
 		case "ephemeral":
 			val, err := strconv.ParseBool(strings.ToLower(value))
 			if err != nil {
 				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
-			c.OdoSettings.Ephemeral = &val
+			settings.Ephemeral = &val
 
 		case "consenttelemetry":
 			val, err := strconv.ParseBool(strings.ToLower(value))
 			if err != nil {
 				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
-			c.OdoSettings.ConsentTelemetry = &val
+			settings.ConsentTelemetry = &val
+
+		case "offline":
+			val, err := strconv.ParseBool(strings.ToLower(value))
+			if err != nil {
+				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
+			}
+			settings.Offline = &val
+
+		case "trustedkeys":
+			var keys []string
+			for _, key := range strings.Split(value, ",") {
+				key = strings.TrimSpace(key)
+				if key == "" {
+					continue
+				}
+				absKey, err := filepath.Abs(key)
+				if err != nil {
+					return errors.Wrapf(err, "unable to set %q to %q", parameter, value)
+				}
+				if !util.CheckPathExists(absKey) {
+					return errors.Errorf("unable to set %q to %q, the key file %s doesn't exist", parameter, value, absKey)
+				}
+				keys = append(keys, absKey)
+			}
+			settings.TrustedKeys = &keys
+
+		case "telemetrysink":
+			sink := strings.ToLower(value)
+			if sink != TelemetrySinkSegment && sink != TelemetrySinkFile && sink != TelemetrySinkHTTP {
+				return errors.Errorf("unable to set %q to %q, value must be one of %s, %s or %s", parameter, value, TelemetrySinkSegment, TelemetrySinkFile, TelemetrySinkHTTP)
+			}
+			settings.TelemetrySink = &sink
+
+		case "telemetryendpoint":
+			if u, err := url.Parse(value); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+				if u.Host == "" {
+					return errors.Errorf("unable to set %q to %q, the URL has no host", parameter, value)
+				}
+			} else {
+				absPath, err := filepath.Abs(value)
+				if err != nil {
+					return errors.Wrapf(err, "unable to set %q to %q", parameter, value)
+				}
+				value = absPath
+			}
+			settings.TelemetryEndpoint = &value
 		}
 	} else {
 		return errors.Errorf("unknown parameter : %q is not a parameter in odo preference, run help to see list of available parameters", parameter)
 	}
-
-	err := util.WriteToFile(&c.Preference, c.Filename)
-	if err != nil {
-		return errors.Errorf("unable to set %q, something is wrong with odo, kindly raise an issue at https://github.com/openshift/odo/issues/new?template=Bug.md", parameter)
-	}
 	return nil
 }
 
@@ -485,56 +687,96 @@ func (c *PreferenceInfo) IsSet(parameter string) bool {
 // and if absent then returns default
 func (c *PreferenceInfo) GetTimeout() int {
 	// default timeout value is 1
-	return util.GetIntOrDefault(c.OdoSettings.Timeout, DefaultTimeout)
+	return util.GetIntOrDefault(c.settings().Timeout, DefaultTimeout)
 }
 
 // GetBuildTimeout gets the value set by BuildTimeout
 func (c *PreferenceInfo) GetBuildTimeout() int {
 	// default timeout value is 300
-	return util.GetIntOrDefault(c.OdoSettings.BuildTimeout, DefaultBuildTimeout)
+	return util.GetIntOrDefault(c.settings().BuildTimeout, DefaultBuildTimeout)
 }
 
 // GetPushTimeout gets the value set by PushTimeout
 func (c *PreferenceInfo) GetPushTimeout() int {
 	// default timeout value is 1
-	return util.GetIntOrDefault(c.OdoSettings.PushTimeout, DefaultPushTimeout)
+	return util.GetIntOrDefault(c.settings().PushTimeout, DefaultPushTimeout)
 }
 
 // GetRegistryCacheTime gets the value set by RegistryCacheTime
 func (c *PreferenceInfo) GetRegistryCacheTime() int {
-	return util.GetIntOrDefault(c.OdoSettings.RegistryCacheTime, DefaultRegistryCacheTime)
+	return util.GetIntOrDefault(c.settings().RegistryCacheTime, DefaultRegistryCacheTime)
 }
 
 // GetUpdateNotification returns the value of UpdateNotification from preferences
 // and if absent then returns default
 func (c *PreferenceInfo) GetUpdateNotification() bool {
-	return util.GetBoolOrDefault(c.OdoSettings.UpdateNotification, true)
+	return util.GetBoolOrDefault(c.settings().UpdateNotification, true)
 }
 
 // GetEphemeralSourceVolume returns the value of ephemeral from preferences
 // and if absent then returns default
 func (c *PreferenceInfo) GetEphemeralSourceVolume() bool {
-	return util.GetBoolOrDefault(c.OdoSettings.Ephemeral, DefaultEphemeralSettings)
+	return util.GetBoolOrDefault(c.settings().Ephemeral, DefaultEphemeralSettings)
 }
 
 // GetNamePrefix returns the value of Prefix from preferences
 // and if absent then returns default
 func (c *PreferenceInfo) GetNamePrefix() string {
-	return util.GetStringOrEmpty(c.OdoSettings.NamePrefix)
+	return util.GetStringOrEmpty(c.settings().NamePrefix)
 }
 
 // GetExperimental returns the value of Experimental from preferences
 // and if absent then returns default
 // default value: false, experimental mode is disabled by default
 func (c *PreferenceInfo) GetExperimental() bool {
-	return util.GetBoolOrDefault(c.OdoSettings.Experimental, false)
+	return util.GetBoolOrDefault(c.settings().Experimental, false)
 }
 
 // GetConsentTelemetry returns the value of ConsentTelemetry from preferences
 // and if absent then returns default
 // default value: false, consent telemetry is disabled by default
 func (c *PreferenceInfo) GetConsentTelemetry() bool {
-	return util.GetBoolOrDefault(c.OdoSettings.ConsentTelemetry, DefaultConsentTelemetrySetting)
+	return util.GetBoolOrDefault(c.settings().ConsentTelemetry, DefaultConsentTelemetrySetting)
+}
+
+// GetOffline returns true if the offline mode is enabled either via the ODO_OFFLINE env variable
+// or via the Offline preference
+// default value: false, offline mode is disabled by default
+func (c *PreferenceInfo) GetOffline() bool {
+	if val, ok := getOfflineEnv(); ok {
+		return val
+	}
+	return util.GetBoolOrDefault(c.settings().Offline, DefaultOfflineSetting)
+}
+
+// GetTrustedKeys returns the files holding the public keys trusted to sign devfiles and starter projects
+func (c *PreferenceInfo) GetTrustedKeys() []string {
+	trustedKeys := c.settings().TrustedKeys
+	if trustedKeys == nil {
+		return []string{}
+	}
+	return *trustedKeys
+}
+
+// GetTelemetrySink returns the value of TelemetrySink from preferences
+// and if absent then returns default
+// default value: segment
+func (c *PreferenceInfo) GetTelemetrySink() string {
+	return util.GetStringOrDefault(c.settings().TelemetrySink, DefaultTelemetrySink)
+}
+
+// GetTelemetryEndpoint returns the value of TelemetryEndpoint from preferences, empty if absent
+func (c *PreferenceInfo) GetTelemetryEndpoint() string {
+	return util.GetStringOrEmpty(c.settings().TelemetryEndpoint)
+}
+
+// GetRegistryList returns the devfile registries from preferences
+func (c *PreferenceInfo) GetRegistryList() []Registry {
+	registryList := c.settings().RegistryList
+	if registryList == nil {
+		return []Registry{}
+	}
+	return *registryList
 }
 
 // FormatSupportedParameters outputs supported parameters and their description
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -44,6 +45,7 @@ func TestNew(t *testing.T) {
 						},
 					},
 				},
+				defaultRegistryList: true,
 			},
 			success: true,
 		},
@@ -476,6 +478,51 @@ func TestSetConfiguration(t *testing.T) {
 			wantErr: false,
 			want:    false,
 		},
+		{
+			name:           fmt.Sprintf("Case 30: set %s to the file of the temporary config", TrustedKeysSetting),
+			parameter:      "trustedkeys",
+			value:          tempConfigFile.Name(),
+			existingConfig: Preference{},
+			wantErr:        false,
+			want:           []string{tempConfigFile.Name()},
+		},
+		{
+			name:           fmt.Sprintf("Case 31: set %s to a file that doesn't exist", TrustedKeysSetting),
+			parameter:      TrustedKeysSetting,
+			value:          filepath.Join(os.TempDir(), "odo-missing-key.pub"),
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           fmt.Sprintf("Case 32: set %s to file", TelemetrySinkSetting),
+			parameter:      "telemetrysink",
+			value:          "File",
+			existingConfig: Preference{},
+			wantErr:        false,
+			want:           TelemetrySinkFile,
+		},
+		{
+			name:           fmt.Sprintf("Case 33: set %s to an unknown sink", TelemetrySinkSetting),
+			parameter:      TelemetrySinkSetting,
+			value:          "syslog",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           fmt.Sprintf("Case 34: set %s to a URL", TelemetryEndpointSetting),
+			parameter:      "telemetryendpoint",
+			value:          "https://telemetry.example.com/odo",
+			existingConfig: Preference{},
+			wantErr:        false,
+			want:           "https://telemetry.example.com/odo",
+		},
+		{
+			name:           fmt.Sprintf("Case 35: set %s to a URL without host", TelemetryEndpointSetting),
+			parameter:      TelemetryEndpointSetting,
+			value:          "http:///odo",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -507,6 +554,18 @@ func TestSetConfiguration(t *testing.T) {
 					if *cfg.OdoSettings.RegistryCacheTime != tt.want {
 						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %d\n", *cfg.OdoSettings.RegistryCacheTime, tt.want)
 					}
+				case "trustedkeys":
+					if !reflect.DeepEqual(cfg.GetTrustedKeys(), tt.want) {
+						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %v\n", cfg.GetTrustedKeys(), tt.want)
+					}
+				case "telemetrysink":
+					if cfg.GetTelemetrySink() != tt.want {
+						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %v\n", cfg.GetTelemetrySink(), tt.want)
+					}
+				case "telemetryendpoint":
+					if cfg.GetTelemetryEndpoint() != tt.want {
+						t.Errorf("unexpected value after execution of SetConfiguration\ngot: %v \nexpected: %v\n", cfg.GetTelemetryEndpoint(), tt.want)
+					}
 				}
 			} else if tt.wantErr && err != nil {
 				// negative cases
@@ -953,3 +1012,106 @@ func TestGetConsentTelemetry(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOffline(t *testing.T) {
+	trueValue := true
+	falseValue := false
+
+	tests := []struct {
+		name           string
+		existingConfig Preference
+		env            string
+		want           bool
+	}{
+		{
+			name:           fmt.Sprintf("Case 1: %s nil", OfflineSetting),
+			existingConfig: Preference{},
+			want:           false,
+		},
+		{
+			name: fmt.Sprintf("Case 2: %s true", OfflineSetting),
+			existingConfig: Preference{
+				OdoSettings: OdoSettings{
+					Offline: &trueValue,
+				},
+			},
+			want: true,
+		},
+		{
+			name: fmt.Sprintf("Case 3: %s false overridden by %s", OfflineSetting, OfflineEnv),
+			existingConfig: Preference{
+				OdoSettings: OdoSettings{
+					Offline: &falseValue,
+				},
+			},
+			env:  "true",
+			want: true,
+		},
+		{
+			name: fmt.Sprintf("Case 4: %s true overridden by %s", OfflineSetting, OfflineEnv),
+			existingConfig: Preference{
+				OdoSettings: OdoSettings{
+					Offline: &trueValue,
+				},
+			},
+			env:  "false",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				os.Setenv(OfflineEnv, tt.env)
+				defer os.Unsetenv(OfflineEnv)
+			}
+			cfg := PreferenceInfo{
+				Preference: tt.existingConfig,
+			}
+			output := cfg.GetOffline()
+
+			if output != tt.want {
+				t.Errorf("GetOffline returned unexpected value\ngot: %t \nexpected: %t\n", output, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortRegistriesByPriority(t *testing.T) {
+	tests := []struct {
+		name         string
+		registryList []Registry
+		want         []string
+	}{
+		{
+			name: "Case 1: Registries without priority are ordered from the newest to the oldest",
+			registryList: []Registry{
+				{Name: "DefaultDevfileRegistry"},
+				{Name: "CheDevfileRegistry"},
+			},
+			want: []string{"CheDevfileRegistry", "DefaultDevfileRegistry"},
+		},
+		{
+			name: "Case 2: Registries with a higher priority come first",
+			registryList: []Registry{
+				{Name: "InternalRegistry", Priority: 10},
+				{Name: "DefaultDevfileRegistry"},
+				{Name: "CheDevfileRegistry"},
+				{Name: "LowRegistry", Priority: -1},
+			},
+			want: []string{"InternalRegistry", "CheDevfileRegistry", "DefaultDevfileRegistry", "LowRegistry"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, registry := range SortRegistriesByPriority(tt.registryList) {
+				got = append(got, registry.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package segment
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/pkg/errors"
+)
+
+const (
+	// telemetryFileName is the name of the default file of the file sink, in the odo configuration directory
+	telemetryFileName = "telemetry.jsonl"
+
+	// maxTelemetryFileSize is the size above which the file of the file sink is rotated
+	maxTelemetryFileSize = 5 * 1024 * 1024
+
+	// httpSinkTimeout is the timeout of the requests of the http sink
+	httpSinkTimeout = 5 * time.Second
+)
+
+// Sink receives the telemetry data of the odo commands
+type Sink interface {
+	// Upload sends the telemetry data, or queues it until the sink is closed
+	Upload(data TelemetryData) error
+	// Close sends the queued data and releases the sink
+	Close() error
+}
+
+var (
+	_ Sink = &Client{}
+	_ Sink = &FileSink{}
+	_ Sink = &HTTPSink{}
+)
+
+// TelemetryRecord is the telemetry data of an odo command, as written by the file and http sinks
+type TelemetryRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	TelemetryData
+}
+
+// NewSink returns the telemetry sink selected by the TelemetrySink preference
+func NewSink(cfg *preference.PreferenceInfo) (Sink, error) {
+	switch sink := cfg.GetTelemetrySink(); sink {
+	case preference.TelemetrySinkSegment:
+		return NewClient(cfg)
+	case preference.TelemetrySinkFile:
+		path := cfg.GetTelemetryEndpoint()
+		if path == "" {
+			var err error
+			if path, err = DefaultTelemetryFile(); err != nil {
+				return nil, err
+			}
+		}
+		return NewFileSink(path), nil
+	case preference.TelemetrySinkHTTP:
+		endpoint := cfg.GetTelemetryEndpoint()
+		if endpoint == "" {
+			return nil, fmt.Errorf("the %s preference must be set to the URL of the %s telemetry sink", preference.TelemetryEndpointSetting, sink)
+		}
+		return NewHTTPSink(endpoint), nil
+	default:
+		return nil, fmt.Errorf("unknown telemetry sink %q", sink)
+	}
+}
+
+// DefaultTelemetryFile returns the default file of the file sink
+func DefaultTelemetryFile() (string, error) {
+	configDir, err := preference.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, telemetryFileName), nil
+}
+
+// FileSink appends the telemetry records to a local JSON lines file, nothing is sent over the network
+type FileSink struct {
+	Path string
+}
+
+// NewFileSink returns a FileSink writing to the file at path
+func NewFileSink(path string) *FileSink {
+	return &FileSink{Path: path}
+}
+
+// Upload appends the record of the data to the file, the file is rotated to <path>.1 when it grows too big
+func (s *FileSink) Upload(data TelemetryData) error {
+	line, err := json.Marshal(TelemetryRecord{Timestamp: time.Now().UTC(), TelemetryData: data})
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.Path), 0750); err != nil {
+		return err
+	}
+	if info, err := os.Stat(s.Path); err == nil && info.Size() > maxTelemetryFileSize {
+		if err = os.Rename(s.Path, s.Path+".1"); err != nil {
+			return errors.Wrapf(err, "unable to rotate the telemetry file %s", s.Path)
+		}
+	}
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open the telemetry file %s", s.Path)
+	}
+	defer file.Close() // #nosec G307
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Close does nothing, the records are written by Upload
+func (s *FileSink) Close() error {
+	return nil
+}
+
+// HTTPSink posts the telemetry records as JSON to an HTTP endpoint
+type HTTPSink struct {
+	Endpoint string
+	client   *http.Client
+}
+
+// NewHTTPSink returns a HTTPSink posting to the endpoint
+func NewHTTPSink(endpoint string) *HTTPSink {
+	return &HTTPSink{
+		Endpoint: endpoint,
+		client:   &http.Client{Timeout: httpSinkTimeout},
+	}
+}
+
+// Upload posts the record of the data to the endpoint
+func (s *HTTPSink) Upload(data TelemetryData) error {
+	body, err := json.Marshal(TelemetryRecord{Timestamp: time.Now().UTC(), TelemetryData: data})
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "unable to send the telemetry data to %s", s.Endpoint)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unable to send the telemetry data to %s: %s", s.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Close does nothing, the records are sent by Upload
+func (s *HTTPSink) Close() error {
+	return nil
+}
+
+// ReadTelemetryRecords returns the last max records of the file written by a FileSink, the lines which are not
+// records are skipped
+func ReadTelemetryRecords(path string, max int) ([]TelemetryRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // #nosec G307
+
+	var records []TelemetryRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record TelemetryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+		if len(records) > max {
+			records = records[1:]
+		}
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,133 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/preference"
+)
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "telemetry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "odo", telemetryFileName)
+
+	sink := NewFileSink(path)
+	if err = sink.Upload(fakeTelemetryData("odo push", nil, context.Background())); err != nil {
+		t.Fatal(err)
+	}
+	if err = sink.Upload(fakeTelemetryData("odo url list", errors.New("no url"), context.Background())); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadTelemetryRecords(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Event != "odo url list" || records[0].Properties.Success || records[0].Timestamp.IsZero() {
+		t.Errorf("unexpected records %+v", records)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("the telemetry file must only be readable by the user: %v", err)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "Case 1: Record accepted",
+			status: http.StatusAccepted,
+		},
+		{
+			name:    "Case 2: Record rejected",
+			status:  http.StatusForbidden,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record TelemetryRecord
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+					t.Error(err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewHTTPSink(server.URL).Upload(fakeTelemetryData("odo push", nil, context.Background()))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if record.Event != "odo push" || !record.Properties.Success {
+				t.Errorf("unexpected record %+v", record)
+			}
+		})
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	fileSink := preference.TelemetrySinkFile
+	httpSink := preference.TelemetrySinkHTTP
+	endpoint := "https://telemetry.example.com/odo"
+
+	tests := []struct {
+		name     string
+		settings preference.OdoSettings
+		want     interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: Segment by default",
+			settings: preference.OdoSettings{},
+			want:     &Client{},
+		},
+		{
+			name:     "Case 2: File sink",
+			settings: preference.OdoSettings{TelemetrySink: &fileSink},
+			want:     &FileSink{},
+		},
+		{
+			name:     "Case 3: HTTP sink",
+			settings: preference.OdoSettings{TelemetrySink: &httpSink, TelemetryEndpoint: &endpoint},
+			want:     &HTTPSink{},
+		},
+		{
+			name:     "Case 4: HTTP sink without endpoint",
+			settings: preference.OdoSettings{TelemetrySink: &httpSink},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &preference.PreferenceInfo{Preference: preference.Preference{OdoSettings: tt.settings}}
+			sink, err := NewSink(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			defer sink.Close()
+			if reflect.TypeOf(sink) != reflect.TypeOf(tt.want) {
+				t.Errorf("got the sink %T, want %T", sink, tt.want)
+			}
+		})
+	}
+}
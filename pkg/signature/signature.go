@@ -0,0 +1,158 @@
+// Package signature computes the digests of the resources odo downloads from devfile registries
+// and verifies their detached signatures against the keys trusted by the user
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/util"
+)
+
+// SignatureSuffix is appended to the location of a resource to get the location of its detached signature
+const SignatureSuffix = ".sig"
+
+// ErrUnsigned is returned when a resource without signature is used from a registry requiring signatures
+var ErrUnsigned = errors.New("resource is not signed")
+
+// Result holds the outcome of the verification of a downloaded resource
+type Result struct {
+	// Digest is the sha256 digest of the resource in the sha256:<hex> form
+	Digest string
+	// Verified is true if the signature of the resource was verified with one of the trusted keys
+	Verified bool
+}
+
+// Verifier verifies the signatures of downloaded resources with the trusted public keys
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// ecdsaSignature is the ASN.1 structure of ECDSA signatures
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// Digest returns the sha256 digest of data in the sha256:<hex> form
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// NewVerifier creates a Verifier trusting the PEM encoded public keys stored in keyFiles
+func NewVerifier(keyFiles []string) (*Verifier, error) {
+	verifier := &Verifier{}
+	for _, keyFile := range keyFiles {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the trusted key %s", keyFile)
+		}
+		found := false
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to parse the trusted key %s", keyFile)
+			}
+			verifier.keys = append(verifier.keys, key)
+			found = true
+		}
+		if !found {
+			return nil, errors.Errorf("no PEM encoded public key found in %s", keyFile)
+		}
+	}
+	return verifier, nil
+}
+
+// Verify checks that sig is a signature of data made with one of the given keys. The signature can either be raw
+// or base64 encoded as produced by `cosign sign-blob`. ECDSA and RSA signatures are made over the sha256 digest of data.
+func Verify(data []byte, sig []byte, keys []crypto.PublicKey) error {
+	rawSig := sig
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig))); err == nil {
+		rawSig = decoded
+	}
+	digest := sha256.Sum256(data)
+
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			var esig ecdsaSignature
+			if _, err := asn1.Unmarshal(rawSig, &esig); err == nil && esig.R != nil && esig.S != nil && ecdsa.Verify(k, digest[:], esig.R, esig.S) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], rawSig) == nil {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, data, rawSig) {
+				return nil
+			}
+		default:
+			klog.V(4).Infof("Ignoring trusted key of unsupported type %T", key)
+		}
+	}
+	return errors.New("the signature doesn't match any of the trusted keys")
+}
+
+// Check computes the digest of the resource and verifies its signature if there is one, sig is nil for unsigned resources.
+// If the signature is required the resource must be signed by one of the trusted keys. A nil Verifier trusts no key.
+func (v *Verifier) Check(source string, data []byte, sig []byte, required bool) (Result, error) {
+	result := Result{Digest: Digest(data)}
+
+	if sig == nil {
+		if required {
+			return result, errors.Wrapf(ErrUnsigned, "%s can't be used, its registry requires signatures", source)
+		}
+		return result, nil
+	}
+
+	if v == nil || len(v.keys) == 0 {
+		if required {
+			return result, errors.Errorf("unable to verify the signature of %s, please configure the trusted keys with `odo preference set TrustedKeys`", source)
+		}
+		klog.V(4).Infof("No trusted key configured, the signature of %s is not verified", source)
+		return result, nil
+	}
+
+	if err := Verify(data, sig, v.keys); err != nil {
+		return result, errors.Wrapf(err, "invalid signature for %s", source)
+	}
+	result.Verified = true
+	return result, nil
+}
+
+// FetchSignature returns the detached signature published next to the resource at location, which is either
+// an http(s) URL, a file:// URL or a path, it returns nil if the resource isn't signed or the signature can't be retrieved
+func FetchSignature(location string, token string, cacheFor int, offline bool) []byte {
+	sigLocation := location + SignatureSuffix
+	if strings.HasPrefix(sigLocation, "http://") || strings.HasPrefix(sigLocation, "https://") {
+		resp, err := util.HTTPGetCachedRequest(util.HTTPRequestParams{URL: sigLocation, Token: token}, cacheFor, offline)
+		if err != nil {
+			klog.V(4).Infof("No signature retrieved from %s: %v", sigLocation, err)
+			return nil
+		}
+		return resp.Body
+	}
+
+	sig, err := ioutil.ReadFile(filepath.FromSlash(strings.TrimPrefix(sigLocation, "file://")))
+	if err != nil {
+		klog.V(4).Infof("No signature read from %s: %v", sigLocation, err)
+		return nil
+	}
+	return sig
+}
@@ -0,0 +1,137 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestDigest(t *testing.T) {
+	want := "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got := Digest([]byte{}); got != want {
+		t.Errorf("Got: %s, want: %s", got, want)
+	}
+}
+
+// createTestKey generates an ECDSA key and stores its public part in a PEM file within dir
+func createTestKey(t *testing.T, dir string, name string) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, name)
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, keyFile
+}
+
+// sign returns the base64 encoded signature of data like `cosign sign-blob` does
+func sign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	digest := sha256.Sum256(data)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestVerifierCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "odosignature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	trustedKey, trustedKeyFile := createTestKey(t, dir, "trusted.pub")
+	untrustedKey, _ := createTestKey(t, dir, "untrusted.pub")
+
+	verifier, err := NewVerifier([]string{trustedKeyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("schemaVersion: 2.0.0\n")
+	tests := []struct {
+		name         string
+		verifier     *Verifier
+		sig          []byte
+		required     bool
+		wantVerified bool
+		wantErr      bool
+	}{
+		{
+			name:     "Case 1: Unsigned resource from a registry not requiring signatures",
+			verifier: verifier,
+		},
+		{
+			name:     "Case 2: Unsigned resource from a registry requiring signatures",
+			verifier: verifier,
+			required: true,
+			wantErr:  true,
+		},
+		{
+			name:         "Case 3: Resource signed with the trusted key",
+			verifier:     verifier,
+			sig:          sign(t, trustedKey, data),
+			required:     true,
+			wantVerified: true,
+		},
+		{
+			name:     "Case 4: Resource signed with an untrusted key",
+			verifier: verifier,
+			sig:      sign(t, untrustedKey, data),
+			wantErr:  true,
+		},
+		{
+			name:     "Case 5: Signed resource without trusted keys",
+			verifier: nil,
+			sig:      sign(t, trustedKey, data),
+		},
+		{
+			name:     "Case 6: Signed resource without trusted keys from a registry requiring signatures",
+			verifier: nil,
+			sig:      sign(t, trustedKey, data),
+			required: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.verifier.Check("nodejs", data, tt.sig, tt.required)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Digest != Digest(data) {
+				t.Errorf("Got digest %s, want %s", result.Digest, Digest(data))
+			}
+			if result.Verified != tt.wantVerified {
+				t.Errorf("Got verified %t, want %t", result.Verified, tt.wantVerified)
+			}
+			if tt.sig == nil && tt.required && errors.Cause(err) != ErrUnsigned {
+				t.Errorf("Got error %v, want %v", err, ErrUnsigned)
+			}
+		})
+	}
+}
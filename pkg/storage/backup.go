@@ -0,0 +1,360 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/log"
+	storagelabels "github.com/openshift/odo/pkg/storage/labels"
+)
+
+// BackupMethod is the way the data of a storage is saved
+type BackupMethod string
+
+const (
+	// ArchiveBackup streams the content of the volume through the component container into a local tar archive
+	ArchiveBackup BackupMethod = "archive"
+	// SnapshotBackup takes a CSI VolumeSnapshot of the PVC of the storage
+	SnapshotBackup BackupMethod = "snapshot"
+)
+
+// mountedStorage is a storage of a component with the pod and the container in which it is mounted
+type mountedStorage struct {
+	pvc           *corev1.PersistentVolumeClaim
+	podName       string
+	containerName string
+	path          string
+}
+
+// getStoragePVC returns the PVC of the given storage of the component
+func getStoragePVC(kClient *kclient.Client, componentName, storageName string) (*corev1.PersistentVolumeClaim, error) {
+	selector := fmt.Sprintf("component=%s,%s=%s", componentName, storagelabels.DevfileStorageLabel, storageName)
+	pvcs, err := kClient.ListPVCs(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get PVC using selector %v", selector)
+	}
+	if len(pvcs) == 0 {
+		return nil, fmt.Errorf("the storage %s of component %s is not pushed to the cluster, please run 'odo push'", storageName, componentName)
+	}
+	return &pvcs[0], nil
+}
+
+// getMountedStorage returns the PVC of the given storage of the component and where it is mounted in the component pod
+func getMountedStorage(kClient *kclient.Client, componentName, storageName string) (*mountedStorage, error) {
+	pvc, err := getStoragePVC(kClient, componentName, storageName)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := kClient.GetPodUsingComponentName(componentName)
+	if err != nil {
+		if _, ok := err.(*kclient.PodNotFoundError); ok {
+			return nil, fmt.Errorf("the component %s is not running, please run 'odo push'", componentName)
+		}
+		return nil, err
+	}
+
+	volumeName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+			volumeName = volume.Name
+			break
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, volumeMount := range container.VolumeMounts {
+			if volumeName != "" && volumeMount.Name == volumeName {
+				return &mountedStorage{
+					pvc:           pvc,
+					podName:       pod.Name,
+					containerName: container.Name,
+					path:          volumeMount.MountPath,
+				}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("the storage %s is not mounted in any container of component %s", storageName, componentName)
+}
+
+// GetBackupMethod returns the snapshot method when the PVC of the storage can be snapshotted with a CSI
+// VolumeSnapshot, and the archive method otherwise
+func GetBackupMethod(kClient *kclient.Client, componentName, storageName string) (BackupMethod, error) {
+	pvc, err := getStoragePVC(kClient, componentName, storageName)
+	if err != nil {
+		return "", err
+	}
+	className, err := kClient.GetVolumeSnapshotClassForPVC(pvc)
+	if err != nil {
+		return "", err
+	}
+	if className == "" {
+		return ArchiveBackup, nil
+	}
+	return SnapshotBackup, nil
+}
+
+// BackupToArchive saves the content of the storage in a gzipped tar archive at archivePath,
+// streamed through the container of the component in which the storage is mounted
+func BackupToArchive(kClient *kclient.Client, componentName, storageName, archivePath string) (err error) {
+	storage, err := getMountedStorage(kClient, componentName, storageName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create archive %s", archivePath)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			_ = os.Remove(archivePath)
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(file)
+	cmd := []string{"tar", "cf", "-", "-C", storage.path, "."}
+	var stderr bytes.Buffer
+	klog.V(3).Infof("Executing command %s", strings.Join(cmd, " "))
+	err = kClient.ExecCMDInContainer(storage.containerName, storage.podName, cmd, gzipWriter, &stderr, nil, false)
+	if err != nil {
+		return errors.Wrapf(err, "unable to archive the content of storage %s: %s", storageName, stderr.String())
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return errors.Wrapf(err, "unable to write archive %s", archivePath)
+	}
+	return file.Close()
+}
+
+// RestoreFromArchive extracts the gzipped tar archive at archivePath into the storage, through the container
+// of the component in which the storage is mounted; existing files are overwritten, other files are kept
+func RestoreFromArchive(kClient *kclient.Client, componentName, storageName, archivePath string) error {
+	storage, err := getMountedStorage(kClient, componentName, storageName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open archive %s", archivePath)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrapf(err, "%s is not a gzipped tar archive", archivePath)
+	}
+	defer gzipReader.Close()
+
+	cmd := []string{"tar", "xf", "-", "-C", storage.path}
+	var stdout, stderr bytes.Buffer
+	klog.V(3).Infof("Executing command %s", strings.Join(cmd, " "))
+	err = kClient.ExecCMDInContainer(storage.containerName, storage.podName, cmd, &stdout, &stderr, gzipReader, false)
+	if err != nil {
+		return errors.Wrapf(err, "unable to restore the content of storage %s: %s", storageName, stderr.String())
+	}
+	return nil
+}
+
+// BackupToSnapshot takes a VolumeSnapshot of the PVC of the storage and waits for it to be ready,
+// it returns the name of the snapshot
+func BackupToSnapshot(kClient *kclient.Client, componentName, applicationName, storageName string) (string, error) {
+	pvc, err := getStoragePVC(kClient, componentName, storageName)
+	if err != nil {
+		return "", err
+	}
+	className, err := kClient.GetVolumeSnapshotClassForPVC(pvc)
+	if err != nil {
+		return "", err
+	}
+	if className == "" {
+		return "", fmt.Errorf("no volume snapshot class can snapshot the PVC %s of storage %s", pvc.Name, storageName)
+	}
+
+	labels := storagelabels.GetLabels(storageName, componentName, applicationName, true)
+	labels["component"] = componentName
+	labels[storagelabels.DevfileStorageLabel] = storageName
+
+	pvcName := pvc.Name
+	snapshot := kclient.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-%s", pvc.Name, time.Now().UTC().Format("20060102150405")),
+			Labels: labels,
+		},
+		Spec: kclient.VolumeSnapshotSpec{
+			Source:                  kclient.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+			VolumeSnapshotClassName: &className,
+		},
+	}
+	klog.V(2).Infof("Creating VolumeSnapshot %s of PVC %s with class %s", snapshot.Name, pvc.Name, className)
+	created, err := kClient.CreateVolumeSnapshot(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	s := log.Spinnerf("Waiting for snapshot %s to be ready", created.Name)
+	defer s.End(false)
+	if _, err = kClient.WaitForVolumeSnapshotReady(created.Name); err != nil {
+		return "", err
+	}
+	s.End(true)
+	return created.Name, nil
+}
+
+// GetLatestSnapshot returns the name of the most recent ready VolumeSnapshot of the storage
+func GetLatestSnapshot(kClient *kclient.Client, componentName, storageName string) (string, error) {
+	selector := fmt.Sprintf("component=%s,%s=%s", componentName, storagelabels.DevfileStorageLabel, storageName)
+	snapshots, err := kClient.ListVolumeSnapshots(selector)
+	if err != nil {
+		return "", err
+	}
+	latest := latestReadySnapshot(snapshots)
+	if latest == "" {
+		return "", fmt.Errorf("no snapshot of storage %s found", storageName)
+	}
+	return latest, nil
+}
+
+// latestReadySnapshot returns the name of the most recent snapshot ready to use, or an empty string
+func latestReadySnapshot(snapshots []kclient.VolumeSnapshot) string {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+	for _, snapshot := range snapshots {
+		if snapshot.Status != nil && snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse {
+			return snapshot.Name
+		}
+	}
+	return ""
+}
+
+// RestoreFromSnapshot replaces the PVC of the storage by a PVC provisioned from the given VolumeSnapshot;
+// the component is scaled down while its PVC is replaced
+func RestoreFromSnapshot(kClient *kclient.Client, componentName, storageName, snapshotName string) (err error) {
+	snapshot, err := kClient.GetVolumeSnapshot(snapshotName)
+	if err != nil {
+		return err
+	}
+	if snapshot.Labels["component"] != componentName || snapshot.Labels[storagelabels.DevfileStorageLabel] != storageName {
+		return fmt.Errorf("the snapshot %s is not a snapshot of storage %s of component %s", snapshotName, storageName, componentName)
+	}
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return fmt.Errorf("the snapshot %s is not ready to use", snapshotName)
+	}
+
+	pvc, err := getStoragePVC(kClient, componentName, storageName)
+	if err != nil {
+		return err
+	}
+	restoredPVC, err := generateRestoredPVC(pvc, snapshot)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := kClient.GetDeploymentByName(componentName)
+	if err != nil {
+		return err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0 {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	s := log.Spinnerf("Stopping component %s", componentName)
+	zero := int32(0)
+	deployment.Spec.Replicas = &zero
+	if _, err = kClient.UpdateDeployment(*deployment); err != nil {
+		s.End(false)
+		return errors.Wrapf(err, "unable to scale down component %s", componentName)
+	}
+	// whatever happens, start the component again
+	defer func() {
+		scaleErr := scaleDeployment(kClient, componentName, replicas)
+		if err == nil {
+			err = scaleErr
+		}
+	}()
+	if err = kClient.WaitForPodsDeletion("component=" + componentName); err != nil {
+		s.End(false)
+		return err
+	}
+	s.End(true)
+
+	s = log.Spinnerf("Restoring storage %s from snapshot %s", storageName, snapshotName)
+	defer s.End(false)
+	if err = kClient.DeletePVC(pvc.Name); err != nil {
+		return errors.Wrapf(err, "unable to delete PVC %s", pvc.Name)
+	}
+	if err = kClient.WaitForPVCDeletion(pvc.Name); err != nil {
+		return err
+	}
+	if _, err = kClient.CreatePVC(*restoredPVC); err != nil {
+		return errors.Wrapf(err, "unable to create PVC %s from snapshot %s", pvc.Name, snapshotName)
+	}
+	s.End(true)
+	return nil
+}
+
+// scaleDeployment sets the replicas of the deployment of the component and waits for its rollout
+func scaleDeployment(kClient *kclient.Client, componentName string, replicas int32) error {
+	deployment, err := kClient.GetDeploymentByName(componentName)
+	if err != nil {
+		return err
+	}
+	deployment.Spec.Replicas = &replicas
+	if _, err = kClient.UpdateDeployment(*deployment); err != nil {
+		return errors.Wrapf(err, "unable to scale up component %s", componentName)
+	}
+	_, err = kClient.WaitForDeploymentRollout(componentName)
+	return err
+}
+
+// generateRestoredPVC returns a copy of the PVC provisioned from the snapshot, its size being increased
+// to the restore size of the snapshot when needed
+func generateRestoredPVC(pvc *corev1.PersistentVolumeClaim, snapshot *kclient.VolumeSnapshot) (*corev1.PersistentVolumeClaim, error) {
+	apiGroup := kclient.SnapshotAPIGroup
+	restored := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pvc.Name,
+			Labels:          pvc.Labels,
+			OwnerReferences: pvc.OwnerReferences,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        *pvc.Spec.Resources.DeepCopy(),
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     kclient.VolumeSnapshotKind,
+				Name:     snapshot.Name,
+			},
+		},
+	}
+
+	if snapshot.Status != nil && snapshot.Status.RestoreSize != nil {
+		restoreSize, err := resource.ParseQuantity(*snapshot.Status.RestoreSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse the restore size of snapshot %s", snapshot.Name)
+		}
+		size := restored.Spec.Resources.Requests[corev1.ResourceStorage]
+		if restoreSize.Cmp(size) > 0 {
+			if restored.Spec.Resources.Requests == nil {
+				restored.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			restored.Spec.Resources.Requests[corev1.ResourceStorage] = restoreSize
+		}
+	}
+	return &restored, nil
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/kclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func Test_getMountedStorage(t *testing.T) {
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "storage-1-nodejs",
+			Labels: map[string]string{"component": "nodejs", "storage-name": "storage-1"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "nodejs-pod",
+			Labels: map[string]string{"component": "nodejs"},
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "storage-1-nodejs-vol",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "storage-1-nodejs"},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{Name: "sidecar"},
+				{
+					Name:         "runtime",
+					VolumeMounts: []corev1.VolumeMount{{Name: "storage-1-nodejs-vol", MountPath: "/data"}},
+				},
+			},
+		},
+	}
+	unmountedPod := *pod.DeepCopy()
+	unmountedPod.Spec.Containers[1].VolumeMounts = nil
+
+	tests := []struct {
+		name         string
+		returnedPVCs []corev1.PersistentVolumeClaim
+		returnedPods []corev1.Pod
+		want         *mountedStorage
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: Storage mounted in a container",
+			returnedPVCs: []corev1.PersistentVolumeClaim{pvc},
+			returnedPods: []corev1.Pod{pod},
+			want: &mountedStorage{
+				pvc:           &pvc,
+				podName:       "nodejs-pod",
+				containerName: "runtime",
+				path:          "/data",
+			},
+		},
+		{
+			name:         "Case 2: Storage not pushed",
+			returnedPods: []corev1.Pod{pod},
+			wantErr:      true,
+		},
+		{
+			name:         "Case 3: Component not running",
+			returnedPVCs: []corev1.PersistentVolumeClaim{pvc},
+			wantErr:      true,
+		},
+		{
+			name:         "Case 4: Storage not mounted",
+			returnedPVCs: []corev1.PersistentVolumeClaim{pvc},
+			returnedPods: []corev1.Pod{unmountedPod},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, fakeClientSet := kclient.FakeNew()
+			fakeClientSet.Kubernetes.PrependReactor("list", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PersistentVolumeClaimList{Items: tt.returnedPVCs}, nil
+			})
+			fakeClientSet.Kubernetes.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PodList{Items: tt.returnedPods}, nil
+			})
+
+			got, err := getMountedStorage(fakeClient, "nodejs", "storage-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getMountedStorage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getMountedStorage() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_latestReadySnapshot(t *testing.T) {
+	ready, notReady := true, false
+	now := time.Now()
+	snapshot := func(name string, age time.Duration, readyToUse *bool) kclient.VolumeSnapshot {
+		return kclient.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status:     &kclient.VolumeSnapshotStatus{ReadyToUse: readyToUse},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		snapshots []kclient.VolumeSnapshot
+		want      string
+	}{
+		{
+			name:      "Case 1: Most recent snapshot",
+			snapshots: []kclient.VolumeSnapshot{snapshot("old", time.Hour, &ready), snapshot("new", time.Minute, &ready)},
+			want:      "new",
+		},
+		{
+			name:      "Case 2: Most recent snapshot not ready",
+			snapshots: []kclient.VolumeSnapshot{snapshot("old", time.Hour, &ready), snapshot("new", time.Minute, &notReady), snapshot("pending", time.Second, nil)},
+			want:      "old",
+		},
+		{
+			name: "Case 3: No snapshot",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestReadySnapshot(tt.snapshots); got != tt.want {
+				t.Errorf("latestReadySnapshot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generateRestoredPVC(t *testing.T) {
+	storageClass := "csi-hostpath-sc"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "storage-1-nodejs",
+			Labels:          map[string]string{"component": "nodejs"},
+			ResourceVersion: "12",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			StorageClassName: &storageClass,
+			VolumeName:       "pvc-1234",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		restoreSize string
+		wantSize    string
+		wantErr     bool
+	}{
+		{
+			name:     "Case 1: No restore size",
+			wantSize: "1Gi",
+		},
+		{
+			name:        "Case 2: Smaller restore size",
+			restoreSize: "500Mi",
+			wantSize:    "1Gi",
+		},
+		{
+			name:        "Case 3: Larger restore size",
+			restoreSize: "2Gi",
+			wantSize:    "2Gi",
+		},
+		{
+			name:        "Case 4: Invalid restore size",
+			restoreSize: "big",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := &kclient.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-1-nodejs-20210101000000"},
+				Status:     &kclient.VolumeSnapshotStatus{},
+			}
+			if tt.restoreSize != "" {
+				snapshot.Status.RestoreSize = &tt.restoreSize
+			}
+
+			got, err := generateRestoredPVC(pvc, snapshot)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("generateRestoredPVC() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != pvc.Name || got.ResourceVersion != "" || got.Spec.VolumeName != "" {
+				t.Errorf("the restored PVC %s should be a new PVC", got.Name)
+			}
+			if got.Spec.DataSource == nil || got.Spec.DataSource.Kind != kclient.VolumeSnapshotKind || got.Spec.DataSource.Name != snapshot.Name {
+				t.Errorf("got data source %+v, want the snapshot %s", got.Spec.DataSource, snapshot.Name)
+			}
+			size := got.Spec.Resources.Requests[corev1.ResourceStorage]
+			if size.String() != tt.wantSize {
+				t.Errorf("got size %s, want %s", size.String(), tt.wantSize)
+			}
+			if original := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; original.String() != "1Gi" {
+				t.Errorf("the original PVC should not be modified, got size %s", original.String())
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/devfile/library/pkg/devfile/generator"
@@ -52,6 +51,16 @@ func (k kubernetesClient) Create(storage Storage) error {
 		Quantity:   quantity,
 	}
 	pvc := generator.GetPVC(pvcParams)
+	if storage.Spec.StorageClass != "" {
+		pvc.Spec.StorageClassName = &storage.Spec.StorageClass
+	}
+	if storage.Spec.AccessMode != "" {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.PersistentVolumeAccessMode(storage.Spec.AccessMode)}
+	}
+	if storage.Spec.VolumeMode != "" {
+		volumeMode := corev1.PersistentVolumeMode(storage.Spec.VolumeMode)
+		pvc.Spec.VolumeMode = &volumeMode
+	}
 
 	// Create PVC
 	klog.V(2).Infof("Creating a PVC with name %v and labels %v", pvcName, labels)
@@ -62,6 +71,22 @@ func (k kubernetesClient) Create(storage Storage) error {
 	return nil
 }
 
+// Update increases the size of the pvc belonging to the given Storage to the size of the Storage
+func (k kubernetesClient) Update(storage Storage) error {
+	pvcName, err := getPVCNameFromStorageName(&k.client, storage.Name)
+	if err != nil {
+		return err
+	}
+
+	quantity, err := resource.ParseQuantity(storage.Spec.Size)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse size: %v", storage.Spec.Size)
+	}
+
+	klog.V(2).Infof("Expanding the PVC %v to %v", pvcName, quantity.String())
+	return k.client.GetKubeClient().ExpandPVC(pvcName, quantity)
+}
+
 // Delete deletes the pvc belonging to the given Storage
 func (k kubernetesClient) Delete(name string) error {
 	pvcName, err := getPVCNameFromStorageName(&k.client, name)
@@ -141,7 +166,9 @@ func (k kubernetesClient) ListFromCluster() (StorageList, error) {
 
 				found = true
 				size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
-				storage = append(storage, GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName))
+				clusterStorage := GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName)
+				setPVCOptions(&clusterStorage, pvc)
+				storage = append(storage, clusterStorage)
 			}
 		}
 		if !found {
@@ -180,7 +207,7 @@ func (k kubernetesClient) List() (StorageList, error) {
 	for _, localStore := range localStorage.Items {
 		found := false
 		for _, clusterStore := range clusterStorage.Items {
-			if reflect.DeepEqual(localStore, clusterStore) {
+			if matchesClusterStorage(localStore, clusterStore) {
 				found = true
 			}
 		}
@@ -196,7 +223,7 @@ func (k kubernetesClient) List() (StorageList, error) {
 	for _, clusterStore := range clusterStorage.Items {
 		found := false
 		for _, localStore := range localStorage.Items {
-			if reflect.DeepEqual(localStore, clusterStore) {
+			if matchesClusterStorage(localStore, clusterStore) {
 				found = true
 			}
 		}
@@ -687,6 +687,24 @@ func Test_kubernetesClient_Create(t *testing.T) {
 				storage: GetMachineFormatWithContainer("odo-projects-vol", "5Gi", "/data", "runtime"),
 			},
 		},
+		{
+			name: "case 4: storage with storage class, access mode and volume mode",
+			fields: fields{
+				generic: generic{
+					appName:       "app",
+					componentName: "nodejs",
+				},
+			},
+			args: args{
+				storage: func() Storage {
+					storage := GetMachineFormatWithContainer("storage-0", "5Gi", "/data", "runtime")
+					storage.Spec.StorageClass = "fast"
+					storage.Spec.AccessMode = "ReadWriteMany"
+					storage.Spec.VolumeMode = "Block"
+					return storage
+				}(),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -736,6 +754,16 @@ func Test_kubernetesClient_Create(t *testing.T) {
 			if !reflect.DeepEqual(createdPVC.Spec.Resources.Requests["storage"], quantity) {
 				t.Errorf("size of PVC is not matching to expected size, expected: %v, got %v", quantity, createdPVC.Spec.Resources.Requests["storage"])
 			}
+
+			// storage class, access mode and volume mode of createdPVC should be the ones of the storage
+			gotStorage := Storage{}
+			setPVCOptions(&gotStorage, *createdPVC)
+			if tt.args.storage.Spec.StorageClass != gotStorage.Spec.StorageClass || tt.args.storage.Spec.VolumeMode != gotStorage.Spec.VolumeMode {
+				t.Errorf("options of PVC are not matching the options of the storage, expected: %v, got %v", tt.args.storage.Spec, gotStorage.Spec)
+			}
+			if tt.args.storage.Spec.AccessMode != "" && tt.args.storage.Spec.AccessMode != gotStorage.Spec.AccessMode {
+				t.Errorf("access mode of PVC is not matching the access mode of the storage, expected: %v, got %v", tt.args.storage.Spec.AccessMode, gotStorage.Spec.AccessMode)
+			}
 		})
 	}
 }
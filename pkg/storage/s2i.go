@@ -31,6 +31,12 @@ func (s s2iClient) Delete(name string) error {
 	return nil
 }
 
+// this method is currently not being used by s2i components
+// it is here to satisfy the interface
+func (s s2iClient) Update(storage Storage) error {
+	return nil
+}
+
 // ListFromCluster lists pvc based Storage from the cluster for s2i components
 func (s s2iClient) ListFromCluster() (StorageList, error) {
 	componentLabels := componentlabels.GetLabels(s.localConfig.GetName(), s.localConfig.GetApplication(), false)
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/localConfigProvider"
@@ -16,6 +17,7 @@ import (
 	"github.com/openshift/odo/pkg/util"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 )
@@ -536,6 +538,9 @@ func ConvertListLocalToMachine(storageListConfig []localConfigProvider.LocalStor
 	for _, storeLocal := range storageListConfig {
 		s := GetMachineReadableFormat(storeLocal.Name, storeLocal.Size, storeLocal.Path)
 		s.Spec.ContainerName = storeLocal.Container
+		s.Spec.StorageClass = storeLocal.StorageClass
+		s.Spec.AccessMode = storeLocal.AccessMode
+		s.Spec.VolumeMode = storeLocal.VolumeMode
 		storageListLocal = append(storageListLocal, s)
 	}
 
@@ -602,7 +607,9 @@ func DevfileListMounted(kClient *kclient.Client, componentName string) (StorageL
 			if volumeMount.Name == pvc.Name+"-vol" {
 				found = true
 				size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
-				storage = append(storage, GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName))
+				clusterStorage := GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName)
+				setPVCOptions(&clusterStorage, pvc)
+				storage = append(storage, clusterStorage)
 			}
 		}
 		if !found {
@@ -620,6 +627,7 @@ type ClientOptions struct {
 
 type Client interface {
 	Create(Storage) error
+	Update(Storage) error
 	Delete(string) error
 	ListFromCluster() (StorageList, error)
 	List() (StorageList, error)
@@ -680,6 +688,7 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 	}
 
 	// find storage to delete
+	var storageToExpand []Storage
 	for storageName, storage := range storageClusterNames {
 		val, ok := storageConfigNames[storageName]
 		if !ok {
@@ -691,12 +700,25 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 			log.Successf("Deleted storage %v from %v", storage.Name, configProvider.GetName())
 			continue
 		} else if storage.Name == val.Name {
-			if val.Spec.Size != storage.Spec.Size {
-				return errors.Errorf("config mismatch for storage with the same name %s", storage.Name)
+			expand, err := checkStorageUpdate(val, storage)
+			if err != nil {
+				return err
+			}
+			if expand {
+				storageToExpand = append(storageToExpand, val)
 			}
 		}
 	}
 
+	// expand the storage whose size was increased
+	for _, storage := range storageToExpand {
+		err = client.Update(storage)
+		if err != nil {
+			return err
+		}
+		log.Successf("Expanded storage %v of %v to %v", storage.Name, configProvider.GetName(), storage.Spec.Size)
+	}
+
 	// find storage to create
 	for storageName, storage := range storageConfigNames {
 		_, ok := storageClusterNames[storageName]
@@ -711,3 +733,69 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 
 	return err
 }
+
+// setPVCOptions sets the storage class, access mode and volume mode of the PVC on the Storage
+func setPVCOptions(storage *Storage, pvc corev1.PersistentVolumeClaim) {
+	if pvc.Spec.StorageClassName != nil {
+		storage.Spec.StorageClass = *pvc.Spec.StorageClassName
+	}
+	if len(pvc.Spec.AccessModes) > 0 {
+		storage.Spec.AccessMode = string(pvc.Spec.AccessModes[0])
+	}
+	if pvc.Spec.VolumeMode != nil {
+		storage.Spec.VolumeMode = string(*pvc.Spec.VolumeMode)
+	}
+}
+
+// matchesPVCOption returns true if the option of the local storage is the one of the PVC of the cluster storage;
+// an option which is not set locally matches any option of the PVC
+func matchesPVCOption(local, cluster, defaultValue string) bool {
+	return local == "" || local == cluster || (cluster == "" && local == defaultValue)
+}
+
+// matchesClusterStorage returns true if the local storage is the same as the storage on the cluster
+func matchesClusterStorage(local, cluster Storage) bool {
+	if matchesPVCOption(local.Spec.StorageClass, cluster.Spec.StorageClass, "") &&
+		matchesPVCOption(local.Spec.AccessMode, cluster.Spec.AccessMode, string(corev1.ReadWriteOnce)) &&
+		matchesPVCOption(local.Spec.VolumeMode, cluster.Spec.VolumeMode, string(corev1.PersistentVolumeFilesystem)) {
+		local.Spec.StorageClass = cluster.Spec.StorageClass
+		local.Spec.AccessMode = cluster.Spec.AccessMode
+		local.Spec.VolumeMode = cluster.Spec.VolumeMode
+	}
+	localSize, localErr := resource.ParseQuantity(local.Spec.Size)
+	clusterSize, clusterErr := resource.ParseQuantity(cluster.Spec.Size)
+	if localErr == nil && clusterErr == nil && localSize.Cmp(clusterSize) == 0 {
+		local.Spec.Size = cluster.Spec.Size
+	}
+	return reflect.DeepEqual(local, cluster)
+}
+
+// checkStorageUpdate checks that the changes between the local storage and the storage on the cluster
+// can be applied to its PVC, it returns true if the PVC needs to be expanded
+func checkStorageUpdate(local, cluster Storage) (bool, error) {
+	if !matchesPVCOption(local.Spec.StorageClass, cluster.Spec.StorageClass, "") {
+		return false, errors.Errorf("the storage class of storage %s can't be changed from %q to %q, delete the storage and push it again to change it", local.Name, cluster.Spec.StorageClass, local.Spec.StorageClass)
+	}
+	if !matchesPVCOption(local.Spec.AccessMode, cluster.Spec.AccessMode, string(corev1.ReadWriteOnce)) {
+		return false, errors.Errorf("the access mode of storage %s can't be changed from %q to %q, delete the storage and push it again to change it", local.Name, cluster.Spec.AccessMode, local.Spec.AccessMode)
+	}
+	if !matchesPVCOption(local.Spec.VolumeMode, cluster.Spec.VolumeMode, string(corev1.PersistentVolumeFilesystem)) {
+		return false, errors.Errorf("the volume mode of storage %s can't be changed from %q to %q, delete the storage and push it again to change it", local.Name, cluster.Spec.VolumeMode, local.Spec.VolumeMode)
+	}
+
+	localSize, err := resource.ParseQuantity(local.Spec.Size)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse size of storage %s: %v", local.Name, local.Spec.Size)
+	}
+	clusterSize, err := resource.ParseQuantity(cluster.Spec.Size)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse size of storage %s on the cluster: %v", cluster.Name, cluster.Spec.Size)
+	}
+	switch localSize.Cmp(clusterSize) {
+	case -1:
+		return false, errors.Errorf("the size of storage %s can't be reduced from %s to %s", local.Name, cluster.Spec.Size, local.Spec.Size)
+	case 1:
+		return true, nil
+	}
+	return false, nil
+}
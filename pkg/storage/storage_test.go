@@ -1052,6 +1052,9 @@ func TestPush(t *testing.T) {
 
 	clusterStorage0 := GetMachineFormatWithContainer("storage-0", "1Gi", "/data", "runtime-0")
 	clusterStorage1 := GetMachineFormatWithContainer("storage-1", "5Gi", "/path", "runtime-1")
+	clusterStorage1WithOptions := GetMachineFormatWithContainer("storage-1", "5Gi", "/path", "runtime-1")
+	clusterStorage1WithOptions.Spec.StorageClass = "standard"
+	clusterStorage1WithOptions.Spec.AccessMode = "ReadWriteOnce"
 
 	tests := []struct {
 		name                string
@@ -1059,6 +1062,7 @@ func TestPush(t *testing.T) {
 		returnedFromCluster StorageList
 		createdItems        []localConfigProvider.LocalStorage
 		deletedItems        []string
+		expandedItems       []localConfigProvider.LocalStorage
 		wantErr             bool
 	}{
 		{
@@ -1201,6 +1205,101 @@ func TestPush(t *testing.T) {
 			},
 			deletedItems: []string{"storage-0"},
 		},
+		{
+			name: "case 10: size increased",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "10Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+			expandedItems: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "10Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+		},
+		{
+			name: "case 11: same size with a different unit",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "5120Mi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+		},
+		{
+			name: "case 12: storage class changed",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:         "storage-1",
+					Size:         "5Gi",
+					Path:         "/path",
+					Container:    "runtime-1",
+					StorageClass: "fast",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1WithOptions,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 13: access mode changed",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:       "storage-1",
+					Size:       "5Gi",
+					Path:       "/path",
+					Container:  "runtime-1",
+					AccessMode: "ReadWriteMany",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1WithOptions,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 14: options set locally to the ones of the PVC",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:         "storage-1",
+					Size:         "5Gi",
+					Path:         "/path",
+					Container:    "runtime-1",
+					StorageClass: "standard",
+					AccessMode:   "ReadWriteOnce",
+					VolumeMode:   "Filesystem",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1WithOptions,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1225,6 +1324,11 @@ func TestPush(t *testing.T) {
 				fakeStorageClient.EXPECT().Delete(tt.deletedItems[i]).Return(nil).Times(1)
 			}
 
+			expanded := ConvertListLocalToMachine(tt.expandedItems)
+			for i := range expanded.Items {
+				fakeStorageClient.EXPECT().Update(expanded.Items[i]).Return(nil).Times(1)
+			}
+
 			if err := Push(fakeStorageClient, fakeLocalConfig); (err != nil) != tt.wantErr {
 				t.Errorf("Push() error = %v, wantErr %v", err, tt.wantErr)
 			}
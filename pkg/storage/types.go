@@ -31,6 +31,13 @@ type StorageSpec struct {
 	Path string `json:"path,omitempty"`
 
 	ContainerName string `json:"containerName,omitempty"`
+
+	// StorageClass is the StorageClass of the PVC, the default class of the cluster is used if empty
+	StorageClass string `json:"storageClass,omitempty"`
+	// AccessMode is the access mode of the PVC, ReadWriteOnce is used if empty
+	AccessMode string `json:"accessMode,omitempty"`
+	// VolumeMode is the volume mode of the PVC, Filesystem is used if empty
+	VolumeMode string `json:"volumeMode,omitempty"`
 }
 
 // StorageList is a list of storages
@@ -0,0 +1,110 @@
+package url
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/library/pkg/devfile/generator"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	urlLabels "github.com/openshift/odo/pkg/url/labels"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createHTTPRoute creates an HTTPRoute attached to the Gateway of the URL and returns the URL string
+func createHTTPRoute(kClient *kclient.Client, parameters CreateParameters, labels map[string]string) (string, error) {
+	if parameters.host == "" {
+		return "", errors.Errorf("the host cannot be empty")
+	}
+	if parameters.gateway == "" {
+		return "", errors.Errorf("the gateway cannot be empty")
+	}
+	if parameters.secureURL {
+		return "", errors.Errorf("secure URLs of HTTPRoute kind are not supported, TLS is configured on the Gateway")
+	}
+
+	deployment, err := kClient.GetDeploymentByName(parameters.componentName)
+	if err != nil {
+		return "", err
+	}
+
+	objectMeta := generator.GetObjectMeta(parameters.componentName, kClient.Namespace, labels, nil)
+	// to avoid error due to duplicate route name defined in different devfile components
+	objectMeta.Name = fmt.Sprintf("%s-%s", parameters.urlName, parameters.componentName)
+	objectMeta.OwnerReferences = append(objectMeta.OwnerReferences, generator.GetOwnerReference(deployment))
+
+	hostname := fmt.Sprintf("%v.%v", parameters.urlName, parameters.host)
+	route := generateHTTPRoute(objectMeta, parameters.componentName, hostname, parameters.path, parameters.portNumber, parameters.gateway)
+	_, err = kClient.CreateHTTPRoute(route)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create HTTPRoute")
+	}
+	return GetURLString("http", "", hostname, false), nil
+}
+
+// generateHTTPRoute generates an HTTPRoute attached to the gateway, referenced as [namespace/]name,
+// which routes the requests for the hostname and the path to the port of the service
+func generateHTTPRoute(objectMeta metav1.ObjectMeta, serviceName string, hostname string, path string, port int, gateway string) kclient.HTTPRoute {
+	if path == "" {
+		path = "/"
+	}
+	return kclient.HTTPRoute{
+		ObjectMeta: objectMeta,
+		Spec: kclient.HTTPRouteSpec{
+			ParentRefs: []kclient.GatewayReference{parseGatewayReference(gateway)},
+			Hostnames:  []string{hostname},
+			Rules: []kclient.HTTPRouteRule{
+				{
+					Matches: []kclient.HTTPRouteMatch{
+						{
+							Path: &kclient.HTTPPathMatch{Type: "PathPrefix", Value: path},
+						},
+					},
+					BackendRefs: []kclient.HTTPBackendRef{
+						{
+							Name: serviceName,
+							Port: int32(port),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseGatewayReference parses a Gateway referenced as [namespace/]name
+func parseGatewayReference(gateway string) kclient.GatewayReference {
+	if i := strings.Index(gateway, "/"); i != -1 {
+		return kclient.GatewayReference{Namespace: gateway[:i], Name: gateway[i+1:]}
+	}
+	return kclient.GatewayReference{Name: gateway}
+}
+
+// getMachineReadableFormatHTTPRoute gives machine readable URL definition of an HTTPRoute
+func getMachineReadableFormatHTTPRoute(r kclient.HTTPRoute) URL {
+	url := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: r.Labels[urlLabels.URLLabel]},
+		Spec:       URLSpec{Kind: localConfigProvider.HTTPROUTE},
+	}
+	if len(r.Spec.Hostnames) > 0 {
+		url.Spec.Host = r.Spec.Hostnames[0]
+	}
+	if len(r.Spec.ParentRefs) > 0 {
+		url.Spec.Gateway = r.Spec.ParentRefs[0].Name
+		if r.Spec.ParentRefs[0].Namespace != "" {
+			url.Spec.Gateway = r.Spec.ParentRefs[0].Namespace + "/" + url.Spec.Gateway
+		}
+	}
+	if len(r.Spec.Rules) > 0 {
+		rule := r.Spec.Rules[0]
+		if len(rule.Matches) > 0 && rule.Matches[0].Path != nil {
+			url.Spec.Path = rule.Matches[0].Path.Value
+		}
+		if len(rule.BackendRefs) > 0 {
+			url.Spec.Port = int(rule.BackendRefs[0].Port)
+		}
+	}
+	return url
+}
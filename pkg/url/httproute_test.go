@@ -0,0 +1,61 @@
+package url
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	urlLabels "github.com/openshift/odo/pkg/url/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseGatewayReference(t *testing.T) {
+	tests := []struct {
+		gateway string
+		want    kclient.GatewayReference
+	}{
+		{
+			gateway: "external",
+			want:    kclient.GatewayReference{Name: "external"},
+		},
+		{
+			gateway: "infra/external",
+			want:    kclient.GatewayReference{Namespace: "infra", Name: "external"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gateway, func(t *testing.T) {
+			if got := parseGatewayReference(tt.gateway); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMachineReadableFormatHTTPRoute(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:   "example-nodejs",
+		Labels: urlLabels.GetLabels("example", "nodejs", "app", true),
+	}
+	route := generateHTTPRoute(objectMeta, "nodejs", "example.com", "/api", 8080, "infra/external")
+
+	want := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: URLSpec{
+			Host:    "example.com",
+			Port:    8080,
+			Path:    "/api",
+			Kind:    localConfigProvider.HTTPROUTE,
+			Gateway: "infra/external",
+		},
+	}
+	if got := getMachineReadableFormatHTTPRoute(route); !reflect.DeepEqual(got, want) {
+		t.Errorf("getMachineReadableFormatHTTPRoute() mismatch: %v", pretty.Compare(got, want))
+	}
+	if route.Spec.Rules[0].BackendRefs[0].Name != "nodejs" {
+		t.Errorf("Got backend %s, want nodejs", route.Spec.Rules[0].BackendRefs[0].Name)
+	}
+}
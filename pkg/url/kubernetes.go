@@ -9,7 +9,6 @@ import (
 	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/occlient"
 	"github.com/pkg/errors"
-	"k8s.io/klog"
 )
 
 // kubernetesClient contains information required for devfile based URL based operations
@@ -19,15 +18,19 @@ type kubernetesClient struct {
 	client           occlient.Client
 }
 
-// ListCluster lists both route and ingress based URLs from the cluster
+// ListCluster lists the route, ingress and HTTPRoute based URLs from the cluster
 func (k kubernetesClient) ListFromCluster() (URLList, error) {
 	labelSelector := fmt.Sprintf("%v=%v", componentlabels.ComponentLabel, k.componentName)
-	klog.V(4).Infof("Listing ingresses with label selector: %v", labelSelector)
-	ingresses, err := k.client.GetKubeClient().ListIngresses(labelSelector)
+	ingressURLs, err := listIngressURLs(k.client.GetKubeClient(), labelSelector)
 	if err != nil {
 		return URLList{}, errors.Wrap(err, "unable to list ingress")
 	}
 
+	httpRouteURLs, err := listHTTPRouteURLs(k.client.GetKubeClient(), labelSelector)
+	if err != nil {
+		return URLList{}, errors.Wrap(err, "unable to list HTTPRoutes")
+	}
+
 	var routes []routev1.Route
 	if k.isRouteSupported {
 		routes, err = k.client.ListRoutes(labelSelector)
@@ -36,11 +39,7 @@ func (k kubernetesClient) ListFromCluster() (URLList, error) {
 		}
 	}
 
-	var clusterURLs []URL
-	for _, i := range ingresses {
-		clusterURL := getMachineReadableFormatIngress(i)
-		clusterURLs = append(clusterURLs, clusterURL)
-	}
+	clusterURLs := append(ingressURLs, httpRouteURLs...)
 	for _, r := range routes {
 		// ignore the routes created by ingresses
 		if r.OwnerReferences != nil && r.OwnerReferences[0].Kind == "Ingress" {
@@ -91,10 +90,12 @@ func (k kubernetesClient) List() (URLList, error) {
 	// else mark them as 'StateTypePushed'
 	var urls sortableURLs
 	for URLName, clusterURL := range clusterURLMap {
-		_, found := localMap[URLName]
+		localURL, found := localMap[URLName]
 		if found {
 			// URL is in both local env file and cluster
 			clusterURL.Status.State = StateTypePushed
+			// the probe is only defined in the devfile
+			clusterURL.Spec.Probe = localURL.Spec.Probe
 			urls = append(urls, clusterURL)
 		} else {
 			// URL is on the cluster but not in local env file
@@ -107,7 +108,14 @@ func (k kubernetesClient) List() (URLList, error) {
 	// if not found on the cluster, mark them as 'StateTypeNotPushed'
 	for localName, localURL := range localMap {
 		_, remoteURLFound := clusterURLMap[localName]
-		if !remoteURLFound {
+		if localURL.Spec.Kind == localConfigProvider.PORTFORWARD {
+			// URLs of portforward kind have no object on the cluster
+			localURL.Status.State = StateTypeNotForwarded
+			if isPortForwarded(k.componentName, k.appName, k.localConfig.GetNamespace(), localURL.Spec.ExternalPort) {
+				localURL.Status.State = StateTypeForwarded
+			}
+			urls = append(urls, localURL)
+		} else if !remoteURLFound {
 			// URL is in the local env file but not pushed to cluster
 			localURL.Status.State = StateTypeNotPushed
 			urls = append(urls, localURL)
@@ -168,7 +168,7 @@ func Test_kubernetesClient_ListCluster(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fkclient, fkclientset := kclient.FakeNew()
+			fkclient, fkclientset := kclient.FakeNewWithoutOptionalAPIs()
 			fkclient.Namespace = "default"
 
 			fkclientset.Kubernetes.PrependReactor("list", "ingresses", func(action ktesting.Action) (bool, runtime.Object, error) {
@@ -391,7 +391,7 @@ func Test_kubernetesClient_List(t *testing.T) {
 			mockLocalConfig := localConfigProvider.NewMockLocalConfigProvider(ctrl)
 			mockLocalConfig.EXPECT().ListURLs().Return(tt.returnedLocalURLs, nil)
 
-			fkclient, fkclientset := kclient.FakeNew()
+			fkclient, fkclientset := kclient.FakeNewWithoutOptionalAPIs()
 			fkclient.Namespace = "default"
 
 			fkclientset.Kubernetes.PrependReactor("list", "ingresses", func(action ktesting.Action) (bool, runtime.Object, error) {
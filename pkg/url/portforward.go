@@ -0,0 +1,74 @@
+package url
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift/odo/pkg/debug"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"k8s.io/klog"
+)
+
+// PortForwardInfo records the process forwarding the ports of the URLs of portforward kind of a component
+type PortForwardInfo struct {
+	ProcessID int `json:"processID"`
+	// LocalPorts are the local ports forwarded by the process
+	LocalPorts []int `json:"localPorts"`
+}
+
+// GetPortForwardInfoFilePath returns the path of the file recording the process forwarding the ports of the component
+func GetPortForwardInfoFilePath(componentName, appName, projectName string) string {
+	arr := []string{projectName, appName, componentName, "odo-url-forward.json"}
+	if appName == "" {
+		arr = []string{projectName, componentName, "odo-url-forward.json"}
+	}
+	return filepath.Join(os.TempDir(), strings.Join(arr, "-"))
+}
+
+// WritePortForwardInfo records that the current process forwards the local ports of the component
+func WritePortForwardInfo(componentName, appName, projectName string, localPorts []int) error {
+	data, err := json.Marshal(PortForwardInfo{ProcessID: os.Getpid(), LocalPorts: localPorts})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(GetPortForwardInfoFilePath(componentName, appName, projectName), data, 0600)
+}
+
+// isPortForwarded returns true if the local port of the component is forwarded by a running `odo url forward`
+func isPortForwarded(componentName, appName, projectName string, localPort int) bool {
+	infoFile := GetPortForwardInfoFilePath(componentName, appName, projectName)
+	data, err := ioutil.ReadFile(infoFile)
+	if err != nil {
+		klog.V(4).Infof("the port forwarding info %v is not present", infoFile)
+		return false
+	}
+	var info PortForwardInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		klog.V(4).Infof("couldn't unmarshal the port forwarding info %v", infoFile)
+		return false
+	}
+	for _, port := range info.LocalPorts {
+		if port == localPort {
+			return debug.IsForwardingPort(info.ProcessID, localPort)
+		}
+	}
+	return false
+}
+
+// GetPortForwardPairs returns the port pairs, in the "localPort:remotePort" format, of the URLs of portforward kind
+func GetPortForwardPairs(urls []localConfigProvider.LocalURL) ([]string, []int) {
+	var portPairs []string
+	var localPorts []int
+	for _, url := range urls {
+		if url.Kind != localConfigProvider.PORTFORWARD {
+			continue
+		}
+		portPairs = append(portPairs, fmt.Sprintf("%d:%d", url.ExposedPort, url.Port))
+		localPorts = append(localPorts, url.ExposedPort)
+	}
+	return portPairs, localPorts
+}
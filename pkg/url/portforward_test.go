@@ -0,0 +1,54 @@
+package url
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/localConfigProvider"
+)
+
+func TestGetPortForwardPairs(t *testing.T) {
+	urls := []localConfigProvider.LocalURL{
+		{Name: "web", Port: 8080, ExposedPort: 9090, Kind: localConfigProvider.PORTFORWARD},
+		{Name: "ingress", Port: 8081, Host: "com", Kind: localConfigProvider.INGRESS},
+		{Name: "metrics", Port: 9100, ExposedPort: 9100, Kind: localConfigProvider.PORTFORWARD},
+	}
+
+	portPairs, localPorts := GetPortForwardPairs(urls)
+	if want := []string{"9090:8080", "9100:9100"}; !reflect.DeepEqual(portPairs, want) {
+		t.Errorf("Got port pairs %v, want %v", portPairs, want)
+	}
+	if want := []int{9090, 9100}; !reflect.DeepEqual(localPorts, want) {
+		t.Errorf("Got local ports %v, want %v", localPorts, want)
+	}
+}
+
+func TestIsPortForwarded(t *testing.T) {
+	componentName, appName, projectName := "nodejs", "app", "forward-test"
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	localPort := listener.Addr().(*net.TCPAddr).Port
+
+	if isPortForwarded(componentName, appName, projectName, localPort) {
+		t.Errorf("the port shouldn't be forwarded without port forwarding info")
+	}
+
+	err = WritePortForwardInfo(componentName, appName, projectName, []int{localPort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(GetPortForwardInfoFilePath(componentName, appName, projectName))
+
+	if !isPortForwarded(componentName, appName, projectName, localPort) {
+		t.Errorf("the port %d should be forwarded", localPort)
+	}
+	if isPortForwarded(componentName, appName, projectName, localPort+1) {
+		t.Errorf("the port %d shouldn't be forwarded", localPort+1)
+	}
+}
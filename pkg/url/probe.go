@@ -0,0 +1,295 @@
+package url
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/localConfigProvider"
+)
+
+// ProbeTimeout is how long a single reachability check waits for an answer
+const ProbeTimeout = 3 * time.Second
+
+// grpcHealthCheckPath is the method of the standard gRPC health checking service
+const grpcHealthCheckPath = "/grpc.health.v1.Health/Check"
+
+// grpcServing is the SERVING value of the status of a gRPC health check response
+const grpcServing = 1
+
+// ProbeResult is the result of the reachability check of a URL
+type ProbeResult struct {
+	// Reachable is true if the URL answered as expected by the probe
+	Reachable bool `json:"reachable"`
+	// Probe is the kind of check performed
+	Probe localConfigProvider.URLProbeType `json:"probe"`
+	// LatencyMillis is the time taken by the URL to answer, in milliseconds
+	LatencyMillis int64 `json:"latencyMs,omitempty"`
+	// Message describes the answer, e.g. the HTTP status, or why the URL is unreachable
+	Message string `json:"message,omitempty"`
+}
+
+// ProbeAddress checks the reachability of the address of a URL with the given probe,
+// an HTTP probe accepting any response is used when the probe is nil
+func ProbeAddress(address string, probe *localConfigProvider.URLProbe, timeout time.Duration) ProbeResult {
+	if probe == nil {
+		probe = &localConfigProvider.URLProbe{Type: localConfigProvider.HTTPProbe}
+	}
+	result := ProbeResult{Probe: probe.Type}
+
+	start := time.Now()
+	var err error
+	switch probe.Type {
+	case localConfigProvider.NoProbe:
+		result.Message = "not checked"
+		return result
+	case localConfigProvider.TCPProbe:
+		result.Reachable, result.Message, err = probeTCP(address, timeout)
+	case localConfigProvider.GRPCProbe:
+		result.Reachable, result.Message, err = probeGRPC(address, timeout)
+	default:
+		result.Reachable, result.Message, err = probeHTTP(address, *probe, timeout)
+	}
+	if err != nil {
+		klog.V(4).Infof("%s probe of '%s' failed: %v", probe.Type, address, err)
+		result.Message = err.Error()
+		return result
+	}
+	result.LatencyMillis = time.Since(start).Milliseconds()
+	return result
+}
+
+// ProbeURLs checks concurrently the reachability of the pushed and forwarded URLs of the list
+// and sets their Status.Reachability
+func ProbeURLs(urls *URLList, componentName string, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for i := range urls.Items {
+		u := &urls.Items[i]
+		if u.Status.State != StateTypePushed && u.Status.State != StateTypeForwarded {
+			continue
+		}
+		address, _ := getURLAddress(*u, componentName)
+		if address == "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := ProbeAddress(address, u.Spec.Probe, timeout)
+			u.Status.Reachability = &result
+		}()
+	}
+	wg.Wait()
+}
+
+// getURLAddress returns the address at which the URL is reached, and its protocol
+func getURLAddress(u URL, componentName string) (string, string) {
+	var address, protocol string
+	if u.Spec.Kind == localConfigProvider.PORTFORWARD {
+		protocol = "http"
+		address = GetPortForwardURLString(u)
+	} else if u.Spec.Kind != localConfigProvider.ROUTE {
+		protocol = GetProtocol(routev1.Route{}, ConvertIngressURLToIngress(u, componentName))
+		address = GetURLString(protocol, "", u.Spec.Host, false)
+	} else {
+		protocol = u.Spec.Protocol
+		address = GetURLString(protocol, u.Spec.Host, "", false)
+	}
+	return address, protocol
+}
+
+// insecureTLSConfig is used by the probes; since the content of the responses is not used,
+// the fact that it can be MITM-ed is irrelevant.
+/* #nosec */
+var insecureTLSConfig = &tls.Config{InsecureSkipVerify: true}
+
+// probeHTTP sends a GET request to the address, the response is accepted if its status is expected by the probe
+func probeHTTP(address string, probe localConfigProvider.URLProbe, timeout time.Duration) (bool, string, error) {
+	if probe.Path != "" {
+		parsed, err := neturl.Parse(address)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "invalid URL %s", address)
+		}
+		parsed.Path = probe.Path
+		address = parsed.String()
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: insecureTLSConfig},
+		Timeout:   timeout,
+	}
+	resp, err := client.Get(address)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "Get request failed for %s", address)
+	}
+	defer resp.Body.Close()
+
+	klog.V(4).Infof("Get request succeeded for '%s', with response code %d", address, resp.StatusCode)
+	return isExpectedStatus(resp.StatusCode, probe.ExpectedStatus), fmt.Sprintf("HTTP %d", resp.StatusCode), nil
+}
+
+// isExpectedStatus returns true if the HTTP status code matches one of the expected codes or classes,
+// any status is expected when none is given
+func isExpectedStatus(code int, expected []string) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	status := strconv.Itoa(code)
+	for _, e := range expected {
+		if e == status || (strings.HasSuffix(e, "xx") && e[0] == status[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// probeTCP opens a TCP connection to the host and port of the address
+func probeTCP(address string, timeout time.Duration) (bool, string, error) {
+	hostPort, err := getHostPort(address)
+	if err != nil {
+		return false, "", err
+	}
+	conn, err := net.DialTimeout("tcp", hostPort, timeout)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "unable to connect to %s", hostPort)
+	}
+	conn.Close()
+	return true, "connected", nil
+}
+
+// probeGRPC calls the standard gRPC health checking service at the address, over HTTP/2 with TLS for https
+// addresses and in cleartext otherwise, the URL is reachable if the server reports it is serving
+func probeGRPC(address string, timeout time.Duration) (bool, string, error) {
+	parsed, err := neturl.Parse(address)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "invalid URL %s", address)
+	}
+	transport := &http2.Transport{TLSClientConfig: insecureTLSConfig}
+	if parsed.Scheme != "https" {
+		// gRPC in cleartext uses HTTP/2 with prior knowledge
+		transport.AllowHTTP = true
+		transport.DialTLS = func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return net.DialTimeout(network, addr, timeout)
+		}
+		parsed.Scheme = "http"
+	}
+	parsed.Path = grpcHealthCheckPath
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	// a length-prefixed, uncompressed and empty HealthCheckRequest asks for the status of the whole server
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, parsed.String(), bytes.NewReader([]byte{0, 0, 0, 0, 0}))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "gRPC health check failed for %s", address)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "unable to read the gRPC health check response of %s", address)
+	}
+
+	grpcStatus := resp.Trailer.Get("Grpc-Status")
+	if grpcStatus == "" {
+		// trailers-only responses carry the status in the headers
+		grpcStatus = resp.Header.Get("Grpc-Status")
+	}
+	if grpcStatus == "" {
+		return false, "", errors.Errorf("%s did not answer with a gRPC response (HTTP %d)", address, resp.StatusCode)
+	}
+	if grpcStatus != "0" {
+		message := resp.Trailer.Get("Grpc-Message")
+		if message == "" {
+			message = resp.Header.Get("Grpc-Message")
+		}
+		return false, fmt.Sprintf("gRPC status %s %s", grpcStatus, message), nil
+	}
+
+	servingStatus := parseHealthCheckResponse(body)
+	switch servingStatus {
+	case grpcServing:
+		return true, "SERVING", nil
+	case 2:
+		return false, "NOT_SERVING", nil
+	default:
+		return false, fmt.Sprintf("serving status %d", servingStatus), nil
+	}
+}
+
+// parseHealthCheckResponse returns the status field of the length-prefixed HealthCheckResponse message,
+// or 0 (UNKNOWN) if it can't be decoded
+func parseHealthCheckResponse(body []byte) uint64 {
+	// 1 byte compression flag and 4 bytes message length
+	if len(body) < 5 || body[0] != 0 {
+		return 0
+	}
+	message := body[5:]
+	for len(message) > 0 {
+		key, n := decodeVarint(message)
+		if n == 0 {
+			return 0
+		}
+		message = message[n:]
+		if key&0x7 != 0 {
+			// the response holds a single varint field
+			return 0
+		}
+		value, n := decodeVarint(message)
+		if n == 0 {
+			return 0
+		}
+		message = message[n:]
+		if key>>3 == 1 {
+			return value
+		}
+	}
+	return 0
+}
+
+// decodeVarint decodes a protobuf varint, returning the value and the number of bytes read, 0 on error
+func decodeVarint(buf []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < len(buf) && i < 10; i++ {
+		value |= uint64(buf[i]&0x7f) << (7 * uint(i))
+		if buf[i] < 0x80 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// getHostPort returns the host:port of the address, using the default port of its scheme if it has none
+func getHostPort(address string) (string, error) {
+	parsed, err := neturl.Parse(address)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid URL %s", address)
+	}
+	if parsed.Port() != "" {
+		return parsed.Host, nil
+	}
+	port := "80"
+	if parsed.Scheme == "https" || parsed.Scheme == "wss" {
+		port = "443"
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), nil
+}
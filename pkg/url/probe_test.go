@@ -0,0 +1,174 @@
+package url
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/localConfigProvider"
+)
+
+func TestProbeAddressHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		probe         *localConfigProvider.URLProbe
+		wantReachable bool
+		wantMessage   string
+	}{
+		{
+			name:          "Case 1: Default probe accepts any response",
+			wantReachable: true,
+			wantMessage:   "HTTP 503",
+		},
+		{
+			name:          "Case 2: Unexpected status code",
+			probe:         &localConfigProvider.URLProbe{Type: localConfigProvider.HTTPProbe, ExpectedStatus: []string{"2xx"}},
+			wantReachable: false,
+			wantMessage:   "HTTP 503",
+		},
+		{
+			name:          "Case 3: Expected status code on the probe path",
+			probe:         &localConfigProvider.URLProbe{Type: localConfigProvider.HTTPProbe, Path: "/health", ExpectedStatus: []string{"200"}},
+			wantReachable: true,
+			wantMessage:   "HTTP 200",
+		},
+		{
+			name:          "Case 4: URL not checked",
+			probe:         &localConfigProvider.URLProbe{Type: localConfigProvider.NoProbe},
+			wantReachable: false,
+			wantMessage:   "not checked",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProbeAddress(server.URL, tt.probe, ProbeTimeout)
+			if got.Reachable != tt.wantReachable || got.Message != tt.wantMessage {
+				t.Errorf("Got %+v, want reachable %v with message %q", got, tt.wantReachable, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestProbeAddressTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := "http://" + listener.Addr().String()
+	probe := &localConfigProvider.URLProbe{Type: localConfigProvider.TCPProbe}
+
+	if got := ProbeAddress(address, probe, ProbeTimeout); !got.Reachable {
+		t.Errorf("Got %+v, want a reachable URL", got)
+	}
+
+	listener.Close()
+	if got := ProbeAddress(address, probe, ProbeTimeout); got.Reachable {
+		t.Errorf("Got %+v, want an unreachable URL", got)
+	}
+}
+
+func TestProbeAddressGRPC(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		wantReachable bool
+		wantMessage   string
+	}{
+		{
+			name: "Case 1: Serving",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/grpc")
+				w.Header().Set("Trailer", "Grpc-Status")
+				// HealthCheckResponse{status: SERVING}
+				_, _ = w.Write([]byte{0, 0, 0, 0, 2, 0x08, 0x01})
+				w.Header().Set("Grpc-Status", "0")
+			},
+			wantReachable: true,
+			wantMessage:   "SERVING",
+		},
+		{
+			name: "Case 2: Not serving",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/grpc")
+				w.Header().Set("Trailer", "Grpc-Status")
+				_, _ = w.Write([]byte{0, 0, 0, 0, 2, 0x08, 0x02})
+				w.Header().Set("Grpc-Status", "0")
+			},
+			wantReachable: false,
+			wantMessage:   "NOT_SERVING",
+		},
+		{
+			name: "Case 3: Health service not implemented",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/grpc")
+				w.Header().Set("Grpc-Status", "12")
+				w.Header().Set("Grpc-Message", "unknown service")
+			},
+			wantReachable: false,
+			wantMessage:   "gRPC status 12 unknown service",
+		},
+		{
+			name: "Case 4: Not a gRPC server",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantReachable: false,
+			wantMessage:   "did not answer with a gRPC response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != grpcHealthCheckPath || r.ProtoMajor != 2 {
+					t.Errorf("unexpected request %s %s", r.Proto, r.URL.Path)
+				}
+				tt.handler(w, r)
+			}))
+			server.EnableHTTP2 = true
+			server.StartTLS()
+			defer server.Close()
+
+			got := ProbeAddress(server.URL, &localConfigProvider.URLProbe{Type: localConfigProvider.GRPCProbe}, ProbeTimeout)
+			if got.Reachable != tt.wantReachable || !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("Got %+v, want reachable %v with message %q", got, tt.wantReachable, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestProbeURLs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	urls := URLList{
+		Items: []URL{
+			{Spec: URLSpec{Kind: localConfigProvider.PORTFORWARD, ExternalPort: port}, Status: URLStatus{State: StateTypeForwarded}},
+			{Spec: URLSpec{Kind: localConfigProvider.PORTFORWARD, ExternalPort: port}, Status: URLStatus{State: StateTypeNotForwarded}},
+		},
+	}
+	ProbeURLs(&urls, "nodejs", time.Second)
+
+	if urls.Items[0].Status.Reachability == nil || !urls.Items[0].Status.Reachability.Reachable {
+		t.Errorf("Got %+v, want a reachable forwarded URL", urls.Items[0].Status.Reachability)
+	}
+	if urls.Items[1].Status.Reachability != nil {
+		t.Errorf("Got %+v, the URLs which are not forwarded should not be checked", urls.Items[1].Status.Reachability)
+	}
+}
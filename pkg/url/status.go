@@ -1,18 +1,14 @@
 package url
 
 import (
-	"crypto/tls"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/openshift/odo/pkg/localConfigProvider"
 
-	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/occlient"
-	"github.com/pkg/errors"
 
 	"k8s.io/klog"
 )
@@ -94,20 +90,12 @@ func getURLsForKubernetes(oclient *occlient.Client, client *kclient.Client, loca
 
 	for _, u := range urls.Items {
 
-		// Ignore unpushed URLs, they necessarily are unreachable
-		if u.Status.State != StateTypePushed && ignoreUnpushed {
+		// Ignore unpushed and unforwarded URLs, they necessarily are unreachable
+		if u.Status.State != StateTypePushed && u.Status.State != StateTypeForwarded && ignoreUnpushed {
 			continue
 		}
 
-		var properURL, protocol string
-
-		if u.Spec.Kind != localConfigProvider.ROUTE {
-			protocol = GetProtocol(routev1.Route{}, ConvertIngressURLToIngress(u, componentName))
-			properURL = GetURLString(protocol, "", u.Spec.Host, false)
-		} else {
-			protocol = u.Spec.Protocol
-			properURL = GetURLString(protocol, u.Spec.Host, "", false)
-		}
+		properURL, protocol := getURLAddress(u, componentName)
 
 		statusURLVal := statusURL{
 			name:   u.Name,
@@ -115,6 +103,7 @@ func getURLsForKubernetes(oclient *occlient.Client, client *kclient.Client, loca
 			kind:   string(u.Spec.Kind),
 			port:   u.Spec.Port,
 			secure: protocol == "https",
+			probe:  u.Spec.Probe,
 		}
 
 		urlList = append(urlList, statusURLVal)
@@ -130,6 +119,7 @@ type statusURL struct {
 	port   int
 	secure bool
 	kind   string
+	probe  *localConfigProvider.URLProbe
 }
 
 // startURLTestGoRoutine tests one or more urls ('urls' param); if at least one of them is successful, a success is reported.
@@ -144,18 +134,17 @@ func startURLTestGoRoutine(urls []statusURL, delayBetweenRequests time.Duration,
 		for {
 
 			successfulMatch := (*statusURL)(nil)
+			successfulResult := ProbeResult{}
 			atLeastOneSuccess := false
+			results := make([]ProbeResult, len(urls))
 
-			for _, currURL := range urls {
-				anyResponseReceived, err := testURL(currURL.url)
-
-				if err != nil {
-					klog.V(4).Infof("Error on connecting to URL '%s' %v", currURL.url, err)
-				}
+			for i, currURL := range urls {
+				results[i] = ProbeAddress(currURL.url, currURL.probe, ProbeTimeout)
 
-				if anyResponseReceived {
+				if results[i].Reachable {
 					match := currURL
 					successfulMatch = &match
+					successfulResult = results[i]
 					atLeastOneSuccess = true
 					break
 				}
@@ -166,11 +155,11 @@ func startURLTestGoRoutine(urls []statusURL, delayBetweenRequests time.Duration,
 
 				if successfulMatch != nil {
 					// At least one of the URLs was reachable, so report success for it
-					loggingClient.URLReachable((*successfulMatch).name, (*successfulMatch).url, (*successfulMatch).port, (*successfulMatch).secure, (*successfulMatch).kind, true, machineoutput.TimestampNow())
+					loggingClient.URLReachable((*successfulMatch).name, (*successfulMatch).url, (*successfulMatch).port, (*successfulMatch).secure, (*successfulMatch).kind, true, string(successfulResult.Probe), successfulResult.LatencyMillis, machineoutput.TimestampNow())
 				} else {
 					// Otherwise report failure for all URLs
-					for _, currURL := range urls {
-						loggingClient.URLReachable(currURL.name, currURL.url, currURL.port, currURL.secure, currURL.kind, atLeastOneSuccess, machineoutput.TimestampNow())
+					for i, currURL := range urls {
+						loggingClient.URLReachable(currURL.name, currURL.url, currURL.port, currURL.secure, currURL.kind, atLeastOneSuccess, string(results[i].Probe), results[i].LatencyMillis, machineoutput.TimestampNow())
 					}
 				}
 			}
@@ -181,34 +170,3 @@ func startURLTestGoRoutine(urls []statusURL, delayBetweenRequests time.Duration,
 		}
 	}()
 }
-
-// testURL tests a single URL, returning true if ANY response was received, or false otherwise (plus an error if applicable)
-func testURL(url string) (bool, error) {
-
-	// Suppress 'G402 (CWE-295): TLS InsecureSkipVerify set true': since we are not using the contents of the HTTP response,
-	// the fact that it can be MITM-ed is irrelevant.
-	/* #nosec */
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	client := &http.Client{Transport: tr}
-
-	resp, err := client.Get(url)
-	if err != nil || resp == nil {
-		errMsg := "Get request failed for " + url + " , with no response code."
-		if err != nil {
-			return false, errors.Wrapf(err, errMsg)
-		}
-		return false, errors.New(errMsg)
-	}
-
-	defer resp.Body.Close()
-
-	// Any response code (including 5XX, 4XX) is considered a success; we only use this to determine if the process
-	// is responding to requests.
-	klog.V(4).Infof("Get request succeeded for '%s', with response code %d", url, resp.StatusCode)
-
-	return true, nil
-
-}
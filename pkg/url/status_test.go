@@ -12,11 +12,13 @@ import (
 	"github.com/openshift/odo/pkg/testingutil"
 
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kclient_fake "github.com/openshift/odo/pkg/kclient/fake"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery/fake"
 	ktesting "k8s.io/client-go/testing"
 )
@@ -25,6 +27,16 @@ type fakeDiscovery struct {
 	*fake.FakeDiscovery
 }
 
+// ServerResourcesForGroupVersion returns a NotFound error like the API server for the group versions it doesn't serve
+func (f *fakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	for _, resourceList := range f.Resources {
+		if resourceList.GroupVersion == groupVersion {
+			return resourceList, nil
+		}
+	}
+	return nil, kerrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
 var fakeDiscoveryWithProject = &fakeDiscovery{
 	FakeDiscovery: &fake.FakeDiscovery{
 		Fake: &ktesting.Fake{
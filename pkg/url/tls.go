@@ -0,0 +1,111 @@
+package url
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	componentlabels "github.com/openshift/odo/pkg/component/labels"
+	"github.com/openshift/odo/pkg/devca"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/util"
+)
+
+// defaultTLSSecretName returns the name of the TLS secret shared by the secure URLs of the component
+// which don't use their own secret
+func defaultTLSSecretName(componentName string) string {
+	return componentName + "-tlssecret"
+}
+
+// ensureDefaultTLSSecret makes sure the default TLS secret of the component holds a certificate for all the hosts of
+// its secure URLs, signed by the local development CA, creating it if it doesn't exist and renewing it before it expires
+// or when a host is missing, certificates not generated by odo are never replaced
+func ensureDefaultTLSSecret(kClient *kclient.Client, componentName, applicationName string, hosts []string, ownerReference metav1.OwnerReference) (string, error) {
+	defaultTLSSecretName := defaultTLSSecretName(componentName)
+	secret, err := kClient.KubeClient.CoreV1().Secrets(kClient.Namespace).Get(context.TODO(), defaultTLSSecretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		// the client returns an empty secret along with the not found error
+		secret = nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if secret != nil && !devca.IsManaged(secret.Data["tls.crt"]) {
+		// tls secret provided by the user for this component
+		return defaultTLSSecretName, nil
+	}
+
+	ca, err := loadDevCA()
+	if err != nil {
+		return "", err
+	}
+
+	if secret != nil && !ca.NeedsRenewal(secret.Data["tls.crt"], hosts...) {
+		return defaultTLSSecretName, nil
+	}
+
+	certPem, keyPem, err := ca.IssueCertificate(hosts...)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to generate certificate for cluster: "+strings.Join(hosts, ", "))
+	}
+
+	if secret != nil {
+		klog.V(4).Infof("renewing the certificate of the tls secret %s", defaultTLSSecretName)
+		if _, err = kClient.UpdateTLSSecret(secret, certPem, keyPem); err != nil {
+			return "", errors.Wrap(err, "unable to renew tls secret")
+		}
+		return defaultTLSSecretName, nil
+	}
+
+	// create tls secret
+	objectMeta := metav1.ObjectMeta{
+		Name:   defaultTLSSecretName,
+		Labels: componentlabels.GetLabels(componentName, applicationName, true),
+		OwnerReferences: []metav1.OwnerReference{
+			ownerReference,
+		},
+	}
+	created, err := kClient.CreateTLSSecret(certPem, keyPem, objectMeta)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create tls secret")
+	}
+	return created.Name, nil
+}
+
+// defaultTLSHosts returns the sorted hosts of the secure ingress URLs using the default TLS secret of the component
+func defaultTLSHosts(urls map[string]URL) []string {
+	var hosts []string
+	for _, url := range urls {
+		if url.Spec.Kind != localConfigProvider.INGRESS || !url.Spec.Secure || url.Spec.TLSSecret != "" || url.Spec.Host == "" {
+			continue
+		}
+		if !util.In(hosts, url.Spec.Host) {
+			hosts = append(hosts, url.Spec.Host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// loadDevCA loads the local development CA, printing the instructions to trust it when it is generated
+func loadDevCA() (*devca.CA, error) {
+	dir, err := devca.GetDir()
+	if err != nil {
+		return nil, err
+	}
+	ca, created, err := devca.LoadOrCreate(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load the local development CA")
+	}
+	if created {
+		log.Info(devca.TrustInstructions(ca.CertFile))
+	}
+	return ca, nil
+}
@@ -0,0 +1,244 @@
+package url
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/occlient"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/testingutil"
+)
+
+// setTempConfigDir points the odo configuration directory, holding the local development CA, to a temporary directory
+func setTempConfigDir(t *testing.T) func() {
+	configDir, err := ioutil.TempDir("", "odourl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(preference.GlobalConfigEnvName, filepath.Join(configDir, "preference.yaml"))
+	return func() {
+		os.Unsetenv(preference.GlobalConfigEnvName)
+		os.RemoveAll(configDir)
+	}
+}
+
+func TestEnsureDefaultTLSSecret(t *testing.T) {
+	defer setTempConfigDir(t)()
+
+	host := "1.2.3.4.nip.io"
+	ca, err := loadDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validCert, _, err := ca.IssueCertificate(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherHostCert, _, err := ca.IssueCertificate("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyCert, err := kclient.GenerateSelfSignedCertificate(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		existingCert []byte
+		secretExists bool
+		// emptySecret returns an empty secret along with the not found error, as the client of a cluster does
+		emptySecret   bool
+		wantOperation string
+	}{
+		{
+			name:          "Case 1: Secret doesn't exist",
+			wantOperation: "create",
+		},
+		{
+			name:         "Case 2: Secret provided by the user",
+			existingCert: []byte("not generated by odo"),
+			secretExists: true,
+		},
+		{
+			name:         "Case 3: Secret signed by the local development CA",
+			existingCert: validCert,
+			secretExists: true,
+		},
+		{
+			name:          "Case 4: Secret signed by the local development CA for another host",
+			existingCert:  otherHostCert,
+			secretExists:  true,
+			wantOperation: "update",
+		},
+		{
+			name:          "Case 5: Self-signed secret created by a previous odo version",
+			existingCert:  legacyCert.CertPem,
+			secretExists:  true,
+			wantOperation: "update",
+		},
+		{
+			name:          "Case 6: Secret doesn't exist, an empty secret is returned with the error",
+			emptySecret:   true,
+			wantOperation: "create",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeKClient, fakeKClientSet := kclient.FakeNew()
+
+			fakeKClientSet.Kubernetes.PrependReactor("get", "secrets", func(action ktesting.Action) (bool, runtime.Object, error) {
+				if !tt.secretExists {
+					if tt.emptySecret {
+						return true, &v1.Secret{}, kerrors.NewNotFound(schema.GroupResource{}, "")
+					}
+					return true, nil, kerrors.NewNotFound(schema.GroupResource{}, "")
+				}
+				return true, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "nodejs-tlssecret"},
+					Data:       map[string][]byte{"tls.crt": tt.existingCert},
+				}, nil
+			})
+			var gotCert []byte
+			returnSecret := func(action ktesting.Action) (bool, runtime.Object, error) {
+				secret := action.(ktesting.CreateAction).GetObject().(*v1.Secret)
+				gotCert = secret.Data["tls.crt"]
+				return true, secret, nil
+			}
+			fakeKClientSet.Kubernetes.PrependReactor("create", "secrets", returnSecret)
+			fakeKClientSet.Kubernetes.PrependReactor("update", "secrets", returnSecret)
+
+			got, err := ensureDefaultTLSSecret(fakeKClient, "nodejs", "app", []string{host}, metav1.OwnerReference{})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got != "nodejs-tlssecret" {
+				t.Errorf("Got secret %s, want nodejs-tlssecret", got)
+			}
+
+			actions := fakeKClientSet.Kubernetes.Actions()
+			gotOperation := ""
+			if len(actions) > 1 {
+				gotOperation = actions[1].GetVerb()
+			}
+			if gotOperation != tt.wantOperation {
+				t.Errorf("Got operation %q, want %q", gotOperation, tt.wantOperation)
+			}
+			if tt.wantOperation != "" && ca.NeedsRenewal(gotCert, host) {
+				t.Errorf("the certificate of the secret is not signed by the local development CA for %s", host)
+			}
+		})
+	}
+}
+
+func TestPushRenewsDefaultTLSSecret(t *testing.T) {
+	defer setTempConfigDir(t)()
+
+	ca, err := loadDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oneHostCert, _, err := ca.IssueCertificate("one.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bothHostsCert, _, err := ca.IssueCertificate("one.example.com", "two.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localURLs := []localConfigProvider.LocalURL{
+		{Name: "api", Host: "one.example.com", Port: 8080, Path: "/", Secure: true, Kind: localConfigProvider.INGRESS},
+		{Name: "web", Host: "two.example.com", Port: 9090, Path: "/", Secure: true, Kind: localConfigProvider.INGRESS},
+	}
+	var clusterURLs []URL
+	for _, localURL := range localURLs {
+		clusterURLs = append(clusterURLs, URL{
+			ObjectMeta: metav1.ObjectMeta{Name: localURL.Name},
+			Spec: URLSpec{
+				Host:      localURL.Name + "." + localURL.Host,
+				Port:      localURL.Port,
+				Path:      localURL.Path,
+				Secure:    true,
+				TLSSecret: "nodejs-tlssecret",
+				Kind:      localConfigProvider.INGRESS,
+			},
+		})
+	}
+
+	tests := []struct {
+		name         string
+		existingCert []byte
+		wantRenewed  bool
+	}{
+		{
+			name:         "Case 1: Certificate missing the host of a secure URL",
+			existingCert: oneHostCert,
+			wantRenewed:  true,
+		},
+		{
+			name:         "Case 2: Certificate for all the secure URLs",
+			existingCert: bothHostsCert,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLocalConfigProvider := localConfigProvider.NewMockLocalConfigProvider(ctrl)
+			mockLocalConfigProvider.EXPECT().GetName().Return("nodejs").AnyTimes()
+			mockLocalConfigProvider.EXPECT().GetApplication().Return("app").AnyTimes()
+			mockLocalConfigProvider.EXPECT().ListURLs().Return(localURLs, nil)
+			mockURLClient := NewMockClient(ctrl)
+			mockURLClient.EXPECT().ListFromCluster().Return(getMachineReadableFormatForList(clusterURLs), nil)
+
+			fakeClient, _ := occlient.FakeNew()
+			fakeKClient, fakeKClientSet := kclient.FakeNew()
+			fakeClient.SetKubeClient(fakeKClient)
+			fakeKClientSet.Kubernetes.PrependReactor("get", "deployments", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, testingutil.CreateFakeDeployment("nodejs"), nil
+			})
+			fakeKClientSet.Kubernetes.PrependReactor("get", "secrets", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "nodejs-tlssecret"},
+					Data:       map[string][]byte{"tls.crt": tt.existingCert},
+				}, nil
+			})
+			var renewedCert []byte
+			fakeKClientSet.Kubernetes.PrependReactor("update", "secrets", func(action ktesting.Action) (bool, runtime.Object, error) {
+				secret := action.(ktesting.UpdateAction).GetObject().(*v1.Secret)
+				renewedCert = secret.Data["tls.crt"]
+				return true, secret, nil
+			})
+
+			err := Push(fakeClient, PushParameters{LocalConfig: mockLocalConfigProvider, URLClient: mockURLClient, IsRouteSupported: true})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			for _, action := range fakeKClientSet.Kubernetes.Actions() {
+				if action.GetVerb() == "delete" || (action.GetVerb() == "create" && action.GetResource().Resource == "ingresses") {
+					t.Errorf("the ingresses should be kept, got the action %s %s", action.GetVerb(), action.GetResource().Resource)
+				}
+			}
+			if (renewedCert != nil) != tt.wantRenewed {
+				t.Fatalf("got the secret renewed: %v, want %v", renewedCert != nil, tt.wantRenewed)
+			}
+			if tt.wantRenewed && ca.NeedsRenewal(renewedCert, "api.one.example.com", "web.two.example.com") {
+				t.Errorf("the renewed certificate is not valid for all the secure URLs")
+			}
+		})
+	}
+}
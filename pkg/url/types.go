@@ -15,14 +15,17 @@ type URL struct {
 
 // URLSpec is
 type URLSpec struct {
-	Host         string                      `json:"host,omitempty"`
-	Protocol     string                      `json:"protocol,omitempty"`
-	Port         int                         `json:"port,omitempty"`
-	Secure       bool                        `json:"secure"`
-	Kind         localConfigProvider.URLKind `json:"kind,omitempty"`
-	TLSSecret    string                      `json:"tlssecret,omitempty"`
-	ExternalPort int                         `json:"externalport,omitempty"`
-	Path         string                      `json:"path,omitempty"`
+	Host         string                        `json:"host,omitempty"`
+	Protocol     string                        `json:"protocol,omitempty"`
+	Port         int                           `json:"port,omitempty"`
+	Secure       bool                          `json:"secure"`
+	Kind         localConfigProvider.URLKind   `json:"kind,omitempty"`
+	TLSSecret    string                        `json:"tlssecret,omitempty"`
+	ExternalPort int                           `json:"externalport,omitempty"`
+	Path         string                        `json:"path,omitempty"`
+	IngressClass string                        `json:"ingressClass,omitempty"`
+	Gateway      string                        `json:"gateway,omitempty"`
+	Probe        *localConfigProvider.URLProbe `json:"probe,omitempty"`
 }
 
 // URLList is a list of applications
@@ -36,6 +39,8 @@ type URLList struct {
 type URLStatus struct {
 	// "Pushed" or "Not Pushed" or "Locally Delted"
 	State StateType `json:"state"`
+	// Reachability is the result of the last reachability check of the URL
+	Reachability *ProbeResult `json:"reachability,omitempty"`
 }
 
 type StateType string
@@ -47,4 +52,8 @@ const (
 	StateTypeNotPushed = "Not Pushed"
 	// StateTypeLocallyDeleted means that URL was deleted from the local config, but it is still present on the cluster/container
 	StateTypeLocallyDeleted = "Locally Deleted"
+	// StateTypeForwarded means that the port of the URL of portforward kind is forwarded by `odo url forward`
+	StateTypeForwarded = "Forwarded"
+	// StateTypeNotForwarded means that the port of the URL of portforward kind isn't forwarded
+	StateTypeNotForwarded = "Not Forwarded"
 )
@@ -20,17 +20,19 @@ import (
 	urlLabels "github.com/openshift/odo/pkg/url/labels"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/pkg/errors"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
 	iextensionsv1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog"
 )
 
 const apiVersion = "odo.dev/v1alpha1"
 
+// ingressClassAnnotation selects the ingress controller of extensions/v1beta1 ingresses
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
 // Get returns URL definition for given URL name
 func (urls URLList) Get(urlName string) URL {
 	for _, url := range urls.Items {
@@ -45,7 +47,16 @@ func (urls URLList) Get(urlName string) URL {
 // Delete deletes a URL
 func Delete(client *occlient.Client, kClient *kclient.Client, urlName string, applicationName string, urlType localConfigProvider.URLKind, isS2i bool) error {
 	if urlType == localConfigProvider.INGRESS {
+		networkingV1, err := kClient.IsNetworkingV1IngressSupported()
+		if err != nil {
+			return err
+		}
+		if networkingV1 {
+			return kClient.DeleteIngressV1(urlName)
+		}
 		return kClient.DeleteIngress(urlName)
+	} else if urlType == localConfigProvider.HTTPROUTE {
+		return kClient.DeleteHTTPRoute(urlName)
 	} else if urlType == localConfigProvider.ROUTE {
 		if isS2i {
 			// Namespace the URL name
@@ -71,13 +82,18 @@ type CreateParameters struct {
 	secretName      string
 	urlKind         localConfigProvider.URLKind
 	path            string
+	ingressClass    string
+	gateway         string
+	// tlsHosts are the hosts of all the secure URLs of the component sharing the default TLS secret, which
+	// holds a single certificate for all of them
+	tlsHosts []string
 }
 
 // Create creates a URL and returns url string and error if any
 // portNumber is the target port number for the route and is -1 in case no port number is specified in which case it is automatically detected for components which expose only one service port)
 func Create(client *occlient.Client, kClient *kclient.Client, parameters CreateParameters, isRouteSupported bool, isS2I bool) (string, error) {
 
-	if parameters.urlKind != localConfigProvider.INGRESS && parameters.urlKind != localConfigProvider.ROUTE {
+	if parameters.urlKind != localConfigProvider.INGRESS && parameters.urlKind != localConfigProvider.ROUTE && parameters.urlKind != localConfigProvider.HTTPROUTE {
 		return "", fmt.Errorf("urlKind %s is not supported for URL creation", parameters.urlKind)
 	}
 
@@ -89,6 +105,10 @@ func Create(client *occlient.Client, kClient *kclient.Client, parameters CreateP
 
 	serviceName := ""
 
+	if !isS2I && parameters.urlKind == localConfigProvider.HTTPROUTE && kClient != nil {
+		return createHTTPRoute(kClient, parameters, labels)
+	}
+
 	if !isS2I && parameters.urlKind == localConfigProvider.INGRESS && kClient != nil {
 		if parameters.host == "" {
 			return "", errors.Errorf("the host cannot be empty")
@@ -108,34 +128,15 @@ func Create(client *occlient.Client, kClient *kclient.Client, parameters CreateP
 				}
 			}
 			if len(parameters.secretName) == 0 {
-				defaultTLSSecretName := parameters.componentName + "-tlssecret"
-				_, err := kClient.KubeClient.CoreV1().Secrets(kClient.Namespace).Get(context.TODO(), defaultTLSSecretName, metav1.GetOptions{})
-				// create tls secret if it does not exist
-				if kerrors.IsNotFound(err) {
-					selfsignedcert, err := kclient.GenerateSelfSignedCertificate(parameters.host)
-					if err != nil {
-						return "", errors.Wrap(err, "unable to generate self-signed certificate for clutser: "+parameters.host)
-					}
-					// create tls secret
-					secretlabels := componentlabels.GetLabels(parameters.componentName, parameters.applicationName, true)
-					objectMeta := metav1.ObjectMeta{
-						Name:   defaultTLSSecretName,
-						Labels: secretlabels,
-						OwnerReferences: []v1.OwnerReference{
-							ownerReference,
-						},
-					}
-					secret, err := kClient.CreateTLSSecret(selfsignedcert.CertPem, selfsignedcert.KeyPem, objectMeta)
-					if err != nil {
-						return "", errors.Wrap(err, "unable to create tls secret")
-					}
-					parameters.secretName = secret.Name
-				} else if err != nil {
+				hosts := parameters.tlsHosts
+				if !util.In(hosts, parameters.host) {
+					hosts = append(hosts, parameters.host)
+				}
+				secretName, err := ensureDefaultTLSSecret(kClient, parameters.componentName, parameters.applicationName, hosts, ownerReference)
+				if err != nil {
 					return "", err
-				} else {
-					// tls secret found for this component
-					parameters.secretName = defaultTLSSecretName
 				}
+				parameters.secretName = secretName
 
 			}
 
@@ -156,7 +157,28 @@ func Create(client *occlient.Client, kClient *kclient.Client, parameters CreateP
 				Path:          parameters.path,
 			},
 		}
+
+		networkingV1, err := kClient.IsNetworkingV1IngressSupported()
+		if err != nil {
+			return "", err
+		}
+		if networkingV1 {
+			ingress := generateIngressV1(ingressParam, parameters.ingressClass)
+			i, err := kClient.CreateIngressV1(ingress)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to create ingress")
+			}
+			return GetURLString(getIngressV1Protocol(*i), "", ingressDomain, false), nil
+		}
+
 		ingress := generator.GetIngress(ingressParam)
+		if parameters.ingressClass != "" {
+			// extensions/v1beta1 ingresses select their controller with an annotation
+			if ingress.Annotations == nil {
+				ingress.Annotations = make(map[string]string)
+			}
+			ingress.Annotations[ingressClassAnnotation] = parameters.ingressClass
+		}
 		// Pass in the namespace name, link to the service (componentName) and labels to create a ingress
 		i, err := kClient.CreateIngress(*ingress)
 		if err != nil {
@@ -243,25 +265,69 @@ func ListPushed(client *occlient.Client, componentName string, applicationName s
 
 }
 
-// ListPushedIngress lists the ingress URLs on cluster for the given component
+// ListPushedIngress lists the ingress and HTTPRoute URLs on cluster for the given component
 func ListPushedIngress(client *kclient.Client, componentName string) (URLList, error) {
 	labelSelector := fmt.Sprintf("%v=%v", componentlabels.ComponentLabel, componentName)
-	klog.V(4).Infof("Listing ingresses with label selector: %v", labelSelector)
-	ingresses, err := client.ListIngresses(labelSelector)
+	urls, err := listIngressURLs(client, labelSelector)
 	if err != nil {
 		return URLList{}, errors.Wrap(err, "unable to list ingress names")
 	}
 
-	var urls []URL
-	for _, i := range ingresses {
-		a := getMachineReadableFormatIngress(i)
-		urls = append(urls, a)
+	routeURLs, err := listHTTPRouteURLs(client, labelSelector)
+	if err != nil {
+		return URLList{}, errors.Wrap(err, "unable to list HTTPRoute names")
 	}
+	urls = append(urls, routeURLs...)
 
 	urlList := getMachineReadableFormatForList(urls)
 	return urlList, nil
 }
 
+// listIngressURLs lists the ingress URLs matching the label selector from the ingress API served by the cluster
+func listIngressURLs(client *kclient.Client, labelSelector string) ([]URL, error) {
+	klog.V(4).Infof("Listing ingresses with label selector: %v", labelSelector)
+	networkingV1, err := client.IsNetworkingV1IngressSupported()
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []URL
+	if networkingV1 {
+		ingresses, err := client.ListIngressesV1(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range ingresses {
+			urls = append(urls, getMachineReadableFormatIngressV1(i))
+		}
+		return urls, nil
+	}
+
+	ingresses, err := client.ListIngresses(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range ingresses {
+		urls = append(urls, getMachineReadableFormatIngress(i))
+	}
+	return urls, nil
+}
+
+// listHTTPRouteURLs lists the HTTPRoute URLs matching the label selector
+func listHTTPRouteURLs(client *kclient.Client, labelSelector string) ([]URL, error) {
+	klog.V(4).Infof("Listing HTTPRoutes with label selector: %v", labelSelector)
+	routes, err := client.ListHTTPRoutes(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []URL
+	for _, r := range routes {
+		urls = append(urls, getMachineReadableFormatHTTPRoute(r))
+	}
+	return urls, nil
+}
+
 type sortableURLs []URL
 
 func (s sortableURLs) Len() int {
@@ -329,8 +395,16 @@ func ConvertEnvinfoURL(envinfoURL localConfigProvider.LocalURL, serviceName stri
 			Kind:      kind,
 			TLSSecret: envinfoURL.TLSSecret,
 			Path:      envinfoURL.Path,
+
+			ExternalPort: envinfoURL.ExposedPort,
+			IngressClass: envinfoURL.IngressClass,
+			Gateway:      envinfoURL.Gateway,
+			Probe:        envinfoURL.Probe,
 		},
 	}
+	if kind == localConfigProvider.HTTPROUTE {
+		url.Spec.Host = hostString
+	}
 	if kind == localConfigProvider.INGRESS {
 		url.Spec.Host = hostString
 		if envinfoURL.Secure && len(envinfoURL.TLSSecret) > 0 {
@@ -360,10 +434,20 @@ func ConvertLocalURL(localURL localConfigProvider.LocalURL) URL {
 			Kind:      localURL.Kind,
 			TLSSecret: localURL.TLSSecret,
 			Path:      localURL.Path,
+
+			ExternalPort: localURL.ExposedPort,
+			IngressClass: localURL.IngressClass,
+			Gateway:      localURL.Gateway,
+			Probe:        localURL.Probe,
 		},
 	}
 }
 
+// GetPortForwardURLString returns the localhost address of an URL of portforward kind
+func GetPortForwardURLString(url URL) string {
+	return fmt.Sprintf("http://localhost:%d%s", url.Spec.ExternalPort, url.Spec.Path)
+}
+
 // GetURLString returns a string representation of given url
 func GetURLString(protocol, URL, ingressDomain string, isS2I bool) string {
 	if protocol == "" && URL == "" && ingressDomain == "" {
@@ -444,6 +528,7 @@ func getMachineReadableFormatIngress(i iextensionsv1.Ingress) URL {
 	if i.Spec.TLS != nil {
 		url.Spec.TLSSecret = i.Spec.TLS[0].SecretName
 	}
+	url.Spec.IngressClass = i.Annotations[ingressClassAnnotation]
 	return url
 
 }
@@ -496,6 +581,90 @@ func ConvertIngressURLToIngress(ingressURL URL, serviceName string) iextensionsv
 	return ingress
 }
 
+// generateIngressV1 generates a networking.k8s.io/v1 ingress from the same parameters as the extensions/v1beta1 one
+func generateIngressV1(ingressParams generator.IngressParams, ingressClass string) networkingv1.Ingress {
+	path := "/"
+	if ingressParams.IngressSpecParams.Path != "" {
+		path = ingressParams.IngressSpecParams.Path
+	}
+	// the paths of extensions/v1beta1 ingresses are implementation specific
+	pathType := networkingv1.PathTypeImplementationSpecific
+
+	ingress := networkingv1.Ingress{
+		ObjectMeta: ingressParams.ObjectMeta,
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: ingressParams.IngressSpecParams.IngressDomain,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: ingressParams.IngressSpecParams.ServiceName,
+											Port: networkingv1.ServiceBackendPort{
+												Number: ingressParams.IngressSpecParams.PortNumber.IntVal,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if ingressClass != "" {
+		ingress.Spec.IngressClassName = &ingressClass
+	}
+	if len(ingressParams.IngressSpecParams.TLSSecretName) != 0 {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts: []string{
+					ingressParams.IngressSpecParams.IngressDomain,
+				},
+				SecretName: ingressParams.IngressSpecParams.TLSSecretName,
+			},
+		}
+	}
+	return ingress
+}
+
+// getIngressV1Protocol returns the protocol of the networking.k8s.io/v1 ingress
+func getIngressV1Protocol(ingress networkingv1.Ingress) string {
+	if ingress.Spec.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// getMachineReadableFormatIngressV1 gives machine readable URL definition of a networking.k8s.io/v1 ingress
+func getMachineReadableFormatIngressV1(i networkingv1.Ingress) URL {
+	url := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: i.Labels[urlLabels.URLLabel]},
+		Spec:       URLSpec{Secure: i.Spec.TLS != nil, Kind: localConfigProvider.INGRESS},
+	}
+	if len(i.Spec.Rules) > 0 && i.Spec.Rules[0].HTTP != nil && len(i.Spec.Rules[0].HTTP.Paths) > 0 {
+		url.Spec.Host = i.Spec.Rules[0].Host
+		url.Spec.Path = i.Spec.Rules[0].HTTP.Paths[0].Path
+		if backend := i.Spec.Rules[0].HTTP.Paths[0].Backend.Service; backend != nil {
+			url.Spec.Port = int(backend.Port.Number)
+		}
+	}
+	if i.Spec.TLS != nil {
+		url.Spec.TLSSecret = i.Spec.TLS[0].SecretName
+	}
+	if i.Spec.IngressClassName != nil {
+		url.Spec.IngressClass = *i.Spec.IngressClassName
+	}
+	return url
+}
+
 type PushParameters struct {
 	LocalConfig      localConfigProvider.LocalConfigProvider
 	URLClient        Client
@@ -514,6 +683,10 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 
 	// get the local URLs
 	for _, url := range localConfigURLs {
+		if url.Kind == localConfigProvider.PORTFORWARD {
+			// the ports of these URLs are forwarded by `odo url forward`, there is nothing to create on the cluster
+			continue
+		}
 		if !parameters.IsRouteSupported && url.Kind == localConfigProvider.ROUTE {
 			// display warning since Host info is missing
 			log.Warningf("Unable to create ingress, missing host information for Endpoint %v, please check instructions on URL creation (refer `odo url create --help`)\n", url.Name)
@@ -547,7 +720,7 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 		if ok {
 			// since the host stored in an ingress
 			// is the combination of name and host of the url
-			if val.Spec.Kind == localConfigProvider.INGRESS {
+			if val.Spec.Kind == localConfigProvider.INGRESS || val.Spec.Kind == localConfigProvider.HTTPROUTE {
 				val.Spec.Host = fmt.Sprintf("%v.%v", urlName, val.Spec.Host)
 			} else if val.Spec.Kind == localConfigProvider.ROUTE {
 				// we don't allow the host input for route based URLs
@@ -560,6 +733,13 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 					val.Spec.Protocol = "http"
 				}
 			}
+			// the secure ingresses without their own secret use the default TLS secret of the component
+			if val.Spec.Kind == localConfigProvider.INGRESS && val.Spec.Secure && val.Spec.TLSSecret == "" {
+				val.Spec.TLSSecret = defaultTLSSecretName(parameters.LocalConfig.GetName())
+			}
+			// the probe is only stored locally
+			val.Spec.Probe = nil
+			urlSpec.Spec.Probe = nil
 			if !reflect.DeepEqual(val.Spec, urlSpec.Spec) {
 				configMismatch = true
 				klog.V(4).Infof("config and cluster mismatch for url %s", urlName)
@@ -567,7 +747,7 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 		}
 
 		if !ok || configMismatch {
-			if urlSpec.Spec.Kind == localConfigProvider.INGRESS && client.GetKubeClient() == nil {
+			if urlSpec.Spec.Kind != localConfigProvider.ROUTE && client.GetKubeClient() == nil {
 				continue
 			}
 			// delete the url
@@ -588,11 +768,26 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 		}
 	}
 
+	// the certificate of the default TLS secret is checked on every push, to renew it before it expires and to keep
+	// every secure URL of the component in it, the ingresses which already exist using the renewed secret
+	tlsHosts := defaultTLSHosts(urlLOCAL)
+	if len(tlsHosts) != 0 && !parameters.IsS2I && client.GetKubeClient() != nil {
+		kClient := client.GetKubeClient()
+		deployment, err := kClient.GetDeploymentByName(parameters.LocalConfig.GetName())
+		if err != nil {
+			return err
+		}
+		_, err = ensureDefaultTLSSecret(kClient, parameters.LocalConfig.GetName(), parameters.LocalConfig.GetApplication(), tlsHosts, generator.GetOwnerReference(deployment))
+		if err != nil {
+			return err
+		}
+	}
+
 	// find URLs to create
 	for urlName, urlInfo := range urlLOCAL {
 		_, ok := urlCLUSTER[urlName]
 		if !ok {
-			if urlInfo.Spec.Kind == localConfigProvider.INGRESS && client.GetKubeClient() == nil {
+			if urlInfo.Spec.Kind != localConfigProvider.ROUTE && client.GetKubeClient() == nil {
 				continue
 			}
 			createParameters := CreateParameters{
@@ -605,6 +800,9 @@ func Push(client *occlient.Client, parameters PushParameters) error {
 				secretName:      urlInfo.Spec.TLSSecret,
 				urlKind:         urlInfo.Spec.Kind,
 				path:            urlInfo.Spec.Path,
+				ingressClass:    urlInfo.Spec.IngressClass,
+				gateway:         urlInfo.Spec.Gateway,
+				tlsHosts:        tlsHosts,
 			}
 			host, err := Create(client, client.GetKubeClient(), createParameters, parameters.IsRouteSupported, parameters.IsS2I)
 			if err != nil {
@@ -33,6 +33,8 @@ import (
 )
 
 func TestCreate(t *testing.T) {
+	defer setTempConfigDir(t)()
+
 	type args struct {
 		componentName    string
 		applicationName  string
@@ -348,7 +350,7 @@ func TestCreate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			client, fakeClientSet := occlient.FakeNew()
-			fakeKClient, fakeKClientSet := kclient.FakeNew()
+			fakeKClient, fakeKClientSet := kclient.FakeNewWithoutOptionalAPIs()
 
 			fakeClientSet.RouteClientset.PrependReactor("create", "routes", func(action ktesting.Action) (bool, runtime.Object, error) {
 				route := action.(ktesting.CreateAction).GetObject().(*routev1.Route)
@@ -680,7 +682,9 @@ func TestPush(t *testing.T) {
 		existingClusterURLs URLList
 		deletedURLs         []URL
 		createdURLs         []URL
-		wantErr             bool
+		// inSync checks that no ingress is created or deleted
+		inSync  bool
+		wantErr bool
 	}{
 		{
 			name: "no urls on local config and cluster",
@@ -988,6 +992,28 @@ func TestPush(t *testing.T) {
 			createdURLs: []URL{},
 			deletedURLs: []URL{},
 		},
+		{
+			name:            "urls with a probe on env file and openshift cluster are in sync",
+			componentName:   "wildfly",
+			applicationName: "app",
+			args:            args{isRouteSupported: true},
+			existingLocalURLs: []localConfigProvider.LocalURL{
+				{
+					Name:  "example-0",
+					Host:  "com",
+					Port:  8080,
+					Path:  "/",
+					Kind:  localConfigProvider.INGRESS,
+					Probe: &localConfigProvider.URLProbe{Type: localConfigProvider.HTTPProbe, Path: "/health"},
+				},
+			},
+			existingClusterURLs: getMachineReadableFormatForList([]URL{
+				getMachineReadableFormatIngress(*fake.GetSingleIngress("example-0", "wildfly", "app")),
+			}),
+			createdURLs: []URL{},
+			deletedURLs: []URL{},
+			inSync:      true,
+		},
 		{
 			name:            "2 (1 ingress,1 route) urls on env file and 2 on openshift cluster (1 ingress,1 route), but they are different",
 			componentName:   "nodejs",
@@ -1382,7 +1408,7 @@ func TestPush(t *testing.T) {
 			mockURLClient.EXPECT().ListFromCluster().Return(tt.existingClusterURLs, nil)
 
 			fakeClient, fakeClientSet := occlient.FakeNew()
-			fakeKClient, fakeKClientSet := kclient.FakeNew()
+			fakeKClient, fakeKClientSet := kclient.FakeNewWithoutOptionalAPIs()
 
 			fakeClient.SetKubeClient(fakeKClient)
 
@@ -1524,6 +1550,14 @@ func TestPush(t *testing.T) {
 						t.Errorf("when urls are in snyc, total action for kubernetes client set should be less than 1")
 					}
 				}
+
+				if tt.inSync {
+					for _, action := range fakeKClientSet.Kubernetes.Actions() {
+						if action.GetVerb() == "create" || action.GetVerb() == "delete" {
+							t.Errorf("when urls are in sync, no url should be created or deleted, got the action %s %s", action.GetVerb(), action.GetResource().Resource)
+						}
+					}
+				}
 			}
 		})
 	}
@@ -1676,3 +1710,40 @@ func TestGetURLString(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMachineReadableFormatIngressV1(t *testing.T) {
+	ingressParams := generator.IngressParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "example-nodejs",
+			Labels: labels.GetLabels("example", "nodejs", "app", true),
+		},
+		IngressSpecParams: generator.IngressSpecParams{
+			ServiceName:   "nodejs",
+			IngressDomain: "example.com",
+			PortNumber:    intstr.FromInt(8080),
+			TLSSecretName: "nodejs-tlssecret",
+			Path:          "/api",
+		},
+	}
+	ingress := generateIngressV1(ingressParams, "nginx")
+
+	want := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: URLSpec{
+			Host:         "example.com",
+			Port:         8080,
+			Secure:       true,
+			Path:         "/api",
+			Kind:         localConfigProvider.INGRESS,
+			TLSSecret:    "nodejs-tlssecret",
+			IngressClass: "nginx",
+		},
+	}
+	if got := getMachineReadableFormatIngressV1(ingress); !reflect.DeepEqual(got, want) {
+		t.Errorf("getMachineReadableFormatIngressV1() mismatch: %v", pretty.Compare(got, want))
+	}
+	if protocol := getIngressV1Protocol(ingress); protocol != "https" {
+		t.Errorf("Got protocol %s, want https", protocol)
+	}
+}
@@ -1,15 +1,67 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
 	"k8s.io/klog"
 )
 
+// HTTPCacheMaxAge is the time after which cached HTTP responses are removed from the cache, even if they could still
+// be served as stale responses when the network is unavailable
+const HTTPCacheMaxAge = 30 * 24 * time.Hour
+
+// ErrNotCached is returned when a resource is requested in offline mode and it is not available in the cache
+var ErrNotCached = errors.New("resource is not available in the local cache")
+
+// HTTPCachedResponse holds the body of a response retrieved through the HTTP cache
+// together with information about its freshness
+type HTTPCachedResponse struct {
+	Body []byte
+	// FromCache is true if the body was served from the cache
+	FromCache bool
+	// Stale is true if the body was served from the cache without being revalidated against the server
+	// because the server couldn't be reached or odo is running in offline mode
+	Stale bool
+	// FetchedAt is the last time the body was retrieved or revalidated from the server
+	FetchedAt time.Time
+}
+
+// httpCacheEntry is a cached response stored in the cache directory along with the validators needed to revalidate it
+type httpCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+	Body         []byte    `json:"body"`
+}
+
+// GetCacheDir returns the directory under which odo caches data downloaded from devfile registries
+func GetCacheDir() string {
+	return cacheDir
+}
+
+// getDefaultCacheDir returns the user cache directory for odo and falls back to the temporary directory
+// if the user cache directory can't be determined
+func getDefaultCacheDir() string {
+	if len(customHomeDir) != 0 {
+		return filepath.Join(customHomeDir, ".cache", "odo")
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "odocache")
+	}
+	return filepath.Join(dir, "odo")
+}
+
 // CleanDefaultHTTPCacheDir cleans the default directory used for HTTP caching
 func CleanDefaultHTTPCacheDir() error {
 	return cleanDefaultHTTPCacheDir(filesystem.DefaultFs{})
@@ -49,3 +101,117 @@ func cleanHttpCache(cacheDir string, cacheTime time.Duration) error {
 	}
 	return nil
 }
+
+// httpCacheFile returns the path of the file caching the response for the given URL
+func httpCacheFile(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(httpCacheDir, hex.EncodeToString(sum[:]))
+}
+
+// readHTTPCacheEntry reads the cached response for the given URL, it returns nil if there is none
+func readHTTPCacheEntry(url string) *httpCacheEntry {
+	data, err := ioutil.ReadFile(httpCacheFile(url))
+	if err != nil {
+		return nil
+	}
+	var entry httpCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		klog.V(4).Infof("Ignoring invalid cache entry for %s", url)
+		return nil
+	}
+	return &entry
+}
+
+// writeHTTPCacheEntry stores the entry in the cache directory
+func writeHTTPCacheEntry(entry httpCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(httpCacheFile(entry.URL), data, ModeReadWriteFile)
+}
+
+// HTTPGetCachedRequest gets resource contents given URL and token (if applicable) using the HTTP cache.
+// Cached responses younger than cacheFor minutes are returned without contacting the server, older ones are
+// revalidated with a conditional request using the stored ETag and Last-Modified validators.
+// If the server can't be reached, the cached response is returned and marked as stale.
+// In offline mode the server is never contacted and ErrNotCached is returned for resources that aren't cached.
+func HTTPGetCachedRequest(request HTTPRequestParams, cacheFor int, offline bool) (HTTPCachedResponse, error) {
+	// make sure that cache directory exists and drop the entries that are too old to be useful
+	if err := os.MkdirAll(httpCacheDir, 0750); err != nil {
+		klog.V(4).Infof("Unable to setup cache: %v", err)
+	} else if err := cleanHttpCache(httpCacheDir, HTTPCacheMaxAge); err != nil {
+		klog.V(4).Infof("Unable to clean up cache directory: %v", err)
+	}
+
+	entry := readHTTPCacheEntry(request.URL)
+	if offline {
+		if entry == nil {
+			return HTTPCachedResponse{}, errors.Wrapf(ErrNotCached, "unable to get %s in offline mode", request.URL)
+		}
+		klog.V(4).Infof("Offline mode, cached response from %s used for %s", entry.FetchedAt, request.URL)
+		return HTTPCachedResponse{Body: entry.Body, FromCache: true, Stale: true, FetchedAt: entry.FetchedAt}, nil
+	}
+
+	if entry != nil && entry.FetchedAt.Add(time.Duration(cacheFor)*time.Minute).After(time.Now()) {
+		klog.V(4).Infof("Cached response used for %s", request.URL)
+		return HTTPCachedResponse{Body: entry.Body, FromCache: true, FetchedAt: entry.FetchedAt}, nil
+	}
+
+	req, err := newHTTPGetRequest(request)
+	if err != nil {
+		return HTTPCachedResponse{}, err
+	}
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	klog.V(4).Infof("HTTPGetCachedRequest: %s", req.URL.String())
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		if entry != nil {
+			klog.V(4).Infof("Unable to reach %s, stale cached response used: %v", request.URL, err)
+			return HTTPCachedResponse{Body: entry.Body, FromCache: true, Stale: true, FetchedAt: entry.FetchedAt}, nil
+		}
+		return HTTPCachedResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		klog.V(4).Infof("Cached response for %s revalidated", request.URL)
+		entry.FetchedAt = time.Now()
+		if err = writeHTTPCacheEntry(*entry); err != nil {
+			klog.V(4).Infof("Unable to update cache entry for %s: %v", request.URL, err)
+		}
+		return HTTPCachedResponse{Body: entry.Body, FromCache: true, FetchedAt: entry.FetchedAt}, nil
+	}
+
+	// the server is having issues, a stale response is still more useful than an error
+	if resp.StatusCode >= http.StatusInternalServerError && entry != nil {
+		klog.V(4).Infof("Server error %d for %s, stale cached response used", resp.StatusCode, request.URL)
+		return HTTPCachedResponse{Body: entry.Body, FromCache: true, Stale: true, FetchedAt: entry.FetchedAt}, nil
+	}
+
+	body, err := readHTTPResponse(request, resp)
+	if err != nil {
+		return HTTPCachedResponse{}, err
+	}
+
+	newEntry := httpCacheEntry{
+		URL:          request.URL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+		Body:         body,
+	}
+	if err = writeHTTPCacheEntry(newEntry); err != nil {
+		klog.V(4).Infof("Response from %s won't be cached: %v", request.URL, err)
+	}
+
+	return HTTPCachedResponse{Body: body, FetchedAt: newEntry.FetchedAt}, nil
+}
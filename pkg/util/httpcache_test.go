@@ -1,11 +1,15 @@
 package util
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
 )
 
 func TestCleanDefaultHTTPCacheDir(t *testing.T) {
@@ -40,3 +44,102 @@ func TestCleanDefaultHTTPCacheDir(t *testing.T) {
 	}
 
 }
+
+func TestHTTPGetCachedRequest(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "odohttpcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	oldHTTPCacheDir := httpCacheDir
+	httpCacheDir = tempDir
+	defer func() { httpCacheDir = oldHTTPCacheDir }()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		if _, err := rw.Write([]byte("OK")); err != nil {
+			t.Error(err)
+		}
+	}))
+	request := HTTPRequestParams{URL: server.URL}
+
+	// offline mode without cached response
+	_, err = HTTPGetCachedRequest(request, 15, true)
+	if errors.Cause(err) != ErrNotCached {
+		t.Errorf("expected ErrNotCached in offline mode, got: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cacheFor     int
+		offline      bool
+		closeServer  bool
+		wantRequests int
+		wantCached   bool
+		wantStale    bool
+	}{
+		{
+			name:         "Case 1: response is downloaded and cached",
+			cacheFor:     15,
+			wantRequests: 1,
+		},
+		{
+			name:         "Case 2: fresh cached response is used without contacting the server",
+			cacheFor:     15,
+			wantRequests: 1,
+			wantCached:   true,
+		},
+		{
+			name:         "Case 3: expired cached response is revalidated",
+			cacheFor:     0,
+			wantRequests: 2,
+			wantCached:   true,
+		},
+		{
+			name:         "Case 4: cached response is used in offline mode",
+			cacheFor:     0,
+			offline:      true,
+			wantRequests: 2,
+			wantCached:   true,
+			wantStale:    true,
+		},
+		{
+			name:         "Case 5: stale response is used when the server can't be reached",
+			cacheFor:     0,
+			closeServer:  true,
+			wantRequests: 2,
+			wantCached:   true,
+			wantStale:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.closeServer {
+				server.Close()
+			}
+			got, err := HTTPGetCachedRequest(request, tt.cacheFor, tt.offline)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got.Body) != "OK" {
+				t.Errorf("got body %q, want %q", string(got.Body), "OK")
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests to the server, want %d", requests, tt.wantRequests)
+			}
+			if got.FromCache != tt.wantCached {
+				t.Errorf("got FromCache %t, want %t", got.FromCache, tt.wantCached)
+			}
+			if got.Stale != tt.wantStale {
+				t.Errorf("got Stale %t, want %t", got.Stale, tt.wantStale)
+			}
+		})
+	}
+}
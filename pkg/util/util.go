@@ -1,9 +1,11 @@
 package util
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"crypto/rand"
 	"fmt"
 	"io"
@@ -29,8 +31,6 @@ import (
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/fatih/color"
 	"github.com/gobwas/glob"
-	"github.com/gregjones/httpcache"
-	"github.com/gregjones/httpcache/diskcache"
 	"github.com/openshift/odo/pkg/testingutil/filesystem"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -46,9 +46,6 @@ const (
 	CredentialPrefix      = "odo-"           // CredentialPrefix is the prefix of the credential that uses to access secure registry
 )
 
-// httpCacheDir determines directory where odo will cache HTTP respones
-var httpCacheDir = filepath.Join(os.TempDir(), "odohttpcache")
-
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyz")
 
 // 63 is the max length of a DeploymentConfig in Openshift and we also have to take into account
@@ -59,6 +56,12 @@ const maxAllowedNamespacedStringLength = 63 - len("-s2idata") - 1
 // note for mocking purpose ONLY
 var customHomeDir = os.Getenv("CUSTOM_HOMEDIR")
 
+// cacheDir determines directory where odo will cache data downloaded from devfile registries
+var cacheDir = getDefaultCacheDir()
+
+// httpCacheDir determines directory where odo will cache HTTP respones
+var httpCacheDir = filepath.Join(cacheDir, "httpcache")
+
 // ResourceRequirementInfo holds resource quantity before transformation into its appropriate form in container spec
 type ResourceRequirementInfo struct {
 	ResourceType corev1.ResourceName
@@ -707,7 +710,33 @@ func GetRemoteFilesMarkedForDeletion(delSrcRelPaths []string, remoteFolder strin
 // HTTPGetRequest gets resource contents given URL and token (if applicable)
 // cacheFor determines how long the response should be cached (in minutes), 0 for no caching
 func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
+	if cacheFor > 0 {
+		resp, err := HTTPGetCachedRequest(request, cacheFor, false)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+
 	// Build http request
+	req, err := newHTTPGetRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("HTTPGetRequest: %s", req.URL.String())
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return readHTTPResponse(request, resp)
+}
+
+// newHTTPGetRequest builds the GET request for the given parameters
+func newHTTPGetRequest(request HTTPRequestParams) (*http.Request, error) {
 	req, err := http.NewRequest("GET", request.URL, nil)
 	if err != nil {
 		return nil, err
@@ -716,51 +745,21 @@ func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 		bearer := "Bearer " + request.Token
 		req.Header.Add("Authorization", bearer)
 	}
+	return req, nil
+}
 
-	httpClient := &http.Client{
+// newHTTPClient returns the HTTP client used for all odo HTTP requests
+func newHTTPClient() *http.Client {
+	return &http.Client{
 		Transport: &http.Transport{
 			ResponseHeaderTimeout: ResponseHeaderTimeout,
 		},
 		Timeout: HTTPRequestTimeout,
 	}
+}
 
-	klog.V(4).Infof("HTTPGetRequest: %s", req.URL.String())
-
-	if cacheFor > 0 {
-		// if there is an error during cache setup we show warning and continue without using cache
-		cacheError := false
-		httpCacheTime := time.Duration(cacheFor) * time.Minute
-
-		// make sure that cache directory exists
-		err = os.MkdirAll(httpCacheDir, 0750)
-		if err != nil {
-			cacheError = true
-			klog.WarningDepth(4, "Unable to setup cache: ", err)
-		}
-		err = cleanHttpCache(httpCacheDir, httpCacheTime)
-		if err != nil {
-			cacheError = true
-			klog.WarningDepth(4, "Unable to clean up cache directory: ", err)
-		}
-
-		if !cacheError {
-			httpClient.Transport = httpcache.NewTransport(diskcache.New(httpCacheDir))
-			klog.V(4).Infof("Response will be cached in %s for %s", httpCacheDir, httpCacheTime)
-		} else {
-			klog.V(4).Info("Response won't be cached.")
-		}
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.Header.Get(httpcache.XFromCache) != "" {
-		klog.V(4).Infof("Cached response used.")
-	}
-
+// readHTTPResponse reads the body of the response and returns an error for non 1xx / 2xx statuses
+func readHTTPResponse(request HTTPRequestParams, resp *http.Response) ([]byte, error) {
 	// We have a non 1xx / 2xx status, return an error
 	if (resp.StatusCode - 300) > 0 {
 		return nil, errors.Errorf("fail to retrive %s: %s", request.URL, http.StatusText(resp.StatusCode))
@@ -833,6 +832,12 @@ func ConvertGitSSHRemoteToHTTPS(remote string) string {
 // takes an absolute path prefixed with file:// and extracts it to a destination.
 // pathToUnzip specifies the path within the zip folder to extract
 func GetAndExtractZip(zipURL string, destination string, pathToUnzip string, starterToken string) error {
+	return GetAndExtractZipWithVerification(zipURL, destination, pathToUnzip, starterToken, nil)
+}
+
+// GetAndExtractZipWithVerification is GetAndExtractZip calling verify with the path of the downloaded zip file
+// before extracting it, the zip file isn't extracted if verify returns an error
+func GetAndExtractZipWithVerification(zipURL string, destination string, pathToUnzip string, starterToken string, verify func(pathToZip string) error) error {
 	if zipURL == "" {
 		return errors.Errorf("Empty zip url: %s", zipURL)
 	}
@@ -870,6 +875,12 @@ func GetAndExtractZip(zipURL string, destination string, pathToUnzip string, sta
 		return errors.Errorf("Invalid Zip URL: %s . Should either be prefixed with file://, http:// or https://", zipURL)
 	}
 
+	if verify != nil {
+		if err := verify(pathToZip); err != nil {
+			return err
+		}
+	}
+
 	filenames, err := Unzip(pathToZip, destination, pathToUnzip)
 	if err != nil {
 		return err
@@ -981,6 +992,72 @@ func Unzip(src, dest, pathToUnzip string) ([]string, error) {
 	return filenames, nil
 }
 
+// Untar will decompress a gzipped tar archive src into the dest directory
+// and return the paths of the extracted files and directories
+func Untar(src, dest string) ([]string, error) {
+	var filenames []string
+
+	f, err := os.Open(src)
+	if err != nil {
+		return filenames, err
+	}
+	defer f.Close()
+
+	gzReader, err := gzip.NewReader(f)
+	if err != nil {
+		return filenames, err
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return filenames, err
+		}
+
+		fpath := filepath.Join(dest, filepath.FromSlash(header.Name))
+		// Check for ZipSlip. More Info: http://bit.ly/2MsjAWE
+		if fpath != filepath.Clean(dest) && !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return filenames, fmt.Errorf("%s: illegal file path", fpath)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(fpath, os.ModePerm); err != nil {
+				return filenames, err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+				return filenames, err
+			}
+
+			outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return filenames, err
+			}
+
+			// limit the number of bytes copied from a file, same as for zip archives
+			_, err = io.Copy(outFile, io.LimitReader(tarReader, 100*1024*1024))
+
+			// Close the file without defer to close before next iteration of loop
+			outFile.Close()
+
+			if err != nil {
+				return filenames, err
+			}
+		default:
+			klog.V(4).Infof("Skipping %s with unsupported type %v", header.Name, header.Typeflag)
+			continue
+		}
+
+		filenames = append(filenames, fpath)
+	}
+	return filenames, nil
+}
+
 // DownloadFileWithCache downloads the file to the filepath given URL and token (if applicable)
 // cacheFor determines how long the response should be cached (in minutes), 0 for no caching
 func DownloadFileWithCache(params DownloadParams, cacheFor int) error {
@@ -0,0 +1,254 @@
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+// CommandFunc returns the command run for a component
+type CommandFunc func(component Component) *exec.Cmd
+
+// Command returns the odo command running the arguments in the context of the component
+func Command(args ...string) CommandFunc {
+	return func(component Component) *exec.Cmd {
+		return exec.Command(os.Args[0], append(args, "--context", component.Context)...) // #nosec G204
+	}
+}
+
+// ForwardedFlags returns the flags set on the command line, to pass them to the commands run for the components,
+// except the skipped flags
+func ForwardedFlags(flags *pflag.FlagSet, skipped ...string) []string {
+	var args []string
+	flags.Visit(func(flag *pflag.Flag) {
+		for _, name := range skipped {
+			if flag.Name == name {
+				return
+			}
+		}
+		if value, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, item := range value.GetSlice() {
+				args = append(args, fmt.Sprintf("--%s=%s", flag.Name, item))
+			}
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+	return args
+}
+
+// RunOrdered runs the commands of the components concurrently, the command of a component starting once the commands of
+// its dependencies succeeded, or once the commands of the components depending on it succeeded if reverse is true.
+// The output of the commands is written to out, each line being prefixed with the name of the component
+func RunOrdered(components []Component, reverse bool, out io.Writer, command CommandFunc) error {
+	prerequisites := map[string][]string{}
+	for _, component := range components {
+		for _, dependency := range component.DependsOn {
+			if reverse {
+				prerequisites[dependency] = append(prerequisites[dependency], component.Name)
+			} else {
+				prerequisites[component.Name] = append(prerequisites[component.Name], dependency)
+			}
+		}
+	}
+
+	mux := newMultiplexer(out, components)
+	done := map[string]chan struct{}{}
+	for _, component := range components {
+		done[component.Name] = make(chan struct{})
+	}
+	var lock sync.Mutex
+	failed := map[string]bool{}
+	var wg sync.WaitGroup
+	for _, component := range components {
+		wg.Add(1)
+		go func(component Component) {
+			defer wg.Done()
+			defer close(done[component.Name])
+			skip := false
+			for _, prerequisite := range prerequisites[component.Name] {
+				<-done[prerequisite]
+				lock.Lock()
+				skip = skip || failed[prerequisite]
+				lock.Unlock()
+			}
+			var err error
+			if skip {
+				err = errors.New("skipped, as a component it depends on failed")
+			} else {
+				err = mux.run(component, command(component))
+			}
+			if err != nil {
+				mux.printf(component, "%v\n", err)
+				lock.Lock()
+				failed[component.Name] = true
+				lock.Unlock()
+			}
+		}(component)
+	}
+	wg.Wait()
+	return failure(failed)
+}
+
+// RunAll runs the commands of the components concurrently and waits for all of them. When prepare is not nil, the
+// command of a component starts once its prepare command succeeded, the prepare command of a component starting once
+// the prepare commands of its dependencies succeeded. The output of the commands is written to out, each line being
+// prefixed with the name of the component
+func RunAll(components []Component, out io.Writer, prepare CommandFunc, command CommandFunc) error {
+	mux := newMultiplexer(out, components)
+	prepared := map[string]chan struct{}{}
+	for _, component := range components {
+		prepared[component.Name] = make(chan struct{})
+	}
+	var lock sync.Mutex
+	failed := map[string]bool{}
+	fail := func(component Component, err error) {
+		mux.printf(component, "%v\n", err)
+		lock.Lock()
+		failed[component.Name] = true
+		lock.Unlock()
+	}
+	// unprepared records the components whose prepare command did not succeed, apart from the failures of their commands
+	unprepared := map[string]bool{}
+	var wg sync.WaitGroup
+	for _, component := range components {
+		wg.Add(1)
+		go func(component Component) {
+			defer wg.Done()
+			// the failure of the prepare command is recorded before its dependents are released
+			if err := func() (err error) {
+				defer close(prepared[component.Name])
+				skip := false
+				for _, dependency := range component.DependsOn {
+					if ch, ok := prepared[dependency]; ok {
+						<-ch
+					}
+					lock.Lock()
+					skip = skip || unprepared[dependency]
+					lock.Unlock()
+				}
+				if skip {
+					err = errors.New("skipped, as a component it depends on failed")
+				} else if prepare != nil {
+					err = mux.run(component, prepare(component))
+				}
+				if err != nil {
+					fail(component, err)
+					lock.Lock()
+					unprepared[component.Name] = true
+					lock.Unlock()
+				}
+				return err
+			}(); err != nil {
+				return
+			}
+			if err := mux.run(component, command(component)); err != nil {
+				fail(component, err)
+			}
+		}(component)
+	}
+	wg.Wait()
+	return failure(failed)
+}
+
+// failure returns an error listing the failed components, nil if none failed
+func failure(failed map[string]bool) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return errors.Errorf("the command did not succeed for the components %s", strings.Join(names, ", "))
+}
+
+// multiplexer writes the output of the commands of several components to the same writer, line by line
+type multiplexer struct {
+	lock  sync.Mutex
+	out   io.Writer
+	width int
+}
+
+func newMultiplexer(out io.Writer, components []Component) *multiplexer {
+	mux := &multiplexer{out: out}
+	for _, component := range components {
+		if len(component.Name) > mux.width {
+			mux.width = len(component.Name)
+		}
+	}
+	return mux
+}
+
+// printf writes the formatted line prefixed with the name of the component
+func (m *multiplexer) printf(component Component, format string, a ...interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	fmt.Fprintf(m.out, "%-*s | %s", m.width, component.Name, fmt.Sprintf(format, a...))
+}
+
+// run runs the command, writing its output prefixed with the name of the component
+func (m *multiplexer) run(component Component, cmd *exec.Cmd) error {
+	wait, err := m.start(component, cmd)
+	if err != nil {
+		return err
+	}
+	return wait()
+}
+
+// start starts the command, and returns the function waiting for the command and flushing its output
+func (m *multiplexer) start(component Component, cmd *exec.Cmd) (func() error, error) {
+	writer := &lineWriter{mux: m, component: component}
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return func() error {
+		err := cmd.Wait()
+		writer.flush()
+		return err
+	}, nil
+}
+
+// lineWriter writes the complete lines written to it to the multiplexer
+type lineWriter struct {
+	lock      sync.Mutex
+	mux       *multiplexer
+	component Component
+	buffer    bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.buffer.Write(p)
+	for {
+		line, err := w.buffer.ReadString('\n')
+		if err != nil {
+			// keep the incomplete line until the rest of it is written
+			w.buffer.WriteString(line)
+			return len(p), nil
+		}
+		w.mux.printf(w.component, "%s", line)
+	}
+}
+
+// flush writes the last line, if it is not terminated by a newline
+func (w *lineWriter) flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.buffer.Len() > 0 {
+		w.mux.printf(w.component, "%s\n", w.buffer.String())
+		w.buffer.Reset()
+	}
+}
@@ -0,0 +1,165 @@
+package workspace
+
+import (
+	"bytes"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestForwardedFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("push", pflag.ContinueOnError)
+	flags.Bool("all", false, "")
+	flags.Bool("show-log", false, "")
+	flags.String("context", "", "")
+	flags.String("run-command", "", "")
+	flags.StringSlice("ignore", nil, "")
+	if err := flags.Parse([]string{"--all", "--show-log", "--context", "dir", "--ignore", "a,b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ForwardedFlags(flags, "all", "context")
+	want := []string{"--ignore=a", "--ignore=b", "--show-log=true"}
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got the flags %v, want %v", got, want)
+	}
+}
+
+func TestRunOrdered(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands use sh")
+	}
+	components := []Component{
+		{Name: "frontend", DependsOn: []string{"backend"}},
+		{Name: "backend", DependsOn: []string{"db"}},
+		{Name: "db"},
+	}
+
+	tests := []struct {
+		name    string
+		reverse bool
+		failing string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Case 1: the components are run after their dependencies",
+			want: []string{"db       | run", "backend  | run", "frontend | run"},
+		},
+		{
+			name:    "Case 2: the components are run after the components depending on them",
+			reverse: true,
+			want:    []string{"frontend | run", "backend  | run", "db       | run"},
+		},
+		{
+			name:    "Case 3: the components depending on a failed component are skipped",
+			failing: "backend",
+			want: []string{
+				"db       | run",
+				"backend  | run",
+				"backend  | no newline",
+				"backend  | exit status 1",
+				"frontend | skipped, as a component it depends on failed",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := RunOrdered(components, tt.reverse, &out, func(component Component) *exec.Cmd {
+				script := "echo run"
+				if component.Name == tt.failing {
+					script += "; printf 'no newline' >&2; exit 1"
+				}
+				return exec.Command("sh", "-c", script)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+			got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got the output %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands use sh")
+	}
+	components := []Component{
+		{Name: "frontend", DependsOn: []string{"backend"}},
+		{Name: "backend", DependsOn: []string{"db"}},
+		{Name: "db"},
+	}
+
+	tests := []struct {
+		name    string
+		failing string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Case 1: the components are prepared after their dependencies, then run",
+			want: []string{
+				"db       | prepare",
+				"backend  | prepare",
+				"frontend | prepare",
+			},
+		},
+		{
+			name:    "Case 2: the components depending on a component failing to prepare are skipped",
+			failing: "backend",
+			want: []string{
+				"db       | prepare",
+				"backend  | prepare",
+				"backend  | exit status 1",
+				"frontend | skipped, as a component it depends on failed",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := RunAll(components, &out, func(component Component) *exec.Cmd {
+				script := "echo prepare"
+				if component.Name == tt.failing {
+					script += "; exit 1"
+				}
+				return exec.Command("sh", "-c", script)
+			}, func(component Component) *exec.Cmd {
+				return exec.Command("echo", "run")
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+			var prepared []string
+			run := map[string]bool{}
+			for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+				if strings.HasSuffix(line, "| run") {
+					run[strings.TrimSpace(strings.Split(line, "|")[0])] = true
+					continue
+				}
+				prepared = append(prepared, line)
+			}
+			if !reflect.DeepEqual(prepared, tt.want) {
+				t.Errorf("got the output %q, want %q", prepared, tt.want)
+			}
+			for _, component := range components {
+				wantRun := tt.failing == "" || component.Name == "db"
+				if run[component.Name] != wantRun {
+					t.Errorf("got the component %s run: %v, want %v", component.Name, run[component.Name], wantRun)
+				}
+			}
+		})
+	}
+}
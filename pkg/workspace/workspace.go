@@ -0,0 +1,192 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/util"
+)
+
+const (
+	// FileName is the name of the workspace file, in the .odo directory of the root of the workspace
+	FileName = "workspace.yaml"
+
+	workspaceKind       = "Workspace"
+	workspaceAPIVersion = "odo.dev/v1alpha1"
+)
+
+// Workspace lists the components developed together, e.g. the microservices of an application in a monorepo
+type Workspace struct {
+	metav1.TypeMeta `yaml:",inline"`
+
+	// Components are the component contexts of the workspace
+	Components []ComponentReference `yaml:"Components,omitempty"`
+}
+
+// ComponentReference is a component context of the workspace
+type ComponentReference struct {
+	// Context is the context directory of the component, relative to the root of the workspace
+	Context string `yaml:"Context"`
+	// DependsOn are the names of the components pushed before this component
+	DependsOn []string `yaml:"DependsOn,omitempty"`
+}
+
+// WorkspaceInfo holds the workspace and the location of its file
+type WorkspaceInfo struct {
+	Workspace `yaml:",inline"`
+
+	// Filename is the path of the workspace file
+	Filename string `yaml:"-"`
+	// Root is the root directory of the workspace, the component contexts are relative to it
+	Root string `yaml:"-"`
+}
+
+// Component is a component of the workspace
+type Component struct {
+	Name      string   `json:"name"`
+	Context   string   `json:"context"`
+	Project   string   `json:"project,omitempty"`
+	App       string   `json:"app,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// ComponentList is the machine readable output of the components of a workspace
+type ComponentList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []Component `json:"items"`
+}
+
+// NewWorkspace returns a workspace with the component contexts
+func NewWorkspace(components []ComponentReference) Workspace {
+	return Workspace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       workspaceKind,
+			APIVersion: workspaceAPIVersion,
+		},
+		Components: components,
+	}
+}
+
+// GetFilename returns the path of the workspace file whose root directory is dir
+func GetFilename(dir string) string {
+	return filepath.Join(dir, ".odo", FileName)
+}
+
+// Find returns the workspace of dir, the workspace file being in dir or in its closest parent
+func Find(dir string) (*WorkspaceInfo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		if filename := GetFilename(current); util.CheckPathExists(filename) {
+			wi := WorkspaceInfo{Filename: filename, Root: current}
+			if err = util.GetFromFile(&wi.Workspace, filename); err != nil {
+				return nil, errors.Wrapf(err, "unable to read the workspace file %s", filename)
+			}
+			return &wi, nil
+		}
+		if filepath.Dir(current) == current {
+			return nil, errors.Errorf("%s is not in a workspace, run `odo workspace init` in the root directory of the workspace to create one", dir)
+		}
+	}
+}
+
+// Write writes the workspace to the workspace file of dir
+func Write(dir string, workspace Workspace) error {
+	return util.WriteToFile(&workspace, GetFilename(dir))
+}
+
+// GetComponents returns the components of the workspace, ordered so that each component is after its dependencies
+func (wi *WorkspaceInfo) GetComponents() ([]Component, error) {
+	var components []Component
+	for _, reference := range wi.Components {
+		context := filepath.Join(wi.Root, filepath.FromSlash(reference.Context))
+		if _, err := os.Stat(context); err != nil {
+			return nil, errors.Wrapf(err, "invalid component context %s in %s", reference.Context, wi.Filename)
+		}
+		envInfo, err := envinfo.NewEnvSpecificInfo(context)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the component settings of %s", context)
+		}
+		if !envInfo.Exists() || envInfo.GetName() == "" {
+			return nil, errors.Errorf("%s doesn't contain a component, run `odo create` in it or remove it from %s", context, wi.Filename)
+		}
+		components = append(components, Component{
+			Name:      envInfo.GetName(),
+			Context:   context,
+			Project:   envInfo.GetNamespace(),
+			App:       envInfo.GetApplication(),
+			DependsOn: reference.DependsOn,
+		})
+	}
+	return Order(components)
+}
+
+// Order returns the components ordered so that each component is after its dependencies, keeping the order of the
+// components otherwise; it returns an error if a dependency is missing or if the dependencies have a cycle
+func Order(components []Component) ([]Component, error) {
+	indexes := map[string]int{}
+	for i, component := range components {
+		if _, ok := indexes[component.Name]; ok {
+			return nil, errors.Errorf("the workspace contains several components named %s", component.Name)
+		}
+		indexes[component.Name] = i
+	}
+	for _, component := range components {
+		for _, dependency := range component.DependsOn {
+			if _, ok := indexes[dependency]; !ok {
+				return nil, errors.Errorf("the component %s depends on %s, which is not in the workspace", component.Name, dependency)
+			}
+		}
+	}
+
+	var ordered []Component
+	added := map[string]bool{}
+	for len(ordered) < len(components) {
+		progress := false
+		for _, component := range components {
+			if added[component.Name] || !allAdded(component.DependsOn, added) {
+				continue
+			}
+			ordered = append(ordered, component)
+			added[component.Name] = true
+			progress = true
+		}
+		if !progress {
+			var cycle []string
+			for _, component := range components {
+				if !added[component.Name] {
+					cycle = append(cycle, component.Name)
+				}
+			}
+			return nil, errors.Errorf("the dependencies of the components %v have a cycle", cycle)
+		}
+	}
+	return ordered, nil
+}
+
+// allAdded returns true if all the names are added
+func allAdded(names []string, added map[string]bool) bool {
+	for _, name := range names {
+		if !added[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewComponentList returns the machine readable output of the components
+func NewComponentList(components []Component) ComponentList {
+	return ComponentList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: workspaceAPIVersion,
+		},
+		Items: components,
+	}
+}
@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: no dependencies, the order is kept",
+			components: []Component{{Name: "frontend"}, {Name: "backend"}},
+			want:       []string{"frontend", "backend"},
+		},
+		{
+			name: "Case 2: the components are after their dependencies",
+			components: []Component{
+				{Name: "frontend", DependsOn: []string{"backend", "auth"}},
+				{Name: "backend", DependsOn: []string{"database"}},
+				{Name: "auth"},
+				{Name: "database"},
+			},
+			want: []string{"auth", "database", "backend", "frontend"},
+		},
+		{
+			name:       "Case 3: missing dependency",
+			components: []Component{{Name: "frontend", DependsOn: []string{"backend"}}},
+			wantErr:    true,
+		},
+		{
+			name: "Case 4: cycle",
+			components: []Component{
+				{Name: "frontend", DependsOn: []string{"backend"}},
+				{Name: "backend", DependsOn: []string{"frontend"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Case 5: duplicate names",
+			components: []Component{{Name: "frontend"}, {Name: "frontend"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := Order(tt.components)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got the error %v, want an error: %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, component := range ordered {
+				got = append(got, component.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got the order %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		filepath.Join(dir, "backend", ".odo", "env", "env.yaml"):  "ComponentSettings:\n  Name: backend\n  Project: myproject\n  AppName: app\n",
+		filepath.Join(dir, "frontend", ".odo", "env", "env.yaml"): "ComponentSettings:\n  Name: frontend\n  Project: myproject\n  AppName: app\n",
+	}
+	for filename, data := range files {
+		if err = os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = Write(dir, NewWorkspace([]ComponentReference{
+		{Context: "frontend", DependsOn: []string{"backend"}},
+		{Context: "backend"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Find(filepath.Dir(dir)); err == nil {
+		t.Errorf("got no error for a directory outside of the workspace")
+	}
+
+	wi, err := Find(filepath.Join(dir, "frontend"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wi.Root != dir {
+		t.Errorf("got the root %s, want %s", wi.Root, dir)
+	}
+	got, err := wi.GetComponents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Component{
+		{Name: "backend", Context: filepath.Join(dir, "backend"), Project: "myproject", App: "app"},
+		{Name: "frontend", Context: filepath.Join(dir, "frontend"), Project: "myproject", App: "app", DependsOn: []string{"backend"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got the components %+v, want %+v", got, want)
+	}
+}